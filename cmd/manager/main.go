@@ -29,6 +29,7 @@ import (
 
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/controller"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/logger"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	kubemetrics "github.com/operator-framework/operator-sdk/pkg/kube-metrics"
@@ -39,6 +40,7 @@ import (
 	"github.com/spf13/pflag"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
@@ -95,10 +97,16 @@ func main() {
 	}
 
 	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{
-		Namespace:          namespace,
+	options := manager.Options{
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
-	})
+	}
+	// Watch every namespace listed in WATCH_NAMESPACE, an empty value watches all of them
+	if watched := infrastructure.GetWatchedNamespaces(namespace); len(watched) == 1 {
+		options.Namespace = watched[0]
+	} else if len(watched) > 1 {
+		options.NewCache = cache.MultiNamespacedCacheBuilder(watched)
+	}
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -22,6 +22,10 @@ export GOPATH=$(go env GOPATH)
 operator-sdk generate k8s
 operator-sdk generate crds --crd-version=v1beta1
 
+echo "Generating cluster-wide operator role"
+# Generate cluster_role.yaml from the operator Role, the first document of role.yaml
+sed -n '/^---$/q;p' deploy/role.yaml | sed 's/^kind: Role$/kind: ClusterRole/' > deploy/cluster_role.yaml
+
 echo "Generating YAML installer"
 # Generate kogito-operator.yaml
 rm kogito-operator.yaml
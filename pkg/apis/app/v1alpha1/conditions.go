@@ -55,6 +55,8 @@ const (
 	BuildRuntimeFailedReason ReasonType = "BuildRuntimeFailedReason"
 	// KogitoInfraNotReadyReason - Unable to deploy Kogito Infra
 	KogitoInfraNotReadyReason ReasonType = "KogitoInfraNotReadyReason"
+	// KogitoInfraNotSupportedReason - Unable to use the referenced Kogito Infra from the service namespace
+	KogitoInfraNotSupportedReason ReasonType = "KogitoInfraNotSupportedReason"
 	// UnknownReason - Unable to determine the error
 	UnknownReason ReasonType = "Unknown"
 	// RolloutDeploymentFailedReason - Unable to rollout deployment
@@ -117,6 +117,7 @@ type KogitoServiceSpecInterface interface {
 	GetInfra() []string
 	AddInfra(name string)
 	GetMonitoring() Monitoring
+	GetMessaging() Messaging
 	GetConfig() map[string]string
 }
 
@@ -181,6 +182,7 @@ type KogitoServiceSpec struct {
 	PropertiesConfigMap string `json:"propertiesConfigMap,omitempty"`
 
 	// Infra provides list of dependent KogitoInfra objects.
+	// KogitoInfra objects of other namespaces are referenced as namespace/name.
 	// +optional
 	Infra []string `json:"infra,omitempty"`
 
@@ -188,6 +190,10 @@ type KogitoServiceSpec struct {
 	// +optional
 	Monitoring Monitoring `json:"monitoring,omitempty"`
 
+	// Settings of the messaging resources, such as Kafka topics, used by the service
+	// +optional
+	Messaging Messaging `json:"messaging,omitempty"`
+
 	// +optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Configs"
@@ -314,6 +320,9 @@ func (k *KogitoServiceSpec) AddInfra(name string) {
 // GetMonitoring ...
 func (k *KogitoServiceSpec) GetMonitoring() Monitoring { return k.Monitoring }
 
+// GetMessaging ...
+func (k *KogitoServiceSpec) GetMessaging() Messaging { return k.Messaging }
+
 // GetConfig ...
 func (k *KogitoServiceSpec) GetConfig() map[string]string {
 	return k.Config
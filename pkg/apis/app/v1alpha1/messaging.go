@@ -0,0 +1,202 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// KafkaTopicCleanupPolicy defines the retention policy of the old segments of a Kafka topic
+type KafkaTopicCleanupPolicy string
+
+const (
+	// KafkaTopicDeletePolicy discards the old segments when their retention time or size limit has been reached
+	KafkaTopicDeletePolicy KafkaTopicCleanupPolicy = "delete"
+	// KafkaTopicCompactPolicy retains at least the last known value for each message key
+	KafkaTopicCompactPolicy KafkaTopicCleanupPolicy = "compact"
+)
+
+// Messaging properties of the messaging resources required by the Kogito service
+type Messaging struct {
+	// Channels consumed by the service. The Kafka topics and Knative Triggers are provisioned for them before the service is deployed.
+	// Channels can also be declared in the "org.kie/messaging/consumed" label of the service image.
+	// If no channel is declared, they are discovered querying the running service.
+	// +optional
+	// +listType=atomic
+	Consumed []string `json:"consumed,omitempty"`
+
+	// Channels produced by the service. The Kafka topics are provisioned for them before the service is deployed.
+	// Channels can also be declared in the "org.kie/messaging/produced" label of the service image.
+	// If no channel is declared, they are discovered querying the running service.
+	// +optional
+	// +listType=atomic
+	Produced []string `json:"produced,omitempty"`
+
+	// Settings of the Kafka topics used by the service.
+	// Topics not listed here are created with the default settings.
+	// +optional
+	// +listType=atomic
+	Topics []KafkaTopicSettings `json:"topics,omitempty"`
+
+	// Settings of the Knative Triggers created for the consumed channels.
+	// Channels not listed here are subscribed filtering by the CloudEvent type matching the channel name, with the default delivery.
+	// +optional
+	// +listType=atomic
+	Triggers []KnativeTriggerSettings `json:"triggers,omitempty"`
+
+	// Channels bound to a KogitoInfra other than the one referenced in the service infra.
+	// Their Kafka topics or Knative Triggers are provisioned by the bound KogitoInfra, and the properties
+	// to connect the channel to it are added to the service application properties.
+	// Channels not listed here use the messaging KogitoInfra referenced in the service infra.
+	// +optional
+	// +listType=atomic
+	Channels []MessagingChannel `json:"channels,omitempty"`
+}
+
+// MessagingChannel binds a messaging channel of the Kogito service to a KogitoInfra
+type MessagingChannel struct {
+	// Name of the channel.
+	Name string `json:"name"`
+
+	// Name of the KogitoInfra, in the service namespace, providing the Kafka or Knative Eventing resource for the channel.
+	Infra string `json:"infra"`
+}
+
+// KafkaTopicSettings defines the settings of a Kafka topic created for the Kogito service
+type KafkaTopicSettings struct {
+	// Name of the Kafka topic.
+	Name string `json:"name"`
+
+	// Number of partitions of the topic. Partitions can only be increased once the topic is created.
+	// Default value: 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Partitions int32 `json:"partitions,omitempty"`
+
+	// Number of replicas of each partition of the topic.
+	// Default value: 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ReplicationFactor int32 `json:"replicationFactor,omitempty"`
+
+	// Maximum time in milliseconds a message is retained before being discarded by the delete cleanup policy.
+	// If not set, the retention configured in the Kafka brokers is used.
+	// +optional
+	RetentionMs *int64 `json:"retentionMs,omitempty"`
+
+	// Retention policy of the old segments of the topic, either "delete" or "compact".
+	// If not set, the policy configured in the Kafka brokers is used.
+	// +optional
+	// +kubebuilder:validation:Enum=delete;compact
+	CleanupPolicy KafkaTopicCleanupPolicy `json:"cleanupPolicy,omitempty"`
+}
+
+// KnativeBackoffPolicy defines the backoff policy between the delivery retries of an event
+type KnativeBackoffPolicy string
+
+const (
+	// KnativeLinearBackoffPolicy waits the backoff delay between retries
+	KnativeLinearBackoffPolicy KnativeBackoffPolicy = "linear"
+	// KnativeExponentialBackoffPolicy doubles the backoff delay at every retry
+	KnativeExponentialBackoffPolicy KnativeBackoffPolicy = "exponential"
+)
+
+// KnativeTriggerSettings defines the settings of the Knative Trigger subscribing the Kogito service to a channel
+type KnativeTriggerSettings struct {
+	// Name of the consumed channel.
+	Name string `json:"name"`
+
+	// CloudEvent type of the events delivered to the service.
+	// Default value: the channel name.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// CloudEvent source of the events delivered to the service. If not set, events from any source are delivered.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Delivery options of the events to the service.
+	// Requires a Knative Eventing version supporting delivery options in Triggers.
+	// +optional
+	Delivery *KnativeDeliverySettings `json:"delivery,omitempty"`
+}
+
+// KnativeDeliverySettings defines how the events are delivered to the Kogito service
+type KnativeDeliverySettings struct {
+	// Minimum number of retries before sending the event to the dead-letter sink.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Retry *int32 `json:"retry,omitempty"`
+
+	// Backoff policy between retries, either "linear" or "exponential".
+	// +optional
+	// +kubebuilder:validation:Enum=linear;exponential
+	BackoffPolicy KnativeBackoffPolicy `json:"backoffPolicy,omitempty"`
+
+	// Delay before retrying, as an ISO-8601 duration. For example: "PT0.5S".
+	// +optional
+	BackoffDelay string `json:"backoffDelay,omitempty"`
+
+	// Sink receiving the events that couldn't be delivered to the service.
+	// +optional
+	DeadLetterSink *KnativeDestination `json:"deadLetterSink,omitempty"`
+}
+
+// KnativeDestination defines an addressable resource in the service namespace or an URI receiving events
+type KnativeDestination struct {
+	// API version of the addressable resource.
+	// Default value: v1.
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the addressable resource.
+	// Default value: Service.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the addressable resource in the service namespace.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// URI receiving the events. If the addressable resource is set, it's resolved relative to its address.
+	// +optional
+	URI string `json:"uri,omitempty"`
+}
+
+// GetTopic gets the settings of the given Kafka topic, nil if not defined
+func (m *Messaging) GetTopic(name string) *KafkaTopicSettings {
+	for i := range m.Topics {
+		if m.Topics[i].Name == name {
+			return &m.Topics[i]
+		}
+	}
+	return nil
+}
+
+// GetTrigger gets the settings of the Knative Trigger for the given channel, nil if not defined
+func (m *Messaging) GetTrigger(name string) *KnativeTriggerSettings {
+	for i := range m.Triggers {
+		if m.Triggers[i].Name == name {
+			return &m.Triggers[i]
+		}
+	}
+	return nil
+}
+
+// GetChannelInfra gets the name of the KogitoInfra bound to the given channel, empty if not bound
+func (m *Messaging) GetChannelInfra(name string) string {
+	for _, channel := range m.Channels {
+		if channel.Name == name {
+			return channel.Infra
+		}
+	}
+	return ""
+}
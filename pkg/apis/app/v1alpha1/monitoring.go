@@ -14,6 +14,8 @@
 
 package v1alpha1
 
+import corev1 "k8s.io/api/core/v1"
+
 const (
 
 	// MonitoringDefaultPath default path
@@ -32,4 +34,190 @@ type Monitoring struct {
 	// HTTP path to scrape for metrics.
 	// +optional
 	Path string `json:"path,omitempty"`
+
+	// Interval at which metrics are scraped, for example 30s. Default to the Prometheus global interval.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// Timeout of the metrics scrape, for example 10s. Default to the Prometheus global timeout.
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
+	// TLS configuration to scrape metrics with the https scheme.
+	// +optional
+	TLSConfig *MonitoringTLSConfig `json:"tlsConfig,omitempty"`
+
+	// Secret key holding the bearer token sent to scrape metrics.
+	// +optional
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+
+	// Relabelings applied to the scraped metrics before ingestion.
+	// +optional
+	// +listType=atomic
+	MetricRelabelings []MonitoringRelabelConfig `json:"metricRelabelings,omitempty"`
+
+	// Extra Grafana dashboards to deploy for the service, along with the ones it provides.
+	// Each dashboard is read from a key of a ConfigMap in the service namespace.
+	// +optional
+	// +listType=atomic
+	Dashboards []MonitoringDashboard `json:"dashboards,omitempty"`
+
+	// Prometheus alerting rules generated for the service. When set, a PrometheusRule is created with the default rules
+	// for the process instance error rate, SLA violations, Kafka consumer errors and pod restarts, plus the custom ones.
+	// +optional
+	Alerts *MonitoringAlerts `json:"alerts,omitempty"`
+}
+
+// MonitoringTLSConfig configures the TLS connection used to scrape the metrics of the service.
+type MonitoringTLSConfig struct {
+	// Secret key holding the CA certificate used to verify the service certificate.
+	// +optional
+	CASecret *corev1.SecretKeySelector `json:"caSecret,omitempty"`
+
+	// Secret key holding the client certificate presented to the service.
+	// +optional
+	CertSecret *corev1.SecretKeySelector `json:"certSecret,omitempty"`
+
+	// Secret key holding the private key of the client certificate.
+	// +optional
+	KeySecret *corev1.SecretKeySelector `json:"keySecret,omitempty"`
+
+	// Server name used to verify the service certificate.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// Disables the verification of the service certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// MonitoringRelabelConfig is a Prometheus relabeling rule applied to the scraped metrics.
+type MonitoringRelabelConfig struct {
+	// Labels whose values are concatenated and matched against the regex.
+	// +optional
+	// +listType=atomic
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+
+	// Separator between the concatenated source label values. Default to ;.
+	// +optional
+	Separator string `json:"separator,omitempty"`
+
+	// Label written by the replace action.
+	// +optional
+	TargetLabel string `json:"targetLabel,omitempty"`
+
+	// Regular expression matched against the concatenated source label values. Default to (.*).
+	// +optional
+	Regex string `json:"regex,omitempty"`
+
+	// Value written by the replace action, can reference the regex groups. Default to $1.
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+
+	// Action performed on a regex match. Default to replace.
+	// +optional
+	// +kubebuilder:validation:Enum=replace;keep;drop;labelmap;labeldrop;labelkeep
+	Action string `json:"action,omitempty"`
+}
+
+// MonitoringDashboard references a Grafana dashboard JSON stored in a ConfigMap.
+type MonitoringDashboard struct {
+	// Name of the ConfigMap holding the dashboard.
+	ConfigMapName string `json:"configMapName"`
+
+	// Key of the dashboard JSON in the ConfigMap, for example my-dashboard.json.
+	Key string `json:"key"`
+}
+
+// MonitoringAlerts configures the Prometheus alerting rules generated for the service.
+type MonitoringAlerts struct {
+	// Thresholds of the default alerting rules.
+	// +optional
+	Thresholds MonitoringAlertThresholds `json:"thresholds,omitempty"`
+
+	// Custom alerting rules. A rule with the name of a default rule replaces it.
+	// +optional
+	// +listType=atomic
+	Rules []MonitoringAlertRule `json:"rules,omitempty"`
+
+	// Metrics evaluated by the default alerting rules, to be set when the service exposes them with other names.
+	// +optional
+	Metrics MonitoringAlertMetrics `json:"metrics,omitempty"`
+}
+
+// MonitoringAlertMetrics overrides the metrics evaluated by the default alerting rules.
+// The defaults are the process metrics of the Kogito Prometheus monitoring addon and the process metrics of the JVM.
+type MonitoringAlertMetrics struct {
+	// Counter of the started process instances. Default to kie_process_instance_started_total.
+	// +optional
+	ProcessInstanceStarted string `json:"processInstanceStarted,omitempty"`
+
+	// Counter of the completed process instances, labeled with their state in the status label. Default to kie_process_instance_completed_total.
+	// +optional
+	ProcessInstanceCompleted string `json:"processInstanceCompleted,omitempty"`
+
+	// Status label value of the process instances completed in error. Default to 5, the error state of the Kogito process instances.
+	// +optional
+	ProcessInstanceErrorStatus string `json:"processInstanceErrorStatus,omitempty"`
+
+	// Counter of the process instance SLA violations. Default to kie_process_instance_sla_violated_total.
+	// +optional
+	SLAViolated string `json:"slaViolated,omitempty"`
+
+	// Regular expression matching the counters of the Kafka consumer errors. Default to kafka_consumer_.*(error|failed).*_total.
+	// +optional
+	KafkaConsumerErrors string `json:"kafkaConsumerErrors,omitempty"`
+
+	// Gauge of the start time of the service process, changing on each restart. Default to process_start_time_seconds.
+	// +optional
+	ProcessStartTime string `json:"processStartTime,omitempty"`
+}
+
+// MonitoringAlertThresholds overrides the thresholds of the default alerting rules. An alert fires when its threshold is exceeded.
+type MonitoringAlertThresholds struct {
+	// Percentage of process instances ending in error over the last 5 minutes. Default to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ProcessInstanceErrorRate *int32 `json:"processInstanceErrorRate,omitempty"`
+
+	// Number of process instance SLA violations over the last 5 minutes. Default to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SLAViolations *int32 `json:"slaViolations,omitempty"`
+
+	// Number of Kafka consumer errors over the last 5 minutes. Default to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	KafkaConsumerErrors *int32 `json:"kafkaConsumerErrors,omitempty"`
+
+	// Number of restarts of a service pod over the last hour. Default to 2.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	PodRestarts *int32 `json:"podRestarts,omitempty"`
+}
+
+// MonitoringAlertRule is a custom Prometheus alerting rule.
+type MonitoringAlertRule struct {
+	// Name of the alert.
+	Alert string `json:"alert"`
+
+	// PromQL expression to evaluate.
+	Expr string `json:"expr"`
+
+	// Time the expression must be true before the alert fires, for example 5m.
+	// +optional
+	For string `json:"for,omitempty"`
+
+	// Severity label of the alert. Default to warning.
+	// +optional
+	Severity string `json:"severity,omitempty"`
+
+	// Extra labels of the alert.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations of the alert, for example summary and description.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
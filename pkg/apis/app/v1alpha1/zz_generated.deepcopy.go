@@ -130,6 +130,222 @@ func (in *ConditionsMeta) DeepCopy() *ConditionsMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAMQP) DeepCopyInto(out *ExternalAMQP) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(SecretCredentials)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAMQP.
+func (in *ExternalAMQP) DeepCopy() *ExternalAMQP {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAMQP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalInfinispan) DeepCopyInto(out *ExternalInfinispan) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(SecretCredentials)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ExternalTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalInfinispan.
+func (in *ExternalInfinispan) DeepCopy() *ExternalInfinispan {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalInfinispan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalInfra) DeepCopyInto(out *ExternalInfra) {
+	*out = *in
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(ExternalKafka)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Infinispan != nil {
+		in, out := &in.Infinispan, &out.Infinispan
+		*out = new(ExternalInfinispan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = new(ExternalKeycloak)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(ExternalPostgreSQL)
+		**out = **in
+	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(ExternalMongoDB)
+		**out = **in
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(ExternalTracing)
+		**out = **in
+	}
+	if in.AMQP != nil {
+		in, out := &in.AMQP, &out.AMQP
+		*out = new(ExternalAMQP)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalInfra.
+func (in *ExternalInfra) DeepCopy() *ExternalInfra {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalInfra)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalKafka) DeepCopyInto(out *ExternalKafka) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(SecretCredentials)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ExternalTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalKafka.
+func (in *ExternalKafka) DeepCopy() *ExternalKafka {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalKafka)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalKeycloak) DeepCopyInto(out *ExternalKeycloak) {
+	*out = *in
+	if in.ClientSecret != nil {
+		in, out := &in.ClientSecret, &out.ClientSecret
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalKeycloak.
+func (in *ExternalKeycloak) DeepCopy() *ExternalKeycloak {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalKeycloak)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalMongoDB) DeepCopyInto(out *ExternalMongoDB) {
+	*out = *in
+	out.ConnectionString = in.ConnectionString
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalMongoDB.
+func (in *ExternalMongoDB) DeepCopy() *ExternalMongoDB {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalMongoDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalPostgreSQL) DeepCopyInto(out *ExternalPostgreSQL) {
+	*out = *in
+	out.Credentials = in.Credentials
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPostgreSQL.
+func (in *ExternalPostgreSQL) DeepCopy() *ExternalPostgreSQL {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalPostgreSQL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalTLS) DeepCopyInto(out *ExternalTLS) {
+	*out = *in
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTLS.
+func (in *ExternalTLS) DeepCopy() *ExternalTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalTracing) DeepCopyInto(out *ExternalTracing) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTracing.
+func (in *ExternalTracing) DeepCopy() *ExternalTracing {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalTracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitSource) DeepCopyInto(out *GitSource) {
 	*out = *in
@@ -162,6 +378,90 @@ func (in *Image) DeepCopy() *Image {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicSettings) DeepCopyInto(out *KafkaTopicSettings) {
+	*out = *in
+	if in.RetentionMs != nil {
+		in, out := &in.RetentionMs, &out.RetentionMs
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicSettings.
+func (in *KafkaTopicSettings) DeepCopy() *KafkaTopicSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KnativeDeliverySettings) DeepCopyInto(out *KnativeDeliverySettings) {
+	*out = *in
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeadLetterSink != nil {
+		in, out := &in.DeadLetterSink, &out.DeadLetterSink
+		*out = new(KnativeDestination)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnativeDeliverySettings.
+func (in *KnativeDeliverySettings) DeepCopy() *KnativeDeliverySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(KnativeDeliverySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KnativeDestination) DeepCopyInto(out *KnativeDestination) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnativeDestination.
+func (in *KnativeDestination) DeepCopy() *KnativeDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(KnativeDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KnativeTriggerSettings) DeepCopyInto(out *KnativeTriggerSettings) {
+	*out = *in
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(KnativeDeliverySettings)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnativeTriggerSettings.
+func (in *KnativeTriggerSettings) DeepCopy() *KnativeTriggerSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(KnativeTriggerSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoBuild) DeepCopyInto(out *KogitoBuild) {
 	*out = *in
@@ -300,7 +600,7 @@ func (in *KogitoInfra) DeepCopyInto(out *KogitoInfra) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -340,6 +640,22 @@ func (in *KogitoInfraCondition) DeepCopy() *KogitoInfraCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoInfraConsumer) DeepCopyInto(out *KogitoInfraConsumer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoInfraConsumer.
+func (in *KogitoInfraConsumer) DeepCopy() *KogitoInfraConsumer {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoInfraConsumer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoInfraList) DeepCopyInto(out *KogitoInfraList) {
 	*out = *in
@@ -377,6 +693,26 @@ func (in *KogitoInfraList) DeepCopyObject() runtime.Object {
 func (in *KogitoInfraSpec) DeepCopyInto(out *KogitoInfraSpec) {
 	*out = *in
 	out.Resource = in.Resource
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalInfra)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceNaming != nil {
+		in, out := &in.ResourceNaming, &out.ResourceNaming
+		*out = new(ResourceNamingPolicy)
+		**out = **in
+	}
+	if in.Mapping != nil {
+		in, out := &in.Mapping, &out.Mapping
+		*out = new(ResourceMapping)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -394,6 +730,23 @@ func (in *KogitoInfraSpec) DeepCopy() *KogitoInfraSpec {
 func (in *KogitoInfraStatus) DeepCopyInto(out *KogitoInfraStatus) {
 	*out = *in
 	in.Condition.DeepCopyInto(&out.Condition)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]KogitoInfraCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(Resource)
+		**out = **in
+	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]KogitoInfraConsumer, len(*in))
+		copy(*out, *in)
+	}
 	if in.AppProps != nil {
 		in, out := &in.AppProps, &out.AppProps
 		*out = make(map[string]string, len(*in))
@@ -408,6 +761,11 @@ func (in *KogitoInfraStatus) DeepCopyInto(out *KogitoInfraStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]KogitoInfraVolume, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -421,6 +779,22 @@ func (in *KogitoInfraStatus) DeepCopy() *KogitoInfraStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoInfraVolume) DeepCopyInto(out *KogitoInfraVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoInfraVolume.
+func (in *KogitoInfraVolume) DeepCopy() *KogitoInfraVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoInfraVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoRuntime) DeepCopyInto(out *KogitoRuntime) {
 	*out = *in
@@ -551,7 +925,8 @@ func (in *KogitoServiceSpec) DeepCopyInto(out *KogitoServiceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.Monitoring = in.Monitoring
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	in.Messaging.DeepCopyInto(&out.Messaging)
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = make(map[string]string, len(*in))
@@ -691,9 +1066,129 @@ func (in *KogitoSupportingServiceStatus) DeepCopy() *KogitoSupportingServiceStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MappedProperty) DeepCopyInto(out *MappedProperty) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappedProperty.
+func (in *MappedProperty) DeepCopy() *MappedProperty {
+	if in == nil {
+		return nil
+	}
+	out := new(MappedProperty)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MappedSecretEnvVar) DeepCopyInto(out *MappedSecretEnvVar) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappedSecretEnvVar.
+func (in *MappedSecretEnvVar) DeepCopy() *MappedSecretEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(MappedSecretEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Messaging) DeepCopyInto(out *Messaging) {
+	*out = *in
+	if in.Consumed != nil {
+		in, out := &in.Consumed, &out.Consumed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Produced != nil {
+		in, out := &in.Produced, &out.Produced
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]KafkaTopicSettings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]KnativeTriggerSettings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]MessagingChannel, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Messaging.
+func (in *Messaging) DeepCopy() *Messaging {
+	if in == nil {
+		return nil
+	}
+	out := new(Messaging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessagingChannel) DeepCopyInto(out *MessagingChannel) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessagingChannel.
+func (in *MessagingChannel) DeepCopy() *MessagingChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(MessagingChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Monitoring) DeepCopyInto(out *Monitoring) {
 	*out = *in
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(MonitoringTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BearerTokenSecret != nil {
+		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricRelabelings != nil {
+		in, out := &in.MetricRelabelings, &out.MetricRelabelings
+		*out = make([]MonitoringRelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Dashboards != nil {
+		in, out := &in.Dashboards, &out.Dashboards
+		*out = make([]MonitoringDashboard, len(*in))
+		copy(*out, *in)
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = new(MonitoringAlerts)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -707,6 +1202,181 @@ func (in *Monitoring) DeepCopy() *Monitoring {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringAlertMetrics) DeepCopyInto(out *MonitoringAlertMetrics) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringAlertMetrics.
+func (in *MonitoringAlertMetrics) DeepCopy() *MonitoringAlertMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringAlertMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringAlertRule) DeepCopyInto(out *MonitoringAlertRule) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringAlertRule.
+func (in *MonitoringAlertRule) DeepCopy() *MonitoringAlertRule {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringAlertRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringAlertThresholds) DeepCopyInto(out *MonitoringAlertThresholds) {
+	*out = *in
+	if in.ProcessInstanceErrorRate != nil {
+		in, out := &in.ProcessInstanceErrorRate, &out.ProcessInstanceErrorRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SLAViolations != nil {
+		in, out := &in.SLAViolations, &out.SLAViolations
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KafkaConsumerErrors != nil {
+		in, out := &in.KafkaConsumerErrors, &out.KafkaConsumerErrors
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodRestarts != nil {
+		in, out := &in.PodRestarts, &out.PodRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringAlertThresholds.
+func (in *MonitoringAlertThresholds) DeepCopy() *MonitoringAlertThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringAlertThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringAlerts) DeepCopyInto(out *MonitoringAlerts) {
+	*out = *in
+	in.Thresholds.DeepCopyInto(&out.Thresholds)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]MonitoringAlertRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Metrics = in.Metrics
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringAlerts.
+func (in *MonitoringAlerts) DeepCopy() *MonitoringAlerts {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringAlerts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringDashboard) DeepCopyInto(out *MonitoringDashboard) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringDashboard.
+func (in *MonitoringDashboard) DeepCopy() *MonitoringDashboard {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringDashboard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringRelabelConfig) DeepCopyInto(out *MonitoringRelabelConfig) {
+	*out = *in
+	if in.SourceLabels != nil {
+		in, out := &in.SourceLabels, &out.SourceLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringRelabelConfig.
+func (in *MonitoringRelabelConfig) DeepCopy() *MonitoringRelabelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringRelabelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringTLSConfig) DeepCopyInto(out *MonitoringTLSConfig) {
+	*out = *in
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertSecret != nil {
+		in, out := &in.CertSecret, &out.CertSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeySecret != nil {
+		in, out := &in.KeySecret, &out.KeySecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringTLSConfig.
+func (in *MonitoringTLSConfig) DeepCopy() *MonitoringTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resource) DeepCopyInto(out *Resource) {
 	*out = *in
@@ -723,6 +1393,85 @@ func (in *Resource) DeepCopy() *Resource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMapping) DeepCopyInto(out *ResourceMapping) {
+	*out = *in
+	if in.AppProps != nil {
+		in, out := &in.AppProps, &out.AppProps
+		*out = make([]MappedProperty, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]MappedProperty, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretEnv != nil {
+		in, out := &in.SecretEnv, &out.SecretEnv
+		*out = make([]MappedSecretEnvVar, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMapping.
+func (in *ResourceMapping) DeepCopy() *ResourceMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceNamingPolicy) DeepCopyInto(out *ResourceNamingPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNamingPolicy.
+func (in *ResourceNamingPolicy) DeepCopy() *ResourceNamingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceNamingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretCredentials) DeepCopyInto(out *SecretCredentials) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretCredentials.
+func (in *SecretCredentials) DeepCopy() *SecretCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebHookSecret) DeepCopyInto(out *WebHookSecret) {
 	*out = *in
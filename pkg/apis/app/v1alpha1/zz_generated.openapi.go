@@ -415,11 +415,55 @@ func schema_pkg_apis_app_v1alpha1_KogitoInfraSpec(ref common.ReferenceCallback)
 							Ref:         ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Resource"),
 						},
 					},
+					"external": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Connection information of an infrastructure service not managed by an operator in the cluster, for example a managed Kafka. When defined, Resource is ignored.",
+							Ref:         ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.ExternalInfra"),
+						},
+					},
+					"resourceNaming": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Naming policy of the Kafka topics and Infinispan caches used by the services bound to this KogitoInfra. Use it to isolate the services of different namespaces or teams sharing the same infrastructure.",
+							Ref:         ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.ResourceNamingPolicy"),
+						},
+					},
+					"mapping": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mapping of the referenced resource, of any kind, to the properties of the bound services. Use it to bind infrastructure not natively supported by the operator. When defined, the resource name is required, and the resource is read as it is, never created.",
+							Ref:         ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.ResourceMapping"),
+						},
+					},
+					"deletionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Behavior when the KogitoInfra is deleted while Kogito services still depend on it. \"Block\" keeps the KogitoInfra until no service depends on it, \"Warn\" deletes it anyway. Default to \"Block\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"allowedNamespaces": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces whose Kogito services can reference this KogitoInfra as \"namespace/name\" in their infra. Use \"*\" to allow every namespace. The Secrets it publishes are copied into the namespaces of these services.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Resource"},
+			"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.ExternalInfra", "github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Resource", "github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.ResourceMapping", "github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.ResourceNamingPolicy"},
 	}
 }
 
@@ -432,7 +476,53 @@ func schema_pkg_apis_app_v1alpha1_KogitoInfraStatus(ref common.ReferenceCallback
 				Properties: map[string]spec.Schema{
 					"condition": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.KogitoInfraCondition"),
+							Description: "Deprecated: use Conditions instead. Latest Success or Failure condition of the reconciliation.",
+							Ref:         ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.KogitoInfraCondition"),
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Ready, ResourceAvailable and Configured conditions of the KogitoInfra.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.KogitoInfraCondition"),
+									},
+								},
+							},
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resource backing the KogitoInfra, with its name and namespace resolved. Not set for external infrastructure.",
+							Ref:         ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Resource"),
+						},
+					},
+					"consumers": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Kogito services currently depending on the KogitoInfra, through their infra or their messaging channels.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.KogitoInfraConsumer"),
+									},
+								},
+							},
 						},
 					},
 					"appProps": {
@@ -468,11 +558,29 @@ func schema_pkg_apis_app_v1alpha1_KogitoInfraStatus(ref common.ReferenceCallback
 							},
 						},
 					},
+					"volumes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes extracted from the linked resource that will be mounted in the deployed Kogito service.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.KogitoInfraVolume"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.KogitoInfraCondition", "k8s.io/api/core/v1.EnvVar"},
+			"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.KogitoInfraCondition", "github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.KogitoInfraConsumer", "github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.KogitoInfraVolume", "github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Resource", "k8s.io/api/core/v1.EnvVar"},
 	}
 }
 
@@ -655,7 +763,7 @@ func schema_pkg_apis_app_v1alpha1_KogitoSupportingServiceSpec(ref common.Referen
 					},
 					"infra": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Infra provides list of dependent KogitoInfra objects.",
+							Description: "Infra provides list of dependent KogitoInfra objects. KogitoInfra objects of other namespaces are referenced as namespace/name.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -673,6 +781,12 @@ func schema_pkg_apis_app_v1alpha1_KogitoSupportingServiceSpec(ref common.Referen
 							Ref:         ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Monitoring"),
 						},
 					},
+					"messaging": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Settings of the messaging resources, such as Kafka topics, used by the service",
+							Ref:         ref("github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Messaging"),
+						},
+					},
 					"config": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Application properties that will be set to the service. For example 'MY_VAR: my_value'.",
@@ -700,7 +814,7 @@ func schema_pkg_apis_app_v1alpha1_KogitoSupportingServiceSpec(ref common.Referen
 			},
 		},
 		Dependencies: []string{
-			"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Monitoring", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ResourceRequirements"},
+			"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Messaging", "github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1.Monitoring", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ResourceRequirements"},
 	}
 }
 
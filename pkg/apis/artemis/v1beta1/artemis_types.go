@@ -0,0 +1,109 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AMQPProtocol is the protocol of the acceptors accepting AMQP connections
+	AMQPProtocol = "amqp"
+	// MulticastRoutingType routes the messages sent to an address to every queue of the address
+	MulticastRoutingType = "multicast"
+)
+
+// ActiveMQArtemisSpec defines the desired state of ActiveMQArtemis
+type ActiveMQArtemisSpec struct {
+	AdminUser      string         `json:"adminUser,omitempty"`
+	AdminPassword  string         `json:"adminPassword,omitempty"`
+	DeploymentPlan DeploymentPlan `json:"deploymentPlan,omitempty"`
+	Acceptors      []AcceptorType `json:"acceptors,omitempty"`
+}
+
+// DeploymentPlan describes the broker pods
+type DeploymentPlan struct {
+	Size               int32 `json:"size,omitempty"`
+	RequireLogin       bool  `json:"requireLogin,omitempty"`
+	PersistenceEnabled bool  `json:"persistenceEnabled,omitempty"`
+}
+
+// AcceptorType describes an acceptor of the broker, exposed by a Service for each broker pod
+type AcceptorType struct {
+	Name       string `json:"name"`
+	Protocols  string `json:"protocols,omitempty"`
+	Port       int32  `json:"port,omitempty"`
+	SSLEnabled bool   `json:"sslEnabled,omitempty"`
+}
+
+// ActiveMQArtemisStatus defines the observed state of ActiveMQArtemis
+type ActiveMQArtemisStatus struct {
+	PodStatus PodStatus `json:"podStatus"`
+}
+
+// PodStatus lists the broker pods by state
+type PodStatus struct {
+	Ready    []string `json:"ready,omitempty"`
+	Starting []string `json:"starting,omitempty"`
+	Stopped  []string `json:"stopped,omitempty"`
+}
+
+// ActiveMQArtemis is the Schema for the activemqartemises API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ActiveMQArtemis struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ActiveMQArtemisSpec   `json:"spec,omitempty"`
+	Status ActiveMQArtemisStatus `json:"status,omitempty"`
+}
+
+// ActiveMQArtemisList contains a list of ActiveMQArtemis
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ActiveMQArtemisList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ActiveMQArtemis `json:"items"`
+}
+
+// ActiveMQArtemisAddressSpec defines the desired state of ActiveMQArtemisAddress
+type ActiveMQArtemisAddressSpec struct {
+	AddressName              string   `json:"addressName"`
+	QueueName                string   `json:"queueName,omitempty"`
+	RoutingType              string   `json:"routingType,omitempty"`
+	RemoveFromBrokerOnDelete bool     `json:"removeFromBrokerOnDelete,omitempty"`
+	ApplyToCrNames           []string `json:"applyToCrNames,omitempty"`
+}
+
+// ActiveMQArtemisAddress is the Schema for the activemqartemisaddresses API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ActiveMQArtemisAddress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ActiveMQArtemisAddressSpec `json:"spec,omitempty"`
+}
+
+// ActiveMQArtemisAddressList contains a list of ActiveMQArtemisAddress
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ActiveMQArtemisAddressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ActiveMQArtemisAddress `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ActiveMQArtemis{}, &ActiveMQArtemisList{}, &ActiveMQArtemisAddress{}, &ActiveMQArtemisAddressList{})
+}
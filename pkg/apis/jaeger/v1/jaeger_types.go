@@ -0,0 +1,65 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// JaegerPhaseRunning is the phase of a Jaeger instance ready to receive traces
+	JaegerPhaseRunning = "Running"
+	// JaegerAllInOneStrategy deploys the agent, collector, query and in-memory storage in a single pod
+	JaegerAllInOneStrategy = "allinone"
+)
+
+// JaegerSpec defines the desired state of Jaeger
+type JaegerSpec struct {
+	Strategy string             `json:"strategy,omitempty"`
+	AllInOne JaegerAllInOneSpec `json:"allInOne,omitempty"`
+}
+
+// JaegerAllInOneSpec defines the options of the all-in-one deployment
+type JaegerAllInOneSpec struct {
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// JaegerStatus defines the observed state of Jaeger
+type JaegerStatus struct {
+	Version string `json:"version"`
+	Phase   string `json:"phase"`
+}
+
+// Jaeger is the Schema for the jaegers API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Jaeger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JaegerSpec   `json:"spec,omitempty"`
+	Status JaegerStatus `json:"status,omitempty"`
+}
+
+// JaegerList contains a list of Jaeger
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type JaegerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Jaeger `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Jaeger{}, &JaegerList{})
+}
@@ -53,22 +53,58 @@ func (kafkaMap *KafkaMap) DeepCopy() *KafkaMap {
 
 // KafkaClusterSpec defines the desired state of Kafka Cluster
 type KafkaClusterSpec struct {
-	Replicas   int32          `json:"replicas,omitempty"`
-	Listeners  KafkaListeners `json:"listeners,omitempty"`
-	Storage    KafkaStorage   `json:"storage,omitempty"`
-	Config     KafkaMap       `json:"config,omitempty"`
-	JvmOptions KafkaMap       `json:"jvmOptions,omitempty"`
+	Replicas      int32               `json:"replicas,omitempty"`
+	Listeners     KafkaListeners      `json:"listeners,omitempty"`
+	Authorization *KafkaAuthorization `json:"authorization,omitempty"`
+	Storage       KafkaStorage        `json:"storage,omitempty"`
+	Config        KafkaMap            `json:"config,omitempty"`
+	JvmOptions    KafkaMap            `json:"jvmOptions,omitempty"`
 }
 
 // KafkaListeners Configures the broker authorization
 type KafkaListeners struct {
 	Plain KafkaListenerPlain `json:"plain,omitempty"`
+	TLS   *KafkaListenerTLS  `json:"tls,omitempty"`
 }
 
 // KafkaListenerPlain Listener type Plain
 type KafkaListenerPlain struct {
+	Authentication *KafkaListenerAuthentication `json:"authentication,omitempty"`
 }
 
+// KafkaListenerTLS Listener type TLS
+type KafkaListenerTLS struct {
+	Authentication *KafkaListenerAuthentication `json:"authentication,omitempty"`
+}
+
+// KafkaListenerAuthentication Authentication configuration of a listener
+type KafkaListenerAuthentication struct {
+	Type KafkaAuthenticationType `json:"type"`
+}
+
+// KafkaAuthenticationType defines the enum for the authentication of Kafka listeners and users
+type KafkaAuthenticationType string
+
+const (
+	// KafkaScramSha512Authentication ...
+	KafkaScramSha512Authentication KafkaAuthenticationType = "scram-sha-512"
+	// KafkaTLSAuthentication ...
+	KafkaTLSAuthentication KafkaAuthenticationType = "tls"
+)
+
+// KafkaAuthorization Authorization configuration of the Kafka brokers
+type KafkaAuthorization struct {
+	Type KafkaAuthorizationType `json:"type"`
+}
+
+// KafkaAuthorizationType defines the enum for the authorization of Kafka brokers and users
+type KafkaAuthorizationType string
+
+const (
+	// KafkaSimpleAuthorization ...
+	KafkaSimpleAuthorization KafkaAuthorizationType = "simple"
+)
+
 // ZookeeperClusterSpec Representation of a Strimzi-managed ZooKeeper "cluster".
 type ZookeeperClusterSpec struct {
 	Replicas int32        `json:"replicas,omitempty"`
@@ -114,8 +150,9 @@ const (
 
 // ListenerStatus defines a single listener
 type ListenerStatus struct {
-	Type      string            `json:"type,omitempty"`
-	Addresses []ListenerAddress `json:"addresses,omitempty"`
+	Type         string            `json:"type,omitempty"`
+	Addresses    []ListenerAddress `json:"addresses,omitempty"`
+	Certificates []string          `json:"certificates,omitempty"`
 }
 
 // ListenerAddress defines a single address of particular listener
@@ -0,0 +1,99 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaUserSpec defines the desired state of KafkaUser
+type KafkaUserSpec struct {
+	Authentication KafkaUserAuthentication `json:"authentication"`
+	Authorization  *KafkaUserAuthorization `json:"authorization,omitempty"`
+}
+
+// KafkaUserAuthentication Authentication mechanism enabled for the user
+type KafkaUserAuthentication struct {
+	Type KafkaAuthenticationType `json:"type"`
+}
+
+// KafkaUserAuthorization Authorization rules for the user
+type KafkaUserAuthorization struct {
+	Type KafkaAuthorizationType `json:"type"`
+	ACLs []KafkaUserACL         `json:"acls,omitempty"`
+}
+
+// KafkaUserACL Access rule of the user
+type KafkaUserACL struct {
+	Resource  KafkaUserACLResource `json:"resource"`
+	Operation KafkaACLOperation    `json:"operation"`
+	Host      string               `json:"host,omitempty"`
+}
+
+// KafkaUserACLResource Resource to which the access rule applies
+type KafkaUserACLResource struct {
+	Type        KafkaACLResourceType `json:"type"`
+	Name        string               `json:"name,omitempty"`
+	PatternType string               `json:"patternType,omitempty"`
+}
+
+// KafkaACLResourceType defines the enum for the resource types of an access rule
+type KafkaACLResourceType string
+
+// KafkaACLOperation defines the enum for the operations of an access rule
+type KafkaACLOperation string
+
+const (
+	// KafkaACLTopicResource ...
+	KafkaACLTopicResource KafkaACLResourceType = "topic"
+	// KafkaACLGroupResource ...
+	KafkaACLGroupResource KafkaACLResourceType = "group"
+
+	// KafkaACLReadOperation ...
+	KafkaACLReadOperation KafkaACLOperation = "Read"
+	// KafkaACLWriteOperation ...
+	KafkaACLWriteOperation KafkaACLOperation = "Write"
+	// KafkaACLDescribeOperation ...
+	KafkaACLDescribeOperation KafkaACLOperation = "Describe"
+)
+
+// KafkaUserStatus defines the observed state of KafkaUser
+type KafkaUserStatus struct {
+	Username   string           `json:"username,omitempty"`
+	Secret     string           `json:"secret,omitempty"`
+	Conditions []KafkaCondition `json:"conditions,omitempty"`
+}
+
+// KafkaUser is the Schema for the kafkausers API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type KafkaUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaUserSpec   `json:"spec,omitempty"`
+	Status KafkaUserStatus `json:"status,omitempty"`
+}
+
+// KafkaUserList contains a list of KafkaUser
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type KafkaUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaUser{}, &KafkaUserList{})
+}
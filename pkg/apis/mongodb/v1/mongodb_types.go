@@ -0,0 +1,99 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MongoDBPhaseRunning is the phase of a MongoDB replica set ready to accept connections
+	MongoDBPhaseRunning = "Running"
+	// MongoDBReplicaSetType is the type of a MongoDB replica set deployment
+	MongoDBReplicaSetType = "ReplicaSet"
+	// MongoDBScramAuthMode is the SCRAM authentication mode
+	MongoDBScramAuthMode AuthMode = "SCRAM"
+)
+
+// MongoDBSpec defines the desired state of MongoDB
+type MongoDBSpec struct {
+	Members  int           `json:"members"`
+	Type     string        `json:"type"`
+	Version  string        `json:"version"`
+	Security Security      `json:"security"`
+	Users    []MongoDBUser `json:"users"`
+}
+
+// Security describes the security of the MongoDB deployment
+type Security struct {
+	Authentication Authentication `json:"authentication"`
+}
+
+// Authentication describes the authentication modes enabled in the MongoDB deployment
+type Authentication struct {
+	Modes []AuthMode `json:"modes"`
+}
+
+// AuthMode is a MongoDB authentication mode
+type AuthMode string
+
+// MongoDBUser describes a user of the MongoDB deployment
+type MongoDBUser struct {
+	Name                       string             `json:"name"`
+	DB                         string             `json:"db"`
+	PasswordSecretRef          SecretKeyReference `json:"passwordSecretRef"`
+	Roles                      []Role             `json:"roles"`
+	ScramCredentialsSecretName string             `json:"scramCredentialsSecretName"`
+}
+
+// SecretKeyReference references a key in a Secret
+type SecretKeyReference struct {
+	Name string `json:"name"`
+	Key  string `json:"key,omitempty"`
+}
+
+// Role is a role granted to a MongoDB user in a database
+type Role struct {
+	DB   string `json:"db"`
+	Name string `json:"name"`
+}
+
+// MongoDBStatus defines the observed state of MongoDB
+type MongoDBStatus struct {
+	MongoURI string `json:"mongoUri"`
+	Phase    string `json:"phase"`
+}
+
+// MongoDB is the Schema for the mongodbs API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MongoDB struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MongoDBSpec   `json:"spec,omitempty"`
+	Status MongoDBStatus `json:"status,omitempty"`
+}
+
+// MongoDBList contains a list of MongoDB
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MongoDBList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MongoDB `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MongoDB{}, &MongoDBList{})
+}
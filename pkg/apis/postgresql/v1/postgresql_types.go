@@ -0,0 +1,77 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PostgresqlClusterStatusRunning is the status of a PostgreSQL cluster ready to accept connections
+	PostgresqlClusterStatusRunning = "Running"
+)
+
+// PostgresqlSpec defines the desired state of a PostgreSQL cluster
+type PostgresqlSpec struct {
+	PostgresqlParam   PostgresqlParam      `json:"postgresql"`
+	Volume            Volume               `json:"volume,omitempty"`
+	TeamID            string               `json:"teamId"`
+	NumberOfInstances int32                `json:"numberOfInstances"`
+	Users             map[string]UserFlags `json:"users,omitempty"`
+	// Databases maps the database names to their owner
+	Databases map[string]string `json:"databases,omitempty"`
+}
+
+// PostgresqlParam describes the PostgreSQL server
+type PostgresqlParam struct {
+	PgVersion  string            `json:"version"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// Volume describes the persistent volume of the PostgreSQL cluster
+type Volume struct {
+	Size         string `json:"size"`
+	StorageClass string `json:"storageClass,omitempty"`
+}
+
+// UserFlags defines the roles of a PostgreSQL user
+type UserFlags []string
+
+// PostgresqlStatus defines the observed state of a PostgreSQL cluster
+type PostgresqlStatus struct {
+	PostgresClusterStatus string `json:"PostgresClusterStatus"`
+}
+
+// Postgresql is the Schema for the postgresqls API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Postgresql struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PostgresqlSpec   `json:"spec,omitempty"`
+	Status PostgresqlStatus `json:"status,omitempty"`
+}
+
+// PostgresqlList contains a list of Postgresql
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PostgresqlList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Postgresql `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Postgresql{}, &PostgresqlList{})
+}
@@ -19,7 +19,11 @@ import (
 	grafana "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
 	keycloakv1alpha1 "github.com/keycloak/keycloak-operator/pkg/apis/keycloak/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	artemisv1beta1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/artemis/v1beta1"
+	jaegerv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/jaeger/v1"
 	kafkabetav1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/kafka/v1beta1"
+	mongodbv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/mongodb/v1"
+	postgresqlv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/postgresql/v1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/logger"
 	appsv1 "github.com/openshift/api/apps/v1"
 	buildv1 "github.com/openshift/api/build/v1"
@@ -87,6 +91,10 @@ func GetRegisteredSchemeBuilder() runtime.SchemeBuilder {
 		imgv1.Install,
 		apiextensionsv1beta1.AddToScheme,
 		kafkabetav1.SchemeBuilder.AddToScheme,
+		postgresqlv1.SchemeBuilder.AddToScheme,
+		mongodbv1.SchemeBuilder.AddToScheme,
+		jaegerv1.SchemeBuilder.AddToScheme,
+		artemisv1beta1.SchemeBuilder.AddToScheme,
 		infinispanv1.AddToScheme,
 		keycloakv1alpha1.SchemeBuilder.AddToScheme,
 		operatormkt.SchemeBuilder.AddToScheme, olmapiv1.AddToScheme, olmapiv1alpha1.AddToScheme,
@@ -117,6 +125,10 @@ func GetRegisteredSchema() *runtime.Scheme {
 	metav1.AddToGroupVersion(s, routev1.GroupVersion)
 	metav1.AddToGroupVersion(s, infinispanv1.SchemeGroupVersion)
 	metav1.AddToGroupVersion(s, kafkabetav1.SchemeGroupVersion)
+	metav1.AddToGroupVersion(s, postgresqlv1.SchemeGroupVersion)
+	metav1.AddToGroupVersion(s, mongodbv1.SchemeGroupVersion)
+	metav1.AddToGroupVersion(s, jaegerv1.SchemeGroupVersion)
+	metav1.AddToGroupVersion(s, artemisv1beta1.SchemeGroupVersion)
 	metav1.AddToGroupVersion(s, grafana.SchemeGroupVersion)
 
 	return s
@@ -91,7 +91,7 @@ func (b *buildConfig) EnsureImageBuild(bc *buildv1.BuildConfig, labelSelector, i
 		Name:      imageName,
 		Namespace: bc.Namespace,
 	}
-	if img, err := ImageStreamC(b.client).FetchDockerImage(imageNamed); err != nil {
+	if img, err := ImageStreamC(b.client).FetchDockerImage(imageNamed, ""); err != nil {
 		return state, err
 	} else if img == nil {
 		log.Debugf("ImageStream not found for build %s", bc.Name)
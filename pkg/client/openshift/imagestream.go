@@ -37,9 +37,10 @@ const (
 
 // ImageStreamInterface exposes OpenShift ImageStream operations
 type ImageStreamInterface interface {
-	// FetchDockerImage fetches a docker image based on a ImageStreamTag with the defined key (namespace and name).
+	// FetchDockerImage fetches a docker image based on a ImageStreamTag with the defined key (namespace and name) and tag.
+	// If tag is nil or empty, will search for "latest".
 	// Returns nil if not found
-	FetchDockerImage(key types.NamespacedName) (*dockerv10.DockerImage, error)
+	FetchDockerImage(key types.NamespacedName, tag string) (*dockerv10.DockerImage, error)
 	// FetchTag fetches for a particular ImageStreamTag on OpenShift cluster.
 	// If tag is nil or empty, will search for "latest".
 	// Returns nil if the object was not found.
@@ -76,9 +77,9 @@ func (i *imageStream) FetchTag(key types.NamespacedName, tag string) (*imgv1.Ima
 	return isTag, err
 }
 
-func (i *imageStream) FetchDockerImage(key types.NamespacedName) (*dockerv10.DockerImage, error) {
+func (i *imageStream) FetchDockerImage(key types.NamespacedName, tag string) (*dockerv10.DockerImage, error) {
 	dockerImage := &dockerv10.DockerImage{}
-	isTag, err := i.FetchTag(key, "")
+	isTag, err := i.FetchTag(key, tag)
 	if err != nil {
 		return nil, err
 	} else if isTag == nil {
@@ -0,0 +1,202 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	artemisv1beta1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/artemis/v1beta1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// artemisCredentialSecretSuffix suffix of the Secret holding the broker credentials used by the Kogito services
+	artemisCredentialSecretSuffix = "-artemis-credential"
+
+	// appPropAMQPHost application property for setting the AMQP broker host
+	appPropAMQPHost int = iota
+	// appPropAMQPPort application property for setting the AMQP broker port
+	appPropAMQPPort
+	// appPropAMQPUseSSL application property for enabling TLS on the AMQP broker connection
+	appPropAMQPUseSSL
+	// envVarAMQPUsername environment variable for setting the AMQP broker username
+	envVarAMQPUsername
+	// envVarAMQPPassword environment variable for setting the AMQP broker password
+	envVarAMQPPassword
+)
+
+var (
+	//AMQP variables for the KogitoInfra deployed infrastructure.
+	//For Quarkus: https://quarkus.io/guides/amqp-reference#configuration-reference
+	//Spring Boot services don't use the SmallRye AMQP connector, hence no properties are provided for them.
+
+	// propertiesAMQPQuarkus AMQP properties for quarkus runtime
+	propertiesAMQPQuarkus = map[int]string{
+		appPropAMQPHost:   "amqp-host",
+		appPropAMQPPort:   "amqp-port",
+		appPropAMQPUseSSL: "amqp-use-ssl",
+
+		envVarAMQPUsername: "AMQP_USERNAME",
+		envVarAMQPPassword: "AMQP_PASSWORD",
+	}
+)
+
+// getAMQPAppProps creates the application properties connecting the SmallRye AMQP connector to the given broker
+func getAMQPAppProps(host string, port int32, useSSL bool) map[string]string {
+	return map[string]string{
+		propertiesAMQPQuarkus[appPropAMQPHost]:   host,
+		propertiesAMQPQuarkus[appPropAMQPPort]:   strconv.Itoa(int(port)),
+		propertiesAMQPQuarkus[appPropAMQPUseSSL]: strconv.FormatBool(useSSL),
+	}
+}
+
+// getAMQPEnvVars creates the environment variables enabling the events, authenticated with the credentials stored in the given Secret, if any
+func getAMQPEnvVars(secretName, usernameKey, passwordKey string) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{framework.CreateEnvVar(enableEventsEnvKey, "true")}
+	if len(secretName) > 0 {
+		envVars = append(envVars,
+			framework.CreateSecretEnvVar(propertiesAMQPQuarkus[envVarAMQPUsername], secretName, usernameKey),
+			framework.CreateSecretEnvVar(propertiesAMQPQuarkus[envVarAMQPPassword], secretName, passwordKey))
+	}
+	return envVars
+}
+
+// artemisInfraResource implementation of KogitoInfraResource
+type artemisInfraResource struct {
+}
+
+// getArtemisWatchedObjects provide list of object that needs to be watched to maintain ActiveMQ Artemis kogitoInfra resource
+func getArtemisWatchedObjects() []framework.WatchedObjects {
+	return []framework.WatchedObjects{
+		{
+			GroupVersion: artemisv1beta1.SchemeGroupVersion,
+			AddToScheme:  artemisv1beta1.SchemeBuilder.AddToScheme,
+			Objects:      []runtime.Object{&artemisv1beta1.ActiveMQArtemis{}},
+		},
+	}
+}
+
+// Reconcile reconcile Kogito infra object
+func (a *artemisInfraResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (requeue bool, resultErr error) {
+	var artemisInstance *artemisv1beta1.ActiveMQArtemis
+
+	if !infrastructure.IsArtemisAvailable(client) {
+		return false, newResourceAPINotFoundError(&instance.Spec.Resource)
+	}
+
+	if len(instance.Spec.Resource.Name) > 0 {
+		log.Debugf("Custom ActiveMQ Artemis instance reference is provided")
+		namespace := instance.Spec.Resource.Namespace
+		if len(namespace) == 0 {
+			namespace = instance.Namespace
+			log.Debugf("Namespace is not provided for custom resource, taking instance namespace(%s) as default", namespace)
+		}
+		if artemisInstance, resultErr = loadDeployedArtemisInstance(client, instance.Spec.Resource.Name, namespace); resultErr != nil {
+			return false, resultErr
+		} else if artemisInstance == nil {
+			return false, newResourceNotFoundError(infrastructure.ArtemisKind, instance.Spec.Resource.Name, namespace)
+		}
+	} else {
+		log.Debugf("Custom ActiveMQ Artemis instance reference is not provided")
+		artemisInstance, resultErr = loadDeployedArtemisInstance(client, infrastructure.ArtemisInstanceName, instance.Namespace)
+		if resultErr != nil {
+			return false, resultErr
+		}
+		if artemisInstance == nil {
+			// if not exist then create new ActiveMQ Artemis instance. ActiveMQ Artemis operator creates the broker pods, services & credentials
+			if resultErr = createNewArtemisInstance(client, infrastructure.ArtemisInstanceName, instance.Namespace, instance, scheme); resultErr != nil {
+				return false, resultErr
+			}
+			return true, nil
+		}
+	}
+	acceptor := infrastructure.GetArtemisAMQPAcceptor(artemisInstance)
+	if acceptor == nil {
+		return false, newInvalidResourceConfigurationError(fmt.Errorf("ActiveMQ Artemis instance %s doesn't define any acceptor for the %s protocol", artemisInstance.Name, artemisv1beta1.AMQPProtocol))
+	}
+	if !infrastructure.IsArtemisReady(artemisInstance) {
+		return false, newResourceNotReadyError(instance, fmt.Errorf("ActiveMQ Artemis instance %s not ready yet, no broker pod is ready", artemisInstance.Name))
+	}
+	secretName, resultErr := syncArtemisCredentialSecret(client, artemisInstance, instance, scheme)
+	if resultErr != nil {
+		return false, resultErr
+	}
+	port := acceptor.Port
+	if port == 0 {
+		port = infrastructure.ArtemisAMQPDefaultPort
+	}
+	instance.Status.AppProps = getAMQPAppProps(infrastructure.GetArtemisAMQPHost(artemisInstance, acceptor), port, acceptor.SSLEnabled)
+	instance.Status.Env = getAMQPEnvVars(secretName, defaultSecretUsernameKey, defaultSecretPasswordKey)
+	return false, nil
+}
+
+func loadDeployedArtemisInstance(cli *client.Client, name string, namespace string) (*artemisv1beta1.ActiveMQArtemis, error) {
+	log.Debug("fetching deployed kogito ActiveMQ Artemis instance")
+	artemisInstance := &artemisv1beta1.ActiveMQArtemis{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: name, Namespace: namespace}, artemisInstance); err != nil {
+		log.Error("Error occurs while fetching kogito ActiveMQ Artemis instance")
+		return nil, err
+	} else if !exists {
+		log.Debug("Kogito ActiveMQ Artemis instance does not exist")
+		return nil, nil
+	} else {
+		log.Debug("Kogito ActiveMQ Artemis instance found")
+		return artemisInstance, nil
+	}
+}
+
+func createNewArtemisInstance(cli *client.Client, name, namespace string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+	log.Debug("Going to create kogito ActiveMQ Artemis instance")
+	artemisInstance := infrastructure.GetArtemisDefaultResource(name, namespace)
+	if err := framework.SetOwner(instance, scheme, artemisInstance); err != nil {
+		return err
+	}
+	if err := kubernetes.ResourceC(cli).Create(artemisInstance); err != nil {
+		log.Error("Error occurs while creating kogito ActiveMQ Artemis instance")
+		return err
+	}
+	log.Debug("Kogito ActiveMQ Artemis instance created successfully")
+	return nil
+}
+
+// syncArtemisCredentialSecret copies the broker credentials generated by ActiveMQ Artemis operator into a Secret
+// in the KogitoInfra namespace, since the broker might be deployed in another namespace.
+func syncArtemisCredentialSecret(cli *client.Client, artemisInstance *artemisv1beta1.ActiveMQArtemis, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (string, error) {
+	credentialsSecretName := infrastructure.GetArtemisCredentialsSecretName(artemisInstance)
+	credentialsSecret := &corev1.Secret{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: credentialsSecretName, Namespace: artemisInstance.Namespace}, credentialsSecret); err != nil {
+		return "", err
+	} else if !exists {
+		return "", newResourceNotReadyError(instance, fmt.Errorf("credentials secret %s of ActiveMQ Artemis instance %s not created yet", credentialsSecretName, artemisInstance.Name))
+	}
+	data := map[string][]byte{
+		defaultSecretUsernameKey: credentialsSecret.Data[infrastructure.ArtemisCredentialsUserKey],
+		defaultSecretPasswordKey: credentialsSecret.Data[infrastructure.ArtemisCredentialsPasswordKey],
+	}
+
+	secretName := instance.Name + artemisCredentialSecretSuffix
+	if err := syncInfraSecret(cli, instance, secretName, data, scheme); err != nil {
+		return "", err
+	}
+	return secretName, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	artemisv1beta1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/artemis/v1beta1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Reconcile_ArtemisResource_CreateDefault(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-artemis", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.ArtemisAPIVersion,
+				Kind:       infrastructure.ArtemisKind,
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	artemis := &artemisv1beta1.ActiveMQArtemis{ObjectMeta: v1.ObjectMeta{Name: infrastructure.ArtemisInstanceName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, artemis)
+	assert.NotNil(t, infrastructure.GetArtemisAMQPAcceptor(artemis))
+
+	// broker not ready yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+
+	// credentials not generated yet
+	artemis.Status.PodStatus.Ready = []string{"kogito-artemis-ss-0"}
+	assert.NoError(t, kubernetes.ResourceC(client).Update(artemis))
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+
+	credentials := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-artemis-credentials-secret", Namespace: t.Name()},
+		Data: map[string][]byte{
+			infrastructure.ArtemisCredentialsUserKey:     []byte("admin"),
+			infrastructure.ArtemisCredentialsPasswordKey: []byte("secret"),
+		},
+	}
+	assert.NoError(t, kubernetes.ResourceC(client).Create(credentials))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	assert.Equal(t, "kogito-artemis-amqp-0-svc."+t.Name()+".svc", kogitoInfra.Status.AppProps[propertiesAMQPQuarkus[appPropAMQPHost]])
+	assert.Equal(t, "5672", kogitoInfra.Status.AppProps[propertiesAMQPQuarkus[appPropAMQPPort]])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesAMQPQuarkus[envVarAMQPUsername], "kogito-artemis-artemis-credential", defaultSecretUsernameKey))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesAMQPQuarkus[envVarAMQPPassword], "kogito-artemis-artemis-credential", defaultSecretPasswordKey))
+
+	secret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "kogito-artemis-artemis-credential", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, secret)
+	assert.Equal(t, []byte("admin"), secret.Data[defaultSecretUsernameKey])
+	assert.Equal(t, []byte("secret"), secret.Data[defaultSecretPasswordKey])
+}
+
+func Test_Reconcile_ArtemisResource_NoAMQPAcceptor(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-artemis", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.ArtemisAPIVersion,
+				Kind:       infrastructure.ArtemisKind,
+				Name:       "my-broker",
+			},
+		},
+	}
+	artemis := &artemisv1beta1.ActiveMQArtemis{
+		ObjectMeta: v1.ObjectMeta{Name: "my-broker", Namespace: t.Name()},
+		Spec: artemisv1beta1.ActiveMQArtemisSpec{
+			Acceptors: []artemisv1beta1.AcceptorType{{Name: "core", Protocols: "core", Port: 61616}},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, artemis).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.InvalidResourceConfiguration, kogitoInfra.Status.Condition.Reason)
+}
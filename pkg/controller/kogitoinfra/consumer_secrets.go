@@ -0,0 +1,147 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// consumerSecretLabel labels the copies of the Secrets published by a KogitoInfra in the namespaces of the services depending on it,
+// with the KogitoInfra name
+const consumerSecretLabel = "kogito.kie.org/infra-secret"
+
+// syncConsumerSecrets copies the Secrets published by the KogitoInfra instance into the namespaces of the Kogito services
+// of other namespaces depending on it, since pods can't reference Secrets of other namespaces.
+// Copies not needed anymore are deleted.
+func syncConsumerSecrets(cli *client.Client, instance *v1alpha1.KogitoInfra) error {
+	consumers, err := getConsumers(cli, instance)
+	if err != nil {
+		return err
+	}
+	var namespaces []string
+	for _, consumer := range consumers {
+		if len(consumer.Namespace) > 0 && !util.Contains(consumer.Namespace, namespaces) {
+			namespaces = append(namespaces, consumer.Namespace)
+		}
+	}
+	copies := map[types.NamespacedName]*corev1.Secret{}
+	if len(namespaces) > 0 {
+		for _, secretName := range infrastructure.GetKogitoInfraSecretNames(instance) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: instance.Namespace}}
+			if exists, err := kubernetes.ResourceC(cli).Fetch(secret); err != nil {
+				return err
+			} else if !exists {
+				return newResourceNotReadyError(instance, fmt.Errorf("secret %s not created yet", secretName))
+			}
+			for _, namespace := range namespaces {
+				secretCopy := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      infrastructure.GetKogitoInfraSecretName(instance, secretName, namespace),
+						Namespace: namespace,
+						Labels:    map[string]string{consumerSecretLabel: instance.Name},
+					},
+					Type: secret.Type,
+					Data: secret.Data,
+				}
+				setKogitoInfraCreator(secretCopy, instance)
+				copies[types.NamespacedName{Name: secretCopy.Name, Namespace: secretCopy.Namespace}] = secretCopy
+			}
+		}
+	}
+
+	deployed, err := getConsumerSecrets(cli, instance)
+	if err != nil {
+		return err
+	}
+	for i := range deployed {
+		key := types.NamespacedName{Name: deployed[i].Name, Namespace: deployed[i].Namespace}
+		secretCopy, required := copies[key]
+		if !required {
+			log.Debugf("Deleting secret %s in namespace %s, not used by the services of KogitoInfra %s anymore", key.Name, key.Namespace, instance.Name)
+			if err := kubernetes.ResourceC(cli).Delete(&deployed[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		delete(copies, key)
+		if !reflect.DeepEqual(deployed[i].Data, secretCopy.Data) {
+			log.Debugf("Secrets of KogitoInfra %s changed, updating secret %s in namespace %s", instance.Name, key.Name, key.Namespace)
+			deployed[i].Data = secretCopy.Data
+			if err := kubernetes.ResourceC(cli).Update(&deployed[i]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, secretCopy := range copies {
+		log.Debugf("Copying secret of KogitoInfra %s into namespace %s as %s", instance.Name, secretCopy.Namespace, secretCopy.Name)
+		if err := kubernetes.ResourceC(cli).Create(secretCopy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteConsumerSecrets deletes the copies of the Secrets published by the KogitoInfra instance in other namespaces
+func deleteConsumerSecrets(cli *client.Client, instance *v1alpha1.KogitoInfra) error {
+	secrets, err := getConsumerSecrets(cli, instance)
+	if err != nil {
+		return err
+	}
+	for i := range secrets {
+		log.Debugf("Deleting secret %s of KogitoInfra %s in namespace %s", secrets[i].Name, instance.Name, secrets[i].Namespace)
+		if err := kubernetes.ResourceC(cli).Delete(&secrets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getConsumerSecrets gets the copies of the Secrets published by the KogitoInfra instance in the namespaces allowed to reference it
+func getConsumerSecrets(cli *client.Client, instance *v1alpha1.KogitoInfra) ([]corev1.Secret, error) {
+	var created []corev1.Secret
+	for _, namespace := range getConsumerNamespaces(instance) {
+		if namespace == instance.Namespace {
+			continue
+		}
+		// SecretList is registered by the OpenShift image API as well, so its kind can't be guessed from the type
+		secrets := &unstructured.UnstructuredList{}
+		secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+		if err := kubernetes.ResourceC(cli).ListWithNamespaceAndLabel(namespace, secrets, map[string]string{consumerSecretLabel: instance.Name}); err != nil {
+			return nil, err
+		}
+		for _, item := range secrets.Items {
+			secret := corev1.Secret{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &secret); err != nil {
+				return nil, err
+			}
+			if secret.Namespace != instance.Namespace && secret.Annotations[kogitoInfraCreatorAnnotation] == getKogitoInfraCreatorKey(instance) {
+				created = append(created, secret)
+			}
+		}
+	}
+	return created, nil
+}
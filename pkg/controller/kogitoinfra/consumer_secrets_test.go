@@ -0,0 +1,74 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_syncConsumerSecrets(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka", Namespace: "kogito-infra"},
+		Spec:       v1alpha1.KogitoInfraSpec{AllowedNamespaces: []string{"team-a", "team-b"}},
+		Status: v1alpha1.KogitoInfraStatus{
+			Env: []corev1.EnvVar{framework.CreateSecretEnvVar("KAFKA_PASSWORD", "kogito-kafka-credential", "password")},
+		},
+	}
+	credentials := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka-credential", Namespace: "kogito-infra"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	teamARuntime := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "travels", Namespace: "team-a"},
+		Spec:       v1alpha1.KogitoRuntimeSpec{KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{"kogito-infra/kogito-kafka"}}},
+	}
+	// namespace not allowed
+	teamCRuntime := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "visas", Namespace: "team-c"},
+		Spec:       v1alpha1.KogitoRuntimeSpec{KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{"kogito-infra/kogito-kafka"}}},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, credentials, teamARuntime, teamCRuntime).Build()
+
+	consumers, err := getConsumers(cli, kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, []v1alpha1.KogitoInfraConsumer{{Kind: kogitoRuntimeKind, Name: "travels", Namespace: "team-a"}}, consumers)
+
+	assert.NoError(t, syncConsumerSecrets(cli, kogitoInfra))
+	secretCopy := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "kogito-infra-kogito-kafka-credential", Namespace: "team-a"}}
+	test.AssertFetchMustExist(t, cli, secretCopy)
+	assert.Equal(t, []byte("secret"), secretCopy.Data["password"])
+	test.AssertFetchMustNotExist(t, cli, &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: secretCopy.Name, Namespace: "team-b"}})
+
+	// copies are kept in sync
+	credentials.Data["password"] = []byte("changed")
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(credentials))
+	assert.NoError(t, syncConsumerSecrets(cli, kogitoInfra))
+	test.AssertFetchMustExist(t, cli, secretCopy)
+	assert.Equal(t, []byte("changed"), secretCopy.Data["password"])
+
+	// the service doesn't depend on the infra anymore
+	teamARuntime.Spec.Infra = nil
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(teamARuntime))
+	assert.NoError(t, syncConsumerSecrets(cli, kogitoInfra))
+	test.AssertFetchMustNotExist(t, cli, &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: secretCopy.Name, Namespace: "team-a"}})
+}
@@ -70,6 +70,14 @@ func newResourceNotReadyError(instance *v1alpha1.KogitoInfra, err error) reconci
 	}
 }
 
+// newInvalidResourceConfigurationError ...
+func newInvalidResourceConfigurationError(err error) reconciliationError {
+	return reconciliationError{
+		Reason:     v1alpha1.InvalidResourceConfiguration,
+		innerError: err,
+	}
+}
+
 func getSupportedResources() []string {
 	res := getSupportedInfraResources()
 	keys := make([]string, 0, len(res))
@@ -0,0 +1,285 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// externalInfraCertsPath is the base path where the certificate authorities of the external services are mounted
+	externalInfraCertsPath = "/home/kogito/certs"
+	// externalInfraCAVolumeSuffix suffix of the volume holding the certificate authority of the external service
+	externalInfraCAVolumeSuffix = "-ca"
+
+	defaultSecretUsernameKey = "username"
+	defaultSecretPasswordKey = "password"
+)
+
+// externalInfraResource implementation of KogitoInfraResource for services not managed by an operator in the cluster
+type externalInfraResource struct {
+}
+
+// Reconcile reconcile Kogito infra object
+func (e *externalInfraResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (requeue bool, resultErr error) {
+	external := instance.Spec.External
+	if resultErr = validateExternalInfra(external); resultErr != nil {
+		return false, newInvalidResourceConfigurationError(resultErr)
+	}
+	for _, secretName := range getExternalInfraSecretNames(external) {
+		if resultErr = verifyExternalInfraSecret(client, secretName, instance.Namespace); resultErr != nil {
+			return false, resultErr
+		}
+	}
+
+	appProps := map[string]string{}
+	var envVars []corev1.EnvVar
+	var volumes []v1alpha1.KogitoInfraVolume
+	if external.Kafka != nil {
+		log.Debugf("External Kafka connection information provided for %s", instance.Name)
+		appProps, envVars, volumes = getExternalKafkaProperties(instance, external.Kafka)
+	} else if external.Infinispan != nil {
+		log.Debugf("External Infinispan connection information provided for %s", instance.Name)
+		appProps, envVars, volumes = getExternalInfinispanProperties(instance, external.Infinispan)
+	} else if external.Keycloak != nil {
+		log.Debugf("External Keycloak connection information provided for %s", instance.Name)
+		appProps, envVars = getExternalKeycloakProperties(external.Keycloak)
+	} else if external.PostgreSQL != nil {
+		log.Debugf("External PostgreSQL connection information provided for %s", instance.Name)
+		appProps, envVars = getExternalPostgresqlProperties(external.PostgreSQL)
+	} else if external.MongoDB != nil {
+		log.Debugf("External MongoDB connection information provided for %s", instance.Name)
+		appProps, envVars = getMongoDBAppProps(external.MongoDB.Database),
+			getMongoDBEnvVars(external.MongoDB.ConnectionString.Name, external.MongoDB.ConnectionString.Key)
+	} else if external.Tracing != nil {
+		log.Debugf("External OpenTelemetry collector provided for %s", instance.Name)
+		appProps, envVars = getTracingAppProps(), getTracingEnvVars(external.Tracing.Endpoint, "")
+	} else if external.AMQP != nil {
+		log.Debugf("External AMQP broker connection information provided for %s", instance.Name)
+		appProps, envVars = getExternalAMQPProperties(external.AMQP)
+	}
+	instance.Status.AppProps = appProps
+	instance.Status.Env = envVars
+	instance.Status.Volumes = volumes
+	log.Debugf("Following app properties are set infra status : %s", appProps)
+	return false, nil
+}
+
+// validateExternalInfra verifies that exactly one external service is defined with its required connection information
+func validateExternalInfra(external *v1alpha1.ExternalInfra) error {
+	defined := 0
+	if external.Kafka != nil {
+		defined++
+		if len(external.Kafka.BootstrapServers) == 0 {
+			return fmt.Errorf("bootstrapServers must be provided for the external Kafka")
+		}
+	}
+	if external.Infinispan != nil {
+		defined++
+		if len(external.Infinispan.ServerList) == 0 {
+			return fmt.Errorf("serverList must be provided for the external Infinispan")
+		}
+	}
+	if external.Keycloak != nil {
+		defined++
+		if len(external.Keycloak.AuthServerURL) == 0 || len(external.Keycloak.ClientID) == 0 {
+			return fmt.Errorf("authServerURL and clientID must be provided for the external Keycloak")
+		}
+	}
+	if external.PostgreSQL != nil {
+		defined++
+		if len(external.PostgreSQL.Host) == 0 || len(external.PostgreSQL.Database) == 0 || len(external.PostgreSQL.Credentials.SecretName) == 0 {
+			return fmt.Errorf("host, database and credentials must be provided for the external PostgreSQL")
+		}
+	}
+	if external.MongoDB != nil {
+		defined++
+		if len(external.MongoDB.ConnectionString.Name) == 0 || len(external.MongoDB.ConnectionString.Key) == 0 || len(external.MongoDB.Database) == 0 {
+			return fmt.Errorf("connectionString and database must be provided for the external MongoDB")
+		}
+	}
+	if external.Tracing != nil {
+		defined++
+		if len(external.Tracing.Endpoint) == 0 {
+			return fmt.Errorf("endpoint must be provided for the external OpenTelemetry collector")
+		}
+	}
+	if external.AMQP != nil {
+		defined++
+		if len(external.AMQP.Host) == 0 {
+			return fmt.Errorf("host must be provided for the external AMQP broker")
+		}
+	}
+	if defined != 1 {
+		return fmt.Errorf("exactly one of kafka, infinispan, keycloak, postgresql, mongodb, tracing or amqp must be defined in the external infrastructure, found %d", defined)
+	}
+	return nil
+}
+
+// getExternalInfraSecretNames gets the names of the Secrets referenced by the external service
+func getExternalInfraSecretNames(external *v1alpha1.ExternalInfra) []string {
+	var credentials *v1alpha1.SecretCredentials
+	var tls *v1alpha1.ExternalTLS
+	var secretNames []string
+	if external.Kafka != nil {
+		credentials, tls = external.Kafka.Credentials, external.Kafka.TLS
+	} else if external.Infinispan != nil {
+		credentials, tls = external.Infinispan.Credentials, external.Infinispan.TLS
+	} else if external.Keycloak != nil && external.Keycloak.ClientSecret != nil {
+		secretNames = append(secretNames, external.Keycloak.ClientSecret.Name)
+	} else if external.PostgreSQL != nil {
+		credentials = &external.PostgreSQL.Credentials
+	} else if external.MongoDB != nil {
+		secretNames = append(secretNames, external.MongoDB.ConnectionString.Name)
+	} else if external.AMQP != nil {
+		credentials = external.AMQP.Credentials
+	}
+	if credentials != nil {
+		secretNames = append(secretNames, credentials.SecretName)
+	}
+	if tls != nil && tls.CASecret != nil && !util.Contains(tls.CASecret.Name, secretNames) {
+		secretNames = append(secretNames, tls.CASecret.Name)
+	}
+	return secretNames
+}
+
+func verifyExternalInfraSecret(cli *client.Client, name, namespace string) error {
+	log.Debugf("Verifying Secret %s referenced by the external infrastructure", name)
+	secret := &corev1.Secret{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		return err
+	} else if !exists {
+		return newResourceNotFoundError("Secret", name, namespace)
+	}
+	return nil
+}
+
+func getExternalKafkaProperties(instance *v1alpha1.KogitoInfra, kafka *v1alpha1.ExternalKafka) (map[string]string, []corev1.EnvVar, []v1alpha1.KogitoInfraVolume) {
+	appProps := getKafkaBootstrapAppProps(kafka.BootstrapServers)
+	envVars := []corev1.EnvVar{framework.CreateEnvVar(enableEventsEnvKey, "true")}
+	saslMechanism := ""
+	if kafka.Credentials != nil {
+		saslMechanism = kafka.SaslMechanism
+		if len(saslMechanism) == 0 {
+			saslMechanism = kafkaSaslMechanismScram512
+		}
+		usernameKey, passwordKey := getSecretCredentialsKeys(kafka.Credentials)
+		envVars = append(envVars, getKafkaSaslEnvVars(instance, kafka.Credentials.SecretName, usernameKey, passwordKey)...)
+	}
+	trustStore, volumes := getExternalTLSTrustStore(instance, kafka.TLS)
+	util.AppendToStringMap(getKafkaSecurityAppProps(instance, saslMechanism, kafka.TLS != nil, trustStore), appProps)
+	return appProps, envVars, volumes
+}
+
+func getExternalInfinispanProperties(instance *v1alpha1.KogitoInfra, infinispan *v1alpha1.ExternalInfinispan) (map[string]string, []corev1.EnvVar, []v1alpha1.KogitoInfraVolume) {
+	appProps := map[string]string{
+		propertiesInfinispanSpring[appPropInfinispanServerList]:  infinispan.ServerList,
+		propertiesInfinispanQuarkus[appPropInfinispanServerList]: infinispan.ServerList,
+	}
+	envVars := []corev1.EnvVar{framework.CreateEnvVar(enablePersistenceEnvKey, "true")}
+	if infinispan.Credentials != nil {
+		saslMechanism := infinispan.SaslMechanism
+		if len(saslMechanism) == 0 {
+			saslMechanism = saslPlain
+		}
+		appProps[propertiesInfinispanSpring[appPropInfinispanUseAuth]] = "true"
+		appProps[propertiesInfinispanQuarkus[appPropInfinispanUseAuth]] = "true"
+		appProps[propertiesInfinispanSpring[appPropInfinispanSaslMechanism]] = saslMechanism
+		appProps[propertiesInfinispanQuarkus[appPropInfinispanSaslMechanism]] = saslMechanism
+		if len(infinispan.AuthRealm) > 0 {
+			appProps[propertiesInfinispanSpring[appPropInfinispanAuthRealm]] = infinispan.AuthRealm
+			appProps[propertiesInfinispanQuarkus[appPropInfinispanAuthRealm]] = infinispan.AuthRealm
+		}
+		usernameKey, passwordKey := getSecretCredentialsKeys(infinispan.Credentials)
+		envVars = append(envVars, getInfinispanCredentialEnvVars(infinispan.Credentials.SecretName, usernameKey, passwordKey)...)
+	} else {
+		appProps[propertiesInfinispanSpring[appPropInfinispanUseAuth]] = "false"
+		appProps[propertiesInfinispanQuarkus[appPropInfinispanUseAuth]] = "false"
+	}
+	trustStore, volumes := getExternalTLSTrustStore(instance, infinispan.TLS)
+	if infinispan.TLS != nil {
+		util.AppendToStringMap(getInfinispanTLSAppProps(trustStore), appProps)
+	}
+	util.AppendToStringMap(getInfinispanCacheNamingAppProps(instance), appProps)
+	return appProps, envVars, volumes
+}
+
+func getExternalKeycloakProperties(keycloak *v1alpha1.ExternalKeycloak) (map[string]string, []corev1.EnvVar) {
+	var envVars []corev1.EnvVar
+	if keycloak.ClientSecret != nil {
+		envVars = getKeycloakClientSecretEnvVars(keycloak.ClientSecret.Name, keycloak.ClientSecret.Key)
+	}
+	return getKeycloakAppProps(keycloak.AuthServerURL, keycloak.ClientID), envVars
+}
+
+func getExternalPostgresqlProperties(postgresql *v1alpha1.ExternalPostgreSQL) (map[string]string, []corev1.EnvVar) {
+	port := postgresql.Port
+	if port == 0 {
+		port = infrastructure.PostgresqlDefaultPort
+	}
+	usernameKey, passwordKey := getSecretCredentialsKeys(&postgresql.Credentials)
+	return getPostgresqlAppProps(fmt.Sprintf("%s:%d", postgresql.Host, port), postgresql.Database),
+		getPostgresqlEnvVars(postgresql.Credentials.SecretName, usernameKey, passwordKey)
+}
+
+func getExternalAMQPProperties(amqp *v1alpha1.ExternalAMQP) (map[string]string, []corev1.EnvVar) {
+	port := amqp.Port
+	if port == 0 {
+		port = infrastructure.ArtemisAMQPDefaultPort
+	}
+	if amqp.Credentials == nil {
+		return getAMQPAppProps(amqp.Host, port, amqp.UseSSL), getAMQPEnvVars("", "", "")
+	}
+	usernameKey, passwordKey := getSecretCredentialsKeys(amqp.Credentials)
+	return getAMQPAppProps(amqp.Host, port, amqp.UseSSL), getAMQPEnvVars(amqp.Credentials.SecretName, usernameKey, passwordKey)
+}
+
+// getExternalTLSTrustStore gets the path of the PEM certificate authority within the service container and the volume
+// mounting it. Returns empty values if the certificate authority is not provided.
+func getExternalTLSTrustStore(instance *v1alpha1.KogitoInfra, tls *v1alpha1.ExternalTLS) (string, []v1alpha1.KogitoInfraVolume) {
+	if tls == nil || tls.CASecret == nil {
+		return "", nil
+	}
+	mountPath := path.Join(externalInfraCertsPath, instance.Name)
+	volume := v1alpha1.KogitoInfraVolume{
+		Name:       instance.Name + externalInfraCAVolumeSuffix,
+		SecretName: tls.CASecret.Name,
+		MountPath:  mountPath,
+	}
+	return path.Join(mountPath, tls.CASecret.Key), []v1alpha1.KogitoInfraVolume{volume}
+}
+
+// getSecretCredentialsKeys gets the username and password keys of the credentials, falling back to the defaults
+func getSecretCredentialsKeys(credentials *v1alpha1.SecretCredentials) (usernameKey, passwordKey string) {
+	usernameKey, passwordKey = credentials.UsernameKey, credentials.PasswordKey
+	if len(usernameKey) == 0 {
+		usernameKey = defaultSecretUsernameKey
+	}
+	if len(passwordKey) == 0 {
+		passwordKey = defaultSecretPasswordKey
+	}
+	return
+}
@@ -0,0 +1,285 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure/services"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Reconcile_ExternalKafka(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "managed-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			External: &v1alpha1.ExternalInfra{
+				Kafka: &v1alpha1.ExternalKafka{
+					BootstrapServers: "my-kafka:9093",
+					Credentials:      &v1alpha1.SecretCredentials{SecretName: "kafka-credentials", UsernameKey: "user"},
+					TLS:              &v1alpha1.ExternalTLS{CASecret: &v1alpha1.SecretKeyReference{Name: "kafka-ca", Key: "ca.crt"}},
+				},
+			},
+		},
+	}
+	credentials := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "kafka-credentials", Namespace: t.Name()}}
+	ca := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "kafka-ca", Namespace: t.Name()}}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, credentials, ca).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	exists, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.True(t, exists)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+
+	appProps := kogitoInfra.Status.AppProps
+	assert.Equal(t, "my-kafka:9093", appProps[services.QuarkusKafkaBootstrapAppProp])
+	assert.Equal(t, "my-kafka:9093", appProps[springKafkaBootstrapAppProp])
+	assert.Equal(t, kafkaSecurityProtocolSaslSSL, appProps[quarkusKafkaSecurityProtocolAppProp])
+	assert.Equal(t, kafkaSecurityProtocolSaslSSL, appProps[springKafkaSecurityProtocolAppProp])
+	assert.Equal(t, kafkaSaslMechanismScram512, appProps[quarkusKafkaSaslMechanismAppProp])
+	assert.Contains(t, appProps[quarkusKafkaSaslJaasConfigAppProp], kafkaScramLoginModule)
+	assert.Equal(t, "/home/kogito/certs/managed-kafka/ca.crt", appProps[quarkusKafkaTrustStoreLocationAppProp])
+	assert.Equal(t, "file:/home/kogito/certs/managed-kafka/ca.crt", appProps[springKafkaTrustStoreLocationAppProp])
+
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(enableEventsEnvKey, "true"))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(getKafkaEnvKey(kafkaSaslUsernameEnvKey, kogitoInfra), "kafka-credentials", "user"))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(getKafkaEnvKey(kafkaSaslPasswordEnvKey, kogitoInfra), "kafka-credentials", defaultSecretPasswordKey))
+
+	assert.Len(t, kogitoInfra.Status.Volumes, 1)
+	assert.Equal(t, "kafka-ca", kogitoInfra.Status.Volumes[0].SecretName)
+	assert.Equal(t, "/home/kogito/certs/managed-kafka", kogitoInfra.Status.Volumes[0].MountPath)
+}
+
+func Test_Reconcile_ExternalInfinispan(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "managed-infinispan", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			External: &v1alpha1.ExternalInfra{
+				Infinispan: &v1alpha1.ExternalInfinispan{
+					ServerList:  "my-infinispan:11222",
+					Credentials: &v1alpha1.SecretCredentials{SecretName: "infinispan-credentials"},
+					TLS:         &v1alpha1.ExternalTLS{},
+				},
+			},
+			ResourceNaming: &v1alpha1.ResourceNamingPolicy{Prefix: "$(NAMESPACE)-"},
+		},
+	}
+	credentials := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "infinispan-credentials", Namespace: t.Name()}}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, credentials).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+
+	appProps := kogitoInfra.Status.AppProps
+	assert.Equal(t, "my-infinispan:11222", appProps[propertiesInfinispanQuarkus[appPropInfinispanServerList]])
+	assert.Equal(t, "true", appProps[propertiesInfinispanQuarkus[appPropInfinispanUseAuth]])
+	assert.Equal(t, saslPlain, appProps[propertiesInfinispanSpring[appPropInfinispanSaslMechanism]])
+	assert.Equal(t, "true", appProps[propertiesInfinispanSpring[appPropInfinispanUseSSL]])
+	assert.NotContains(t, appProps, propertiesInfinispanQuarkus[appPropInfinispanTrustStore])
+	assert.Equal(t, infrastructure.KogitoServiceNamespacePlaceholder+"-", appProps[kogitoInfinispanCachePrefixAppProp])
+	assert.NotContains(t, appProps, kogitoInfinispanCacheSuffixAppProp)
+
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(enablePersistenceEnvKey, "true"))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesInfinispanQuarkus[envVarInfinispanUser], "infinispan-credentials", defaultSecretUsernameKey))
+	assert.Empty(t, kogitoInfra.Status.Volumes)
+}
+
+func Test_Reconcile_ExternalKeycloak(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "managed-keycloak", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			External: &v1alpha1.ExternalInfra{
+				Keycloak: &v1alpha1.ExternalKeycloak{
+					AuthServerURL: "https://my-keycloak/auth/realms/kogito",
+					ClientID:      "kogito-app",
+					ClientSecret:  &v1alpha1.SecretKeyReference{Name: "keycloak-client", Key: "secret"},
+				},
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	// client secret not created yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotFound, kogitoInfra.Status.Condition.Reason)
+
+	clientSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "keycloak-client", Namespace: t.Name()}}
+	assert.NoError(t, kubernetes.ResourceC(client).Create(clientSecret))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	assert.Equal(t, "https://my-keycloak/auth/realms/kogito", kogitoInfra.Status.AppProps[quarkusOidcAuthServerURLAppProp])
+	assert.Equal(t, "kogito-app", kogitoInfra.Status.AppProps[springClientIDAppProp])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(quarkusOidcClientSecretEnvKey, "keycloak-client", "secret"))
+}
+
+func Test_Reconcile_ExternalPostgresql(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "managed-postgresql", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			External: &v1alpha1.ExternalInfra{
+				PostgreSQL: &v1alpha1.ExternalPostgreSQL{
+					Host:        "my-postgresql",
+					Database:    "kogito",
+					Credentials: v1alpha1.SecretCredentials{SecretName: "postgresql-credentials", PasswordKey: "database-password"},
+				},
+			},
+		},
+	}
+	credentials := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "postgresql-credentials", Namespace: t.Name()}}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, credentials).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	assert.Equal(t, "postgresql", kogitoInfra.Status.AppProps[propertiesPostgresqlQuarkus[appPropPostgresqlDBKind]])
+	assert.Equal(t, "jdbc:postgresql://my-postgresql:5432/kogito", kogitoInfra.Status.AppProps[propertiesPostgresqlQuarkus[appPropPostgresqlJdbcURL]])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesPostgresqlSpring[envVarPostgresqlUser], "postgresql-credentials", defaultSecretUsernameKey))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesPostgresqlSpring[envVarPostgresqlPassword], "postgresql-credentials", "database-password"))
+}
+
+func Test_Reconcile_ExternalMongoDB(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "managed-mongodb", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			External: &v1alpha1.ExternalInfra{
+				MongoDB: &v1alpha1.ExternalMongoDB{
+					ConnectionString: v1alpha1.SecretKeyReference{Name: "mongodb-connection", Key: "uri"},
+					Database:         "processes",
+				},
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	// secret not created yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotFound, kogitoInfra.Status.Condition.Reason)
+
+	connection := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "mongodb-connection", Namespace: t.Name()}}
+	assert.NoError(t, kubernetes.ResourceC(client).Create(connection))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	assert.Equal(t, "processes", kogitoInfra.Status.AppProps[propertiesMongoDBQuarkus[appPropMongoDBDatabase]])
+	assert.Equal(t, "processes", kogitoInfra.Status.AppProps[propertiesMongoDBSpring[appPropMongoDBDatabase]])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesMongoDBQuarkus[envVarMongoDBConnectionString], "mongodb-connection", "uri"))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesMongoDBSpring[envVarMongoDBConnectionString], "mongodb-connection", "uri"))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(enablePersistenceEnvKey, "true"))
+}
+
+func Test_Reconcile_ExternalTracing(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "otel-collector", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			External: &v1alpha1.ExternalInfra{Tracing: &v1alpha1.ExternalTracing{Endpoint: "http://otel-collector:4317"}},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	assert.Equal(t, "true", kogitoInfra.Status.AppProps[propertiesTracingQuarkus[appPropTracingEnabled]])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(propertiesTracingQuarkus[envVarTracingOTLPEndpoint], "http://otel-collector:4317"))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(propertiesTracingSpring[envVarTracingOTLPEndpoint], "http://otel-collector:4317"))
+	for _, env := range kogitoInfra.Status.Env {
+		assert.NotEqual(t, propertiesTracingQuarkus[envVarTracingJaegerEndpoint], env.Name)
+	}
+}
+
+func Test_Reconcile_ExternalAMQP(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "managed-amqp", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			External: &v1alpha1.ExternalInfra{
+				AMQP: &v1alpha1.ExternalAMQP{
+					Host:        "my-broker",
+					UseSSL:      true,
+					Credentials: &v1alpha1.SecretCredentials{SecretName: "amqp-credentials"},
+				},
+			},
+		},
+	}
+	credentials := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "amqp-credentials", Namespace: t.Name()}}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, credentials).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	assert.Equal(t, "my-broker", kogitoInfra.Status.AppProps[propertiesAMQPQuarkus[appPropAMQPHost]])
+	assert.Equal(t, "5672", kogitoInfra.Status.AppProps[propertiesAMQPQuarkus[appPropAMQPPort]])
+	assert.Equal(t, "true", kogitoInfra.Status.AppProps[propertiesAMQPQuarkus[appPropAMQPUseSSL]])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesAMQPQuarkus[envVarAMQPUsername], "amqp-credentials", defaultSecretUsernameKey))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesAMQPQuarkus[envVarAMQPPassword], "amqp-credentials", defaultSecretPasswordKey))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(enableEventsEnvKey, "true"))
+}
+
+func Test_validateExternalInfra(t *testing.T) {
+	tests := []struct {
+		name     string
+		external *v1alpha1.ExternalInfra
+		wantErr  bool
+	}{
+		{"Kafka", &v1alpha1.ExternalInfra{Kafka: &v1alpha1.ExternalKafka{BootstrapServers: "kafka:9092"}}, false},
+		{"No service", &v1alpha1.ExternalInfra{}, true},
+		{"Missing bootstrap servers", &v1alpha1.ExternalInfra{Kafka: &v1alpha1.ExternalKafka{}}, true},
+		{"MongoDB", &v1alpha1.ExternalInfra{MongoDB: &v1alpha1.ExternalMongoDB{
+			ConnectionString: v1alpha1.SecretKeyReference{Name: "mongodb", Key: "uri"}, Database: "kogito"}}, false},
+		{"Missing MongoDB connection string", &v1alpha1.ExternalInfra{MongoDB: &v1alpha1.ExternalMongoDB{Database: "kogito"}}, true},
+		{"Tracing", &v1alpha1.ExternalInfra{Tracing: &v1alpha1.ExternalTracing{Endpoint: "http://otel-collector:4317"}}, false},
+		{"Missing tracing endpoint", &v1alpha1.ExternalInfra{Tracing: &v1alpha1.ExternalTracing{}}, true},
+		{"AMQP", &v1alpha1.ExternalInfra{AMQP: &v1alpha1.ExternalAMQP{Host: "my-broker"}}, false},
+		{"Missing AMQP host", &v1alpha1.ExternalInfra{AMQP: &v1alpha1.ExternalAMQP{Port: 5671}}, true},
+		{"More than one service", &v1alpha1.ExternalInfra{
+			Kafka:      &v1alpha1.ExternalKafka{BootstrapServers: "kafka:9092"},
+			Infinispan: &v1alpha1.ExternalInfinispan{ServerList: "infinispan:11222"},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateExternalInfra(tt.external); (err != nil) != tt.wantErr {
+				t.Errorf("validateExternalInfra() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// kogitoInfraFinalizer is added to every KogitoInfra to release the resources it created that can't be garbage collected,
+	// such as resources in other namespaces, and to hold its deletion while Kogito services depend on it
+	kogitoInfraFinalizer = "kogito.kie.org/infra"
+	// kogitoInfraCreatorAnnotation references the KogitoInfra, as namespace/name, that created a resource in another namespace
+	kogitoInfraCreatorAnnotation = "kogito.kie.org/created-by-infra"
+)
+
+// InfraResourceFinalizer is implemented by the infra resources creating objects that are not garbage collected with the KogitoInfra
+type InfraResourceFinalizer interface {
+	Finalize(client *client.Client, instance *v1alpha1.KogitoInfra) error
+}
+
+// addKogitoInfraFinalizer adds the finalizer to the given KogitoInfra if not added yet
+func addKogitoInfraFinalizer(cli *client.Client, instance *v1alpha1.KogitoInfra) error {
+	if controllerutil.ContainsFinalizer(instance, kogitoInfraFinalizer) {
+		return nil
+	}
+	controllerutil.AddFinalizer(instance, kogitoInfraFinalizer)
+	return kubernetes.ResourceC(cli).Update(instance)
+}
+
+// finalizeKogitoInfra releases the resources of the KogitoInfra being deleted and removes its finalizer.
+// Returns true if the deletion is held because Kogito services still depend on the KogitoInfra.
+func finalizeKogitoInfra(cli *client.Client, instance *v1alpha1.KogitoInfra) (bool, error) {
+	if !controllerutil.ContainsFinalizer(instance, kogitoInfraFinalizer) {
+		return false, nil
+	}
+	consumers, err := getConsumers(cli, instance)
+	if err != nil {
+		return false, err
+	}
+	if len(consumers) > 0 {
+		if instance.Spec.DeletionPolicy != v1alpha1.WarnDeletionPolicy {
+			message := fmt.Sprintf("KogitoInfra is being deleted, waiting for the services depending on it to stop using it: %s", getConsumerNames(consumers))
+			log.Infof("Holding the deletion of KogitoInfra %s: %s", instance.Name, message)
+			instance.Status.Consumers = consumers
+			setCondition(instance, v1alpha1.ReadyInfraConditionType, corev1.ConditionFalse, v1alpha1.DeletionBlocked, message)
+			return true, kubernetes.ResourceC(cli).UpdateStatus(instance)
+		}
+		log.Warnf("Deleting KogitoInfra %s still used by services %s", instance.Name, getConsumerNames(consumers))
+	}
+	if err := deleteConsumerSecrets(cli, instance); err != nil {
+		return false, err
+	}
+	if infraResource, err := getKogitoInfraResource(instance); err == nil {
+		if finalizer, ok := infraResource.(InfraResourceFinalizer); ok {
+			if err := finalizer.Finalize(cli, instance); err != nil {
+				return false, err
+			}
+		}
+	}
+	controllerutil.RemoveFinalizer(instance, kogitoInfraFinalizer)
+	return false, kubernetes.ResourceC(cli).Update(instance)
+}
+
+func getConsumerNames(consumers []v1alpha1.KogitoInfraConsumer) []string {
+	names := make([]string, 0, len(consumers))
+	for _, consumer := range consumers {
+		if len(consumer.Namespace) > 0 {
+			names = append(names, fmt.Sprintf("%s %s/%s", consumer.Kind, consumer.Namespace, consumer.Name))
+		} else {
+			names = append(names, fmt.Sprintf("%s %s", consumer.Kind, consumer.Name))
+		}
+	}
+	return names
+}
+
+// getKogitoInfraCreatorKey gets the value of the creator annotation set in the resources created by the given KogitoInfra
+func getKogitoInfraCreatorKey(instance *v1alpha1.KogitoInfra) string {
+	return types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}.String()
+}
+
+// setKogitoInfraCreator annotates the given resource, created by the KogitoInfra in another namespace, to delete it with the KogitoInfra
+func setKogitoInfraCreator(resource meta.ResourceObject, instance *v1alpha1.KogitoInfra) {
+	annotations := resource.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[kogitoInfraCreatorAnnotation] = getKogitoInfraCreatorKey(instance)
+	resource.SetAnnotations(annotations)
+}
+
+// deleteCreatedResources deletes the resources of the given list kind created by the KogitoInfra in the given namespace
+func deleteCreatedResources(cli *client.Client, namespace string, instance *v1alpha1.KogitoInfra, list runtime.Object) error {
+	if err := kubernetes.ResourceC(cli).ListWithNamespace(namespace, list); err != nil {
+		return err
+	}
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		resource, ok := item.(meta.ResourceObject)
+		if !ok || resource.GetAnnotations()[kogitoInfraCreatorAnnotation] != getKogitoInfraCreatorKey(instance) {
+			continue
+		}
+		log.Debugf("Deleting %s created by KogitoInfra %s in namespace %s", resource.GetName(), instance.Name, namespace)
+		if err := kubernetes.ResourceC(cli).Delete(resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/kafka/v1beta1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newDeletedKafkaInfra(t *testing.T, kafkaNamespace string) *v1alpha1.KogitoInfra {
+	now := v1.Now()
+	return &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              "kogito-kafka",
+			Namespace:         t.Name(),
+			DeletionTimestamp: &now,
+			Finalizers:        []string{kogitoInfraFinalizer},
+		},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KafkaAPIVersion,
+				Kind:       infrastructure.KafkaKind,
+				Name:       "my-kafka",
+				Namespace:  kafkaNamespace,
+			},
+		},
+	}
+}
+
+func Test_Reconcile_AddFinalizer(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{APIVersion: infrastructure.KafkaAPIVersion, Kind: infrastructure.KafkaKind, Name: "my-kafka"},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	test.AssertFetchMustExist(t, client, kogitoInfra)
+	assert.True(t, controllerutil.ContainsFinalizer(kogitoInfra, kogitoInfraFinalizer))
+}
+
+func Test_Reconcile_DeletionBlocked(t *testing.T) {
+	kogitoInfra := newDeletedKafkaInfra(t, "")
+	kogitoRuntime := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "travels", Namespace: t.Name()},
+		Spec:       v1alpha1.KogitoRuntimeSpec{KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{kogitoInfra.Name}}},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, kogitoRuntime).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	test.AssertFetchMustExist(t, client, kogitoInfra)
+	assert.True(t, controllerutil.ContainsFinalizer(kogitoInfra, kogitoInfraFinalizer))
+	ready := kogitoInfra.Status.GetCondition(v1alpha1.ReadyInfraConditionType)
+	assert.NotNil(t, ready)
+	assert.Equal(t, v1alpha1.DeletionBlocked, ready.Reason)
+	assert.Equal(t, []v1alpha1.KogitoInfraConsumer{{Kind: kogitoRuntimeKind, Name: kogitoRuntime.Name}}, kogitoInfra.Status.Consumers)
+
+	// the service doesn't depend on the infra anymore
+	kogitoRuntime.Spec.Infra = nil
+	assert.NoError(t, kubernetes.ResourceC(client).Update(kogitoRuntime))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	deletedInfra := &v1alpha1.KogitoInfra{ObjectMeta: v1.ObjectMeta{Name: kogitoInfra.Name, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, deletedInfra)
+	assert.False(t, controllerutil.ContainsFinalizer(deletedInfra, kogitoInfraFinalizer))
+}
+
+func Test_Reconcile_DeletionWarn_ReleasesKafkaResourcesInOtherNamespace(t *testing.T) {
+	kafkaNamespace := "kafka"
+	kogitoInfra := newDeletedKafkaInfra(t, kafkaNamespace)
+	kogitoInfra.Spec.DeletionPolicy = v1alpha1.WarnDeletionPolicy
+	kogitoRuntime := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "travels", Namespace: t.Name()},
+		Spec:       v1alpha1.KogitoRuntimeSpec{KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{kogitoInfra.Name}}},
+	}
+	kafkaUser := &v1beta1.KafkaUser{ObjectMeta: v1.ObjectMeta{Name: kogitoInfra.Name, Namespace: kafkaNamespace}}
+	setKogitoInfraCreator(kafkaUser, kogitoInfra)
+	otherKafkaUser := &v1beta1.KafkaUser{ObjectMeta: v1.ObjectMeta{Name: "other", Namespace: kafkaNamespace}}
+	travelsKey := infrastructure.GetKafkaTopicServiceKey(t.Name(), kogitoRuntime.Name)
+	unusedTopic := infrastructure.GetKafkaTopic("travellers", kafkaNamespace, "my-kafka", nil)
+	infrastructure.SetKafkaTopicServices(unusedTopic, []string{travelsKey})
+	sharedTopic := infrastructure.GetKafkaTopic("visas", kafkaNamespace, "my-kafka", nil)
+	infrastructure.SetKafkaTopicServices(sharedTopic, []string{travelsKey, "visas/visas"})
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, kogitoRuntime, kafkaUser, otherKafkaUser, unusedTopic, sharedTopic).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	deletedInfra := &v1alpha1.KogitoInfra{ObjectMeta: v1.ObjectMeta{Name: kogitoInfra.Name, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, deletedInfra)
+	assert.False(t, controllerutil.ContainsFinalizer(deletedInfra, kogitoInfraFinalizer))
+	test.AssertFetchMustNotExist(t, client, kafkaUser)
+	test.AssertFetchMustExist(t, client, otherKafkaUser)
+	test.AssertFetchMustNotExist(t, client, unusedTopic)
+	test.AssertFetchMustExist(t, client, sharedTopic)
+	assert.Equal(t, []string{"visas/visas"}, infrastructure.GetKafkaTopicServices(sharedTopic))
+}
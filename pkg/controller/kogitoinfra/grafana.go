@@ -0,0 +1,102 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+
+	grafanav1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// grafanaInfraResource for Grafana resources reconciliation
+type grafanaInfraResource struct{}
+
+// getGrafanaWatchedObjects provide list of object that needs to be watched to maintain Grafana kogitoInfra resource
+func getGrafanaWatchedObjects() []framework.WatchedObjects {
+	return []framework.WatchedObjects{
+		{
+			GroupVersion: grafanav1.SchemeGroupVersion,
+			AddToScheme:  grafanav1.AddToScheme,
+			Objects:      []runtime.Object{&grafanav1.Grafana{}},
+		},
+	}
+}
+
+// Reconcile reconcile Kogito infra object
+func (g *grafanaInfraResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (requeue bool, resultErr error) {
+	var grafana *grafanav1.Grafana
+
+	if !infrastructure.IsGrafanaAvailable(client) {
+		return false, newResourceAPINotFoundError(&instance.Spec.Resource)
+	}
+
+	resource := getResolvedResource(instance)
+	if grafana, resultErr = loadDeployedGrafana(client, resource.Name, resource.Namespace); resultErr != nil {
+		return false, resultErr
+	}
+	if grafana == nil {
+		if len(instance.Spec.Resource.Name) > 0 {
+			return false, newResourceNotFoundError(infrastructure.GrafanaKind, resource.Name, resource.Namespace)
+		}
+		log.Debugf("Grafana reference is not provided")
+		if resultErr = createNewGrafana(client, resource.Name, resource.Namespace, instance, scheme); resultErr != nil {
+			return false, resultErr
+		}
+		return true, nil
+	}
+	if !infrastructure.IsGrafanaReady(grafana) {
+		return false, newResourceNotReadyError(instance, fmt.Errorf("Grafana %s not ready yet: %s", grafana.Name, grafana.Status.Message))
+	}
+	return false, nil
+}
+
+func loadDeployedGrafana(cli *client.Client, name, namespace string) (*grafanav1.Grafana, error) {
+	log.Debug("fetching deployed Grafana")
+	grafana := &grafanav1.Grafana{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: name, Namespace: namespace}, grafana); err != nil {
+		log.Error("Error occurs while fetching Grafana")
+		return nil, err
+	} else if !exists {
+		log.Debug("Grafana does not exist")
+		return nil, nil
+	} else {
+		log.Debug("Grafana found")
+		return grafana, nil
+	}
+}
+
+// createNewGrafana creates the default Grafana instance, along with a data source querying the Prometheus instances of its namespace
+func createNewGrafana(cli *client.Client, name, namespace string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+	log.Debug("Going to create Grafana")
+	dataSource := infrastructure.GetGrafanaDefaultDataSource(name, namespace)
+	if _, err := kubernetes.ResourceC(cli).CreateIfNotExistsForOwner(dataSource, instance, scheme); err != nil {
+		log.Error("Error occurs while creating Grafana data source")
+		return err
+	}
+	grafana := infrastructure.GetGrafanaDefaultResource(name, namespace)
+	if err := kubernetes.ResourceC(cli).CreateForOwner(grafana, instance, scheme); err != nil {
+		log.Error("Error occurs while creating Grafana")
+		return err
+	}
+	log.Debug("Grafana created successfully")
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	grafanav1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Reconcile_GrafanaResource_CreateDefault(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-grafana", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.GrafanaAPIVersion,
+				Kind:       infrastructure.GrafanaKind,
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	grafana := &grafanav1.Grafana{ObjectMeta: v1.ObjectMeta{Name: infrastructure.GrafanaInstanceName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, grafana)
+	assert.Len(t, grafana.Spec.DashboardLabelSelector, 1)
+	assert.Equal(t, infrastructure.GetMonitoringLabels(), grafana.Spec.DashboardLabelSelector[0].MatchLabels)
+	dataSource := &grafanav1.GrafanaDataSource{ObjectMeta: v1.ObjectMeta{Name: infrastructure.GrafanaInstanceName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, dataSource)
+	assert.Equal(t, "http://prometheus-operated."+t.Name()+".svc:9090", dataSource.Spec.Datasources[0].Url)
+
+	// grafana not ready yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+
+	grafana.Status.Phase = grafanav1.PhaseReconciling
+	assert.NoError(t, kubernetes.ResourceC(client).Update(grafana))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+}
+
+func Test_Reconcile_GrafanaResource_NotFound(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-grafana", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.GrafanaAPIVersion,
+				Kind:       infrastructure.GrafanaKind,
+				Name:       "my-grafana",
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotFound, kogitoInfra.Status.Condition.Reason)
+	test.AssertFetchMustNotExist(t, client, &grafanav1.Grafana{ObjectMeta: v1.ObjectMeta{Name: infrastructure.GrafanaInstanceName, Namespace: t.Name()}})
+}
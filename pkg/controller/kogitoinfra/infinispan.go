@@ -22,18 +22,24 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"path"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
-	secretName   = "kogito-infinispan-credential"
 	replicasSize = 1
 
+	// infinispanCredentialSecretSuffix suffix of the Secret holding the credentials and the certificate of the infinispan server
+	infinispanCredentialSecretSuffix = "-infinispan-credential"
+	// infinispanCredentialVolumeSuffix suffix of the volume mounting the certificate of the infinispan server
+	infinispanCredentialVolumeSuffix = "-infinispan-credential"
+
 	// appPropInfinispanServerList application property for setting infinispan server
 	appPropInfinispanServerList int = iota
 	// appPropInfinispanUseAuth application property for enabling infinispan authentication
@@ -48,8 +54,18 @@ const (
 	envVarInfinispanUser
 	// envVarInfinispanPassword environment variable for setting infinispan password
 	envVarInfinispanPassword
-	infinispanEnvKeyCredSecret = "INFINISPAN_CREDENTIAL_SECRET"
-	enablePersistenceEnvKey    = "ENABLE_PERSISTENCE"
+	// appPropInfinispanUseSSL application property for enabling infinispan TLS, only required by Spring Boot
+	appPropInfinispanUseSSL
+	// appPropInfinispanTrustStore application property for setting the infinispan truststore
+	appPropInfinispanTrustStore
+	// appPropInfinispanTrustStoreType application property for setting the infinispan truststore type
+	appPropInfinispanTrustStoreType
+	// kogitoInfinispanCachePrefixAppProp Kogito application property for setting the prefix of the infinispan cache names
+	kogitoInfinispanCachePrefixAppProp = "kogito.persistence.infinispan.cache-prefix"
+	// kogitoInfinispanCacheSuffixAppProp Kogito application property for setting the suffix of the infinispan cache names
+	kogitoInfinispanCacheSuffixAppProp = "kogito.persistence.infinispan.cache-suffix"
+	infinispanEnvKeyCredSecret         = "INFINISPAN_CREDENTIAL_SECRET"
+	enablePersistenceEnvKey            = "ENABLE_PERSISTENCE"
 	// saslPlain is the PLAIN type.
 	saslPlain string = "PLAIN"
 )
@@ -68,6 +84,9 @@ var (
 
 		envVarInfinispanUser:     "QUARKUS_INFINISPAN_CLIENT_AUTH_USERNAME",
 		envVarInfinispanPassword: "QUARKUS_INFINISPAN_CLIENT_AUTH_PASSWORD",
+
+		appPropInfinispanTrustStore:     "quarkus.infinispan-client.trust-store",
+		appPropInfinispanTrustStoreType: "quarkus.infinispan-client.trust-store-type",
 	}
 	// propertiesInfinispanSpring infinispan properties for spring boot runtime
 	propertiesInfinispanSpring = map[int]string{
@@ -78,32 +97,50 @@ var (
 
 		envVarInfinispanUser:     "INFINISPAN_REMOTE_AUTH_USERNAME",
 		envVarInfinispanPassword: "INFINISPAN_REMOTE_AUTH_PASSWORD",
+
+		appPropInfinispanUseSSL:         "infinispan.remote.use-ssl",
+		appPropInfinispanTrustStore:     "infinispan.remote.trust-store-file-name",
+		appPropInfinispanTrustStoreType: "infinispan.remote.trust-store-type",
 	}
 )
 
-func getInfinispanSecretEnvVars(cli *client.Client, infinispanInstance *infinispan.Infinispan, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) ([]corev1.EnvVar, error) {
-	var envProps []corev1.EnvVar
-
-	customInfinispanSecret, resultErr := loadCustomKogitoInfinispanSecret(cli, instance.Namespace)
-	if resultErr != nil {
-		return nil, resultErr
+// getInfinispanCredentialEnvVars creates the environment variables holding the infinispan credentials stored in the given Secret
+func getInfinispanCredentialEnvVars(secretName, usernameKey, passwordKey string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		framework.CreateEnvVar(infinispanEnvKeyCredSecret, secretName),
+		framework.CreateSecretEnvVar(propertiesInfinispanSpring[envVarInfinispanUser], secretName, usernameKey),
+		framework.CreateSecretEnvVar(propertiesInfinispanQuarkus[envVarInfinispanUser], secretName, usernameKey),
+		framework.CreateSecretEnvVar(propertiesInfinispanSpring[envVarInfinispanPassword], secretName, passwordKey),
+		framework.CreateSecretEnvVar(propertiesInfinispanQuarkus[envVarInfinispanPassword], secretName, passwordKey),
 	}
+}
 
-	if customInfinispanSecret == nil {
-		customInfinispanSecret, resultErr = createCustomKogitoInfinispanSecret(cli, instance.Namespace, infinispanInstance, instance, scheme)
-		if resultErr != nil {
-			return nil, resultErr
-		}
+// getInfinispanTLSAppProps creates the application properties to connect to the infinispan server with TLS.
+// When the PEM truststore is not provided, the default certificate authorities of the service image are trusted.
+func getInfinispanTLSAppProps(trustStore string) map[string]string {
+	appProps := map[string]string{
+		propertiesInfinispanSpring[appPropInfinispanUseSSL]: "true",
 	}
+	if len(trustStore) > 0 {
+		appProps[propertiesInfinispanSpring[appPropInfinispanTrustStore]] = trustStore
+		appProps[propertiesInfinispanSpring[appPropInfinispanTrustStoreType]] = pemTrustStoreType
+		appProps[propertiesInfinispanQuarkus[appPropInfinispanTrustStore]] = trustStore
+		appProps[propertiesInfinispanQuarkus[appPropInfinispanTrustStoreType]] = pemTrustStoreType
+	}
+	return appProps
+}
 
-	envProps = append(envProps, framework.CreateEnvVar(enablePersistenceEnvKey, "true"))
-	secretName := customInfinispanSecret.Name
-	envProps = append(envProps, framework.CreateEnvVar(infinispanEnvKeyCredSecret, secretName))
-	envProps = append(envProps, framework.CreateSecretEnvVar(propertiesInfinispanSpring[envVarInfinispanUser], secretName, infrastructure.InfinispanSecretUsernameKey))
-	envProps = append(envProps, framework.CreateSecretEnvVar(propertiesInfinispanQuarkus[envVarInfinispanUser], secretName, infrastructure.InfinispanSecretUsernameKey))
-	envProps = append(envProps, framework.CreateSecretEnvVar(propertiesInfinispanSpring[envVarInfinispanPassword], secretName, infrastructure.InfinispanSecretPasswordKey))
-	envProps = append(envProps, framework.CreateSecretEnvVar(propertiesInfinispanQuarkus[envVarInfinispanPassword], secretName, infrastructure.InfinispanSecretPasswordKey))
-	return envProps, nil
+// getInfinispanCacheNamingAppProps creates the application properties applying the KogitoInfra naming policy to the infinispan caches
+func getInfinispanCacheNamingAppProps(instance *v1alpha1.KogitoInfra) map[string]string {
+	appProps := map[string]string{}
+	prefix, suffix := infrastructure.GetInfraResourceNamingAffixes(instance)
+	if len(prefix) > 0 {
+		appProps[kogitoInfinispanCachePrefixAppProp] = prefix
+	}
+	if len(suffix) > 0 {
+		appProps[kogitoInfinispanCacheSuffixAppProp] = suffix
+	}
+	return appProps
 }
 
 func getInfinispanAppProps(cli *client.Client, name string, namespace string) (map[string]string, error) {
@@ -131,19 +168,37 @@ func updateInfinispanAppPropsInStatus(cli *client.Client, infinispanInstance *in
 	if err != nil {
 		return err
 	}
+	util.AppendToStringMap(getInfinispanCacheNamingAppProps(instance), appProps)
 	instance.Status.AppProps = appProps
 	log.Debugf("Following app properties are set infra status : %s", appProps)
 	return nil
 }
 
-func updateInfinispanEnvVarsInStatus(cli *client.Client, infinispanInstance *infinispan.Infinispan, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+// reconcileInfinispanCredentials configures the Kogito services to authenticate with the credentials of the infinispan server and,
+// when its endpoint is encrypted, to trust its certificate. The credentials and the certificate are copied into a Secret
+// in the KogitoInfra namespace, so each KogitoInfra keeps the ones of the infinispan server it's bound to.
+func reconcileInfinispanCredentials(cli *client.Client, infinispanInstance *infinispan.Infinispan, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
 	log.Debugf("going to Update Infinispan env properties in kogito infra instance status")
-	envVars, err := getInfinispanSecretEnvVars(cli, infinispanInstance, instance, scheme)
+	tlsEnabled := infrastructure.IsInfinispanEncryptionEnabled(infinispanInstance)
+	secretName, err := syncInfinispanCredentialSecret(cli, infinispanInstance, tlsEnabled, instance, scheme)
 	if err != nil {
 		return err
 	}
-	instance.Status.Env = envVars
-	log.Debugf("Following env properties are set infra status : %s", envVars)
+	instance.Status.Env = append([]corev1.EnvVar{framework.CreateEnvVar(enablePersistenceEnvKey, "true")},
+		getInfinispanCredentialEnvVars(secretName, infrastructure.InfinispanSecretUsernameKey, infrastructure.InfinispanSecretPasswordKey)...)
+	instance.Status.Volumes = nil
+	if tlsEnabled {
+		mountPath := path.Join(externalInfraCertsPath, instance.Name)
+		util.AppendToStringMap(getInfinispanTLSAppProps(path.Join(mountPath, infrastructure.InfinispanTLSCertKey)), instance.Status.AppProps)
+		instance.Status.Volumes = []v1alpha1.KogitoInfraVolume{
+			{
+				Name:       instance.Name + infinispanCredentialVolumeSuffix,
+				SecretName: secretName,
+				MountPath:  mountPath,
+			},
+		}
+	}
+	log.Debugf("Following env properties are set infra status : %s", instance.Status.Env)
 	return nil
 }
 
@@ -184,50 +239,34 @@ func createNewInfinispanInstance(cli *client.Client, name string, namespace stri
 	return infinispanRes, nil
 }
 
-func loadCustomKogitoInfinispanSecret(cli *client.Client, namespace string) (*corev1.Secret, error) {
-	log.Debugf("Fetching %s ", secretName)
-	secret := &corev1.Secret{}
-	if exits, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
-		log.Errorf("Error occurs while fetching %s", secretName)
-		return nil, err
-	} else if !exits {
-		log.Errorf("%s not found", secretName)
-		return nil, nil
-	} else {
-		log.Debugf("%s successfully fetched", secretName)
-		return secret, nil
-	}
-}
-
-func createCustomKogitoInfinispanSecret(cli *client.Client, namespace string, infinispanInstance *infinispan.Infinispan, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (*corev1.Secret, error) {
-	log.Debugf("Creating new secret %s", secretName)
-
+// syncInfinispanCredentialSecret creates or updates the Secret holding the credentials and, if the endpoint is encrypted,
+// the certificate used by the Kogito services to connect to the infinispan server
+func syncInfinispanCredentialSecret(cli *client.Client, infinispanInstance *infinispan.Infinispan, tlsEnabled bool, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (string, error) {
 	credentials, err := infrastructure.GetInfinispanCredential(cli, infinispanInstance)
 	if err != nil {
-		return nil, err
-	}
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      secretName,
-			Namespace: namespace,
-		},
-		Type: corev1.SecretTypeOpaque,
+		return "", err
 	}
+	data := map[string][]byte{}
 	if credentials != nil {
-		secret.StringData = map[string]string{
-			infrastructure.InfinispanSecretUsernameKey: credentials.Username,
-			infrastructure.InfinispanSecretPasswordKey: credentials.Password,
-		}
+		data[infrastructure.InfinispanSecretUsernameKey] = []byte(credentials.Username)
+		data[infrastructure.InfinispanSecretPasswordKey] = []byte(credentials.Password)
 	}
-	if err := controllerutil.SetOwnerReference(instance, secret, scheme); err != nil {
-		return nil, err
+	if tlsEnabled {
+		certSecretName := infinispanInstance.Spec.Security.EndpointEncryption.CertSecretName
+		certSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: certSecretName, Namespace: infinispanInstance.Namespace}}
+		if exists, err := kubernetes.ResourceC(cli).Fetch(certSecret); err != nil {
+			return "", err
+		} else if !exists {
+			return "", newResourceNotReadyError(instance, fmt.Errorf("certificate secret %s for infinispan instance %s not created yet", certSecretName, infinispanInstance.Name))
+		}
+		data[infrastructure.InfinispanTLSCertKey] = certSecret.Data[infrastructure.InfinispanTLSCertKey]
 	}
-	if err := kubernetes.ResourceC(cli).Create(secret); err != nil {
-		log.Errorf("Error occurs while creating %s", secret)
-		return nil, err
+
+	secretName := instance.Name + infinispanCredentialSecretSuffix
+	if err := syncInfraSecret(cli, instance, secretName, data, scheme); err != nil {
+		return "", err
 	}
-	log.Debug("%s successfully created", secret)
-	return secret, nil
+	return secretName, nil
 }
 
 type infinispanInfraResource struct {
@@ -299,13 +338,13 @@ func (i *infinispanInfraResource) Reconcile(client *client.Client, instance *v1a
 	if infinispanCondition == nil || infinispanCondition.Status != string(v1.ConditionTrue) {
 		return false, newResourceNotReadyError(instance, fmt.Errorf("infinispan instance %s not ready. Waiting for Condition.Type == True", infinispanInstance.Name))
 	}
-	if resultErr := updateInfinispanAppPropsInStatus(client, infinispanInstance, instance); resultErr != nil {
-		return false, nil
+	if resultErr = updateInfinispanAppPropsInStatus(client, infinispanInstance, instance); resultErr != nil {
+		return false, resultErr
 	}
-	if resultErr := updateInfinispanEnvVarsInStatus(client, infinispanInstance, instance, scheme); resultErr != nil {
-		return false, nil
+	if resultErr = reconcileInfinispanCredentials(client, infinispanInstance, instance, scheme); resultErr != nil {
+		return false, resultErr
 	}
-	return false, resultErr
+	return false, nil
 }
 
 func getLatestInfinispanCondition(instance *infinispan.Infinispan) *infinispan.InfinispanCondition {
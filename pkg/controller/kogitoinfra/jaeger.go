@@ -0,0 +1,168 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	jaegerv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/jaeger/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// tracingServiceNameFieldPath pod field holding the name of the Kogito service, used as the name of the service in the traces
+const tracingServiceNameFieldPath = "metadata.labels['" + framework.LabelAppKey + "']"
+
+const (
+	// appPropTracingEnabled application property for enabling the tracing
+	appPropTracingEnabled int = iota
+	// appPropTracingSamplerType application property for setting the type of the sampler
+	appPropTracingSamplerType
+	// appPropTracingSamplerParam application property for setting the ratio of sampled requests
+	appPropTracingSamplerParam
+	// envVarTracingOTLPEndpoint environment variable for setting the OTLP endpoint receiving the traces
+	envVarTracingOTLPEndpoint
+	// envVarTracingJaegerEndpoint environment variable for setting the Jaeger collector endpoint receiving the traces
+	envVarTracingJaegerEndpoint
+	// envVarTracingServiceName environment variable for setting the name of the service in the traces
+	envVarTracingServiceName
+)
+
+var (
+	//Tracing variables for the KogitoInfra deployed infrastructure.
+	//For Quarkus: https://quarkus.io/guides/opentelemetry and https://quarkus.io/guides/opentracing
+	//For Spring: https://docs.spring.io/spring-cloud-sleuth/docs/current/reference/html/appendix.html
+
+	// propertiesTracingQuarkus tracing properties for quarkus runtime, using either the OpenTelemetry or the Jaeger extension
+	propertiesTracingQuarkus = map[int]string{
+		appPropTracingEnabled:      "quarkus.opentelemetry.enabled",
+		appPropTracingSamplerType:  "quarkus.jaeger.sampler-type",
+		appPropTracingSamplerParam: "quarkus.jaeger.sampler-param",
+
+		envVarTracingOTLPEndpoint:   "QUARKUS_OPENTELEMETRY_TRACER_EXPORTER_OTLP_ENDPOINT",
+		envVarTracingJaegerEndpoint: "QUARKUS_JAEGER_ENDPOINT",
+		envVarTracingServiceName:    "QUARKUS_JAEGER_SERVICE_NAME",
+	}
+	// propertiesTracingSpring tracing properties for spring boot runtime, using Spring Cloud Sleuth with the OpenTelemetry exporter
+	propertiesTracingSpring = map[int]string{
+		appPropTracingEnabled:      "spring.sleuth.enabled",
+		appPropTracingSamplerParam: "spring.sleuth.sampler.probability",
+
+		envVarTracingOTLPEndpoint: "SPRING_SLEUTH_OTEL_EXPORTER_OTLP_ENDPOINT",
+		envVarTracingServiceName:  "OTEL_SERVICE_NAME",
+	}
+)
+
+// getTracingAppProps creates the application properties enabling the tracing of every request
+func getTracingAppProps() map[string]string {
+	return map[string]string{
+		propertiesTracingQuarkus[appPropTracingEnabled]:      "true",
+		propertiesTracingQuarkus[appPropTracingSamplerType]:  "const",
+		propertiesTracingQuarkus[appPropTracingSamplerParam]: "1",
+		propertiesTracingSpring[appPropTracingEnabled]:       "true",
+		propertiesTracingSpring[appPropTracingSamplerParam]:  "1.0",
+	}
+}
+
+// getTracingEnvVars creates the environment variables sending the traces to the given OTLP endpoint and, if not empty,
+// to the given Jaeger collector endpoint, named after the Kogito service
+func getTracingEnvVars(otlpEndpoint, jaegerEndpoint string) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{
+		framework.CreateEnvVar(propertiesTracingQuarkus[envVarTracingOTLPEndpoint], otlpEndpoint),
+		framework.CreateEnvVar(propertiesTracingSpring[envVarTracingOTLPEndpoint], otlpEndpoint),
+		framework.CreateFieldRefEnvVar(propertiesTracingQuarkus[envVarTracingServiceName], tracingServiceNameFieldPath),
+		framework.CreateFieldRefEnvVar(propertiesTracingSpring[envVarTracingServiceName], tracingServiceNameFieldPath),
+	}
+	if len(jaegerEndpoint) > 0 {
+		envVars = append(envVars, framework.CreateEnvVar(propertiesTracingQuarkus[envVarTracingJaegerEndpoint], jaegerEndpoint))
+	}
+	return envVars
+}
+
+// jaegerInfraResource for Jaeger resources reconciliation
+type jaegerInfraResource struct{}
+
+// getJaegerWatchedObjects provide list of object that needs to be watched to maintain Jaeger kogitoInfra resource
+func getJaegerWatchedObjects() []framework.WatchedObjects {
+	return []framework.WatchedObjects{
+		{
+			GroupVersion: jaegerv1.SchemeGroupVersion,
+			AddToScheme:  jaegerv1.SchemeBuilder.AddToScheme,
+			Objects:      []runtime.Object{&jaegerv1.Jaeger{}},
+		},
+	}
+}
+
+// Reconcile reconcile Kogito infra object
+func (j *jaegerInfraResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (requeue bool, resultErr error) {
+	var jaeger *jaegerv1.Jaeger
+
+	if !infrastructure.IsJaegerAvailable(client) {
+		return false, newResourceAPINotFoundError(&instance.Spec.Resource)
+	}
+
+	resource := getResolvedResource(instance)
+	if jaeger, resultErr = loadDeployedJaeger(client, resource.Name, resource.Namespace); resultErr != nil {
+		return false, resultErr
+	}
+	if jaeger == nil {
+		if len(instance.Spec.Resource.Name) > 0 {
+			return false, newResourceNotFoundError(infrastructure.JaegerKind, resource.Name, resource.Namespace)
+		}
+		log.Debugf("Jaeger reference is not provided")
+		if resultErr = createNewJaeger(client, resource.Name, resource.Namespace, instance, scheme); resultErr != nil {
+			return false, resultErr
+		}
+		return true, nil
+	}
+	if jaeger.Status.Phase != jaegerv1.JaegerPhaseRunning {
+		return false, newResourceNotReadyError(instance, fmt.Errorf("Jaeger instance %s not ready yet. Waiting for phase %s", jaeger.Name, jaegerv1.JaegerPhaseRunning))
+	}
+	instance.Status.AppProps = getTracingAppProps()
+	instance.Status.Env = getTracingEnvVars(infrastructure.GetJaegerCollectorOTLPEndpoint(jaeger), infrastructure.GetJaegerCollectorHTTPEndpoint(jaeger))
+	return false, nil
+}
+
+func loadDeployedJaeger(cli *client.Client, name, namespace string) (*jaegerv1.Jaeger, error) {
+	log.Debug("fetching deployed Jaeger instance")
+	jaeger := &jaegerv1.Jaeger{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: name, Namespace: namespace}, jaeger); err != nil {
+		log.Error("Error occurs while fetching Jaeger instance")
+		return nil, err
+	} else if !exists {
+		log.Debug("Jaeger instance does not exist")
+		return nil, nil
+	} else {
+		log.Debug("Jaeger instance found")
+		return jaeger, nil
+	}
+}
+
+func createNewJaeger(cli *client.Client, name, namespace string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+	log.Debug("Going to create Jaeger instance")
+	jaeger := infrastructure.GetJaegerDefaultResource(name, namespace)
+	if err := kubernetes.ResourceC(cli).CreateForOwner(jaeger, instance, scheme); err != nil {
+		log.Error("Error occurs while creating Jaeger instance")
+		return err
+	}
+	log.Debug("Jaeger instance created successfully")
+	return nil
+}
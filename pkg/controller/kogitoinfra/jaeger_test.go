@@ -0,0 +1,90 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	jaegerv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/jaeger/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Reconcile_JaegerResource_CreateDefault(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-jaeger", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.JaegerAPIVersion,
+				Kind:       infrastructure.JaegerKind,
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	jaeger := &jaegerv1.Jaeger{ObjectMeta: v1.ObjectMeta{Name: infrastructure.JaegerInstanceName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, jaeger)
+	assert.Equal(t, jaegerv1.JaegerAllInOneStrategy, jaeger.Spec.Strategy)
+
+	// jaeger not running yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+
+	jaeger.Status.Phase = jaegerv1.JaegerPhaseRunning
+	assert.NoError(t, kubernetes.ResourceC(client).Update(jaeger))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	otlpEndpoint := "http://kogito-jaeger-collector." + t.Name() + ".svc:4317"
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(propertiesTracingQuarkus[envVarTracingOTLPEndpoint], otlpEndpoint))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(propertiesTracingSpring[envVarTracingOTLPEndpoint], otlpEndpoint))
+	assert.Contains(t, kogitoInfra.Status.Env,
+		framework.CreateEnvVar(propertiesTracingQuarkus[envVarTracingJaegerEndpoint], "http://kogito-jaeger-collector."+t.Name()+".svc:14268/api/traces"))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateFieldRefEnvVar(propertiesTracingQuarkus[envVarTracingServiceName], "metadata.labels['app']"))
+	assert.Equal(t, "true", kogitoInfra.Status.AppProps[propertiesTracingQuarkus[appPropTracingEnabled]])
+	assert.Equal(t, "true", kogitoInfra.Status.AppProps[propertiesTracingSpring[appPropTracingEnabled]])
+}
+
+func Test_Reconcile_JaegerResource_NotFound(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-jaeger", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.JaegerAPIVersion,
+				Kind:       infrastructure.JaegerKind,
+				Name:       "my-jaeger",
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotFound, kogitoInfra.Status.Condition.Reason)
+	test.AssertFetchMustNotExist(t, client, &jaegerv1.Jaeger{ObjectMeta: v1.ObjectMeta{Name: infrastructure.JaegerInstanceName, Namespace: t.Name()}})
+}
@@ -23,9 +23,13 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure/services"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"path"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -37,9 +41,79 @@ const (
 	// springKafkaBootstrapAppProp spring boot application property for setting kafka server
 	springKafkaBootstrapAppProp = "spring.kafka.bootstrap-servers"
 
+	// quarkusKafkaSecurityProtocolAppProp quarkus application property for setting the kafka security protocol
+	quarkusKafkaSecurityProtocolAppProp = "kafka.security.protocol"
+	// quarkusKafkaSaslMechanismAppProp quarkus application property for setting the kafka SASL mechanism
+	quarkusKafkaSaslMechanismAppProp = "kafka.sasl.mechanism"
+	// quarkusKafkaSaslJaasConfigAppProp quarkus application property for setting the kafka SASL JAAS configuration
+	quarkusKafkaSaslJaasConfigAppProp = "kafka.sasl.jaas.config"
+	// quarkusKafkaTrustStoreLocationAppProp quarkus application property for setting the kafka truststore
+	quarkusKafkaTrustStoreLocationAppProp = "kafka.ssl.truststore.location"
+	// quarkusKafkaTrustStoreTypeAppProp quarkus application property for setting the kafka truststore type
+	quarkusKafkaTrustStoreTypeAppProp = "kafka.ssl.truststore.type"
+
+	// springKafkaSecurityProtocolAppProp spring boot application property for setting the kafka security protocol
+	springKafkaSecurityProtocolAppProp = "spring.kafka.security.protocol"
+	// springKafkaSaslMechanismAppProp spring boot application property for setting the kafka SASL mechanism
+	springKafkaSaslMechanismAppProp = "spring.kafka.properties.sasl.mechanism"
+	// springKafkaSaslJaasConfigAppProp spring boot application property for setting the kafka SASL JAAS configuration
+	springKafkaSaslJaasConfigAppProp = "spring.kafka.properties.sasl.jaas.config"
+	// springKafkaTrustStoreLocationAppProp spring boot application property for setting the kafka truststore
+	springKafkaTrustStoreLocationAppProp = "spring.kafka.ssl.trust-store-location"
+	// springKafkaTrustStoreTypeAppProp spring boot application property for setting the kafka truststore type
+	springKafkaTrustStoreTypeAppProp = "spring.kafka.ssl.trust-store-type"
+
+	// quarkusKafkaKeyStoreLocationAppProp quarkus application property for setting the kafka client keystore
+	quarkusKafkaKeyStoreLocationAppProp = "kafka.ssl.keystore.location"
+	// quarkusKafkaKeyStoreTypeAppProp quarkus application property for setting the kafka client keystore type
+	quarkusKafkaKeyStoreTypeAppProp = "kafka.ssl.keystore.type"
+	// quarkusKafkaKeyStorePasswordAppProp quarkus application property for setting the kafka client keystore password
+	quarkusKafkaKeyStorePasswordAppProp = "kafka.ssl.keystore.password"
+
+	// springKafkaKeyStoreLocationAppProp spring boot application property for setting the kafka client keystore
+	springKafkaKeyStoreLocationAppProp = "spring.kafka.ssl.key-store-location"
+	// springKafkaKeyStoreTypeAppProp spring boot application property for setting the kafka client keystore type
+	springKafkaKeyStoreTypeAppProp = "spring.kafka.ssl.key-store-type"
+	// springKafkaKeyStorePasswordAppProp spring boot application property for setting the kafka client keystore password
+	springKafkaKeyStorePasswordAppProp = "spring.kafka.ssl.key-store-password"
+
+	// kafkaSaslUsernameEnvKey environment variable holding the kafka SASL username, referenced by the JAAS configuration
+	kafkaSaslUsernameEnvKey = "KAFKA_SASL_USERNAME"
+	// kafkaSaslPasswordEnvKey environment variable holding the kafka SASL password, referenced by the JAAS configuration
+	kafkaSaslPasswordEnvKey = "KAFKA_SASL_PASSWORD"
+	// kafkaKeyStorePasswordEnvKey environment variable holding the password of the kafka client keystore
+	kafkaKeyStorePasswordEnvKey = "KAFKA_SSL_KEYSTORE_PASSWORD"
+
+	kafkaSecurityProtocolSSL           = "SSL"
+	kafkaSecurityProtocolSaslPlaintext = "SASL_PLAINTEXT"
+	kafkaSecurityProtocolSaslSSL       = "SASL_SSL"
+
+	kafkaSaslMechanismPlain    = "PLAIN"
+	kafkaSaslMechanismScram512 = "SCRAM-SHA-512"
+	kafkaPlainLoginModule      = "org.apache.kafka.common.security.plain.PlainLoginModule"
+	kafkaScramLoginModule      = "org.apache.kafka.common.security.scram.ScramLoginModule"
+	pemTrustStoreType          = "PEM"
+	pkcs12KeyStoreType         = "PKCS12"
+
+	// kafkaCredentialSecretSuffix suffix of the Secret holding the certificates and credentials of the KafkaUser
+	kafkaCredentialSecretSuffix = "-kafka-credential"
+	// kafkaCredentialVolumeSuffix suffix of the volume mounting the certificates and credentials of the KafkaUser
+	kafkaCredentialVolumeSuffix = "-kafka-credential"
+	// kafkaUserUsernameKey key of the username in the Secret holding the credentials of the KafkaUser
+	kafkaUserUsernameKey = "username"
+
 	kafkaDefaultReplicas = 1
 )
 
+// invalidEnvKeyChars matches the characters not allowed in the environment variables referenced by the application properties
+var invalidEnvKeyChars = regexp.MustCompile("[^A-Z0-9_]")
+
+// getKafkaEnvKey qualifies the given environment variable with the namespace and name of the KogitoInfra instance,
+// so that a service bound to several Kafka KogitoInfra instances gets the credentials of each one
+func getKafkaEnvKey(envKey string, instance *v1alpha1.KogitoInfra) string {
+	return envKey + "_" + invalidEnvKeyChars.ReplaceAllString(strings.ToUpper(instance.Namespace+"_"+instance.Name), "_")
+}
+
 func getKafkaEnvVars(kafkaInstance *kafkabetav1.Kafka) ([]corev1.EnvVar, error) {
 	kafkaURI, err := infrastructure.ResolveKafkaServerURI(kafkaInstance)
 	if err != nil {
@@ -59,25 +133,137 @@ func getKafkaAppProps(kafkaInstance *kafkabetav1.Kafka) (map[string]string, erro
 	if err != nil {
 		return nil, err
 	}
-	appProps := map[string]string{}
 	if len(kafkaURI) > 0 {
-		appProps[springKafkaBootstrapAppProp] = kafkaURI
-		appProps[services.QuarkusKafkaBootstrapAppProp] = kafkaURI
+		return getKafkaBootstrapAppProps(kafkaURI), nil
+	}
+	return map[string]string{}, nil
+}
+
+// getKafkaBootstrapAppProps creates the application properties to connect to the given kafka bootstrap servers
+func getKafkaBootstrapAppProps(bootstrapServers string) map[string]string {
+	return map[string]string{
+		springKafkaBootstrapAppProp:           bootstrapServers,
+		services.QuarkusKafkaBootstrapAppProp: bootstrapServers,
+	}
+}
+
+// getKafkaSecurityAppProps creates the application properties to connect to a secured kafka cluster.
+// SASL is enabled when saslMechanism is provided. When TLS is enabled without a PEM truststore location, the default
+// certificate authorities of the service image are trusted.
+func getKafkaSecurityAppProps(instance *v1alpha1.KogitoInfra, saslMechanism string, tlsEnabled bool, trustStoreLocation string) map[string]string {
+	appProps := map[string]string{}
+	securityProtocol := ""
+	if len(saslMechanism) > 0 {
+		loginModule := kafkaScramLoginModule
+		if saslMechanism == kafkaSaslMechanismPlain {
+			loginModule = kafkaPlainLoginModule
+		}
+		jaasConfig := fmt.Sprintf("%s required username=\"${%s}\" password=\"${%s}\";", loginModule,
+			getKafkaEnvKey(kafkaSaslUsernameEnvKey, instance), getKafkaEnvKey(kafkaSaslPasswordEnvKey, instance))
+		appProps[quarkusKafkaSaslMechanismAppProp] = saslMechanism
+		appProps[quarkusKafkaSaslJaasConfigAppProp] = jaasConfig
+		appProps[springKafkaSaslMechanismAppProp] = saslMechanism
+		appProps[springKafkaSaslJaasConfigAppProp] = jaasConfig
+		securityProtocol = kafkaSecurityProtocolSaslPlaintext
+	}
+	if tlsEnabled {
+		if len(trustStoreLocation) > 0 {
+			appProps[quarkusKafkaTrustStoreLocationAppProp] = trustStoreLocation
+			appProps[quarkusKafkaTrustStoreTypeAppProp] = pemTrustStoreType
+			appProps[springKafkaTrustStoreLocationAppProp] = "file:" + trustStoreLocation
+			appProps[springKafkaTrustStoreTypeAppProp] = pemTrustStoreType
+		}
+		securityProtocol = kafkaSecurityProtocolSSL
+		if len(saslMechanism) > 0 {
+			securityProtocol = kafkaSecurityProtocolSaslSSL
+		}
+	}
+	if len(securityProtocol) > 0 {
+		appProps[quarkusKafkaSecurityProtocolAppProp] = securityProtocol
+		appProps[springKafkaSecurityProtocolAppProp] = securityProtocol
+	}
+	return appProps
+}
+
+// getKafkaSaslEnvVars creates the environment variables holding the kafka SASL credentials stored in the given Secret
+func getKafkaSaslEnvVars(instance *v1alpha1.KogitoInfra, secretName, usernameKey, passwordKey string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		framework.CreateSecretEnvVar(getKafkaEnvKey(kafkaSaslUsernameEnvKey, instance), secretName, usernameKey),
+		framework.CreateSecretEnvVar(getKafkaEnvKey(kafkaSaslPasswordEnvKey, instance), secretName, passwordKey),
+	}
+}
+
+// getKafkaKeyStoreAppProps creates the application properties to authenticate against kafka with the PKCS #12 keystore
+// in the given location. The keystore password is read from the kafkaKeyStorePasswordEnvKey environment variable of the instance.
+func getKafkaKeyStoreAppProps(instance *v1alpha1.KogitoInfra, keyStoreLocation string) map[string]string {
+	keyStorePassword := fmt.Sprintf("${%s}", getKafkaEnvKey(kafkaKeyStorePasswordEnvKey, instance))
+	return map[string]string{
+		quarkusKafkaKeyStoreLocationAppProp: keyStoreLocation,
+		quarkusKafkaKeyStoreTypeAppProp:     pkcs12KeyStoreType,
+		quarkusKafkaKeyStorePasswordAppProp: keyStorePassword,
+		springKafkaKeyStoreLocationAppProp:  "file:" + keyStoreLocation,
+		springKafkaKeyStoreTypeAppProp:      pkcs12KeyStoreType,
+		springKafkaKeyStorePasswordAppProp:  keyStorePassword,
 	}
-	return appProps, nil
 }
 
 // kafkaInfraResource implementation of KogitoInfraResource
 type kafkaInfraResource struct {
 }
 
+// Finalize deletes the KafkaUser created in another namespace and stops tracking the services depending on the KogitoInfra
+// in the KafkaTopics they use, deleting the ones not used anymore
+func (k *kafkaInfraResource) Finalize(client *client.Client, instance *v1alpha1.KogitoInfra) error {
+	resource := getResolvedResource(instance)
+	if resource == nil || resource.Namespace == instance.Namespace || !infrastructure.IsStrimziAvailable(client) {
+		return nil
+	}
+	if err := deleteCreatedResources(client, resource.Namespace, instance, &kafkabetav1.KafkaUserList{}); err != nil {
+		return err
+	}
+	consumers, err := getConsumers(client, instance)
+	if err != nil {
+		return err
+	}
+	var serviceKeys []string
+	for _, consumer := range consumers {
+		serviceKeys = append(serviceKeys, infrastructure.GetKafkaTopicServiceKey(getConsumerNamespace(consumer, instance), consumer.Name))
+	}
+	kafkaTopics := &kafkabetav1.KafkaTopicList{}
+	if err := kubernetes.ResourceC(client).ListWithNamespaceAndLabel(resource.Namespace, kafkaTopics, infrastructure.GetKafkaTopicLabels(resource.Name)); err != nil {
+		return err
+	}
+	for i := range kafkaTopics.Items {
+		kafkaTopic := &kafkaTopics.Items[i]
+		if !infrastructure.IsManagedKafkaTopic(kafkaTopic) {
+			continue
+		}
+		services := infrastructure.GetKafkaTopicServices(kafkaTopic)
+		for _, serviceKey := range serviceKeys {
+			services = util.RemoveFromSlice(services, serviceKey)
+		}
+		if len(services) == 0 {
+			log.Debugf("Kafka topic %s not used anymore, deleting it", kafkaTopic.Name)
+			if err := kubernetes.ResourceC(client).Delete(kafkaTopic); err != nil {
+				return err
+			}
+		} else if len(services) < len(infrastructure.GetKafkaTopicServices(kafkaTopic)) {
+			infrastructure.SetKafkaTopicServices(kafkaTopic, services)
+			if err := kubernetes.ResourceC(client).Update(kafkaTopic); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // getKafkaWatchedObjects provide list of object that needs to be watched to maintain Kafka kogitoInfra resource
 func getKafkaWatchedObjects() []framework.WatchedObjects {
 	return []framework.WatchedObjects{
 		{
 			GroupVersion: kafkabetav1.SchemeGroupVersion,
 			AddToScheme:  kafkabetav1.SchemeBuilder.AddToScheme,
-			Objects:      []runtime.Object{&kafkabetav1.Kafka{}},
+			Objects:      []runtime.Object{&kafkabetav1.Kafka{}, &kafkabetav1.KafkaUser{}},
 		},
 	}
 }
@@ -133,9 +319,135 @@ func (k *kafkaInfraResource) Reconcile(client *client.Client, instance *v1alpha1
 	if resultErr = updateKafkaEnvVarsInStatus(kafkaInstance, instance); resultErr != nil {
 		return true, resultErr
 	}
+	if resultErr = reconcileKafkaSecurity(client, kafkaInstance, instance, scheme); resultErr != nil {
+		return false, resultErr
+	}
 	return false, nil
 }
 
+// reconcileKafkaSecurity configures the Kogito services to connect to the TLS listener of the Kafka instance and
+// to authenticate with a KafkaUser when the listener requires it. The cluster CA certificate and the user credentials
+// are copied into a Secret in the KogitoInfra namespace that is mounted in the services.
+func reconcileKafkaSecurity(cli *client.Client, kafkaInstance *kafkabetav1.Kafka, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+	instance.Status.Volumes = nil
+	tlsEnabled := infrastructure.IsKafkaTLSEnabled(kafkaInstance)
+	authentication := infrastructure.GetKafkaAuthentication(kafkaInstance)
+	if !tlsEnabled && len(authentication) == 0 {
+		return nil
+	}
+
+	data := map[string][]byte{}
+	if tlsEnabled {
+		caSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: infrastructure.GetKafkaClusterCACertSecretName(kafkaInstance), Namespace: kafkaInstance.Namespace}}
+		if exists, err := kubernetes.ResourceC(cli).Fetch(caSecret); err != nil {
+			return err
+		} else if !exists {
+			return newResourceNotReadyError(instance, fmt.Errorf("cluster CA certificate secret %s for kafka instance %s not created yet", caSecret.Name, kafkaInstance.Name))
+		}
+		data[infrastructure.KafkaClusterCACertKey] = caSecret.Data[infrastructure.KafkaClusterCACertKey]
+	}
+	if len(authentication) > 0 {
+		kafkaUser, err := reconcileKafkaUser(cli, kafkaInstance, authentication, instance, scheme)
+		if err != nil {
+			return err
+		}
+		userSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: kafkaUser.Status.Secret, Namespace: kafkaUser.Namespace}}
+		if exists, err := kubernetes.ResourceC(cli).Fetch(userSecret); err != nil {
+			return err
+		} else if !exists {
+			return newResourceNotReadyError(instance, fmt.Errorf("secret %s of kafka user %s not created yet", userSecret.Name, kafkaUser.Name))
+		}
+		if authentication == kafkabetav1.KafkaScramSha512Authentication {
+			data[kafkaUserUsernameKey] = []byte(kafkaUser.Status.Username)
+			data[infrastructure.KafkaUserPasswordKey] = userSecret.Data[infrastructure.KafkaUserPasswordKey]
+		} else {
+			data[infrastructure.KafkaUserKeystoreKey] = userSecret.Data[infrastructure.KafkaUserKeystoreKey]
+			data[infrastructure.KafkaUserKeystorePasswordKey] = userSecret.Data[infrastructure.KafkaUserKeystorePasswordKey]
+		}
+	}
+	secretName, err := syncKafkaCredentialSecret(cli, data, instance, scheme)
+	if err != nil {
+		return err
+	}
+
+	mountPath := path.Join(externalInfraCertsPath, instance.Name)
+	trustStore := ""
+	if tlsEnabled {
+		trustStore = path.Join(mountPath, infrastructure.KafkaClusterCACertKey)
+	}
+	saslMechanism := ""
+	switch authentication {
+	case kafkabetav1.KafkaScramSha512Authentication:
+		saslMechanism = kafkaSaslMechanismScram512
+		instance.Status.Env = append(instance.Status.Env, getKafkaSaslEnvVars(instance, secretName, kafkaUserUsernameKey, infrastructure.KafkaUserPasswordKey)...)
+	case kafkabetav1.KafkaTLSAuthentication:
+		util.AppendToStringMap(getKafkaKeyStoreAppProps(instance, path.Join(mountPath, infrastructure.KafkaUserKeystoreKey)), instance.Status.AppProps)
+		instance.Status.Env = append(instance.Status.Env, framework.CreateSecretEnvVar(getKafkaEnvKey(kafkaKeyStorePasswordEnvKey, instance), secretName, infrastructure.KafkaUserKeystorePasswordKey))
+	}
+	util.AppendToStringMap(getKafkaSecurityAppProps(instance, saslMechanism, tlsEnabled, trustStore), instance.Status.AppProps)
+	instance.Status.Volumes = []v1alpha1.KogitoInfraVolume{
+		{
+			Name:       instance.Name + kafkaCredentialVolumeSuffix,
+			SecretName: secretName,
+			MountPath:  mountPath,
+		},
+	}
+	return nil
+}
+
+// reconcileKafkaUser creates the KafkaUser used by the Kogito services bound to the KogitoInfra instance.
+// The access to the topics is granted by the services when they declare them.
+func reconcileKafkaUser(cli *client.Client, kafkaInstance *kafkabetav1.Kafka, authentication kafkabetav1.KafkaAuthenticationType, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (*kafkabetav1.KafkaUser, error) {
+	kafkaUser := &kafkabetav1.KafkaUser{}
+	exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: instance.Name, Namespace: kafkaInstance.Namespace}, kafkaUser)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		log.Debugf("Creating kafka user %s for kafka instance %s", instance.Name, kafkaInstance.Name)
+		kafkaUser = infrastructure.GetKafkaUserDefaultResource(instance.Name, kafkaInstance, authentication)
+		if kafkaInstance.Namespace == instance.Namespace {
+			if err := framework.SetOwner(instance, scheme, kafkaUser); err != nil {
+				return nil, err
+			}
+		} else {
+			setKogitoInfraCreator(kafkaUser, instance)
+		}
+		if err := kubernetes.ResourceC(cli).Create(kafkaUser); err != nil {
+			return nil, err
+		}
+	} else if kafkaUser.Spec.Authentication.Type != authentication {
+		log.Debugf("Kafka instance %s authentication changed, updating kafka user %s", kafkaInstance.Name, kafkaUser.Name)
+		kafkaUser.Spec.Authentication.Type = authentication
+		if err := kubernetes.ResourceC(cli).Update(kafkaUser); err != nil {
+			return nil, err
+		}
+	}
+	if len(kafkaUser.Status.Secret) == 0 || !isKafkaUserReady(kafkaUser) {
+		return nil, newResourceNotReadyError(instance, fmt.Errorf("kafka user %s not ready yet. Waiting for Condition status Ready", kafkaUser.Name))
+	}
+	return kafkaUser, nil
+}
+
+func isKafkaUserReady(kafkaUser *kafkabetav1.KafkaUser) bool {
+	for _, condition := range kafkaUser.Status.Conditions {
+		if condition.Type == kafkabetav1.KafkaConditionTypeReady && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// syncKafkaCredentialSecret creates or updates the Secret holding the certificates and credentials used by the
+// Kogito services to connect to kafka
+func syncKafkaCredentialSecret(cli *client.Client, data map[string][]byte, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (string, error) {
+	secretName := instance.Name + kafkaCredentialSecretSuffix
+	if err := syncInfraSecret(cli, instance, secretName, data, scheme); err != nil {
+		return "", err
+	}
+	return secretName, nil
+}
+
 func updateKafkaAppPropsInStatus(kafkaInstance *kafkabetav1.Kafka, instance *v1alpha1.KogitoInfra) error {
 	appProps, err := getKafkaAppProps(kafkaInstance)
 	if err != nil {
@@ -15,8 +15,16 @@
 package kogitoinfra
 
 import (
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/kafka/v1beta1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"reflect"
 	"testing"
 	time "time"
@@ -51,3 +59,117 @@ func Test_mustParseKafkaTransition(t *testing.T) {
 		})
 	}
 }
+
+func newSecuredKafkaTestResources(t *testing.T, authentication v1beta1.KafkaAuthenticationType) (*v1alpha1.KogitoInfra, *v1beta1.Kafka, *corev1.Secret) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka-infra", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KafkaAPIVersion,
+				Kind:       infrastructure.KafkaKind,
+				Name:       "my-kafka",
+			},
+		},
+	}
+	kafka := &v1beta1.Kafka{
+		ObjectMeta: v1.ObjectMeta{Name: "my-kafka", Namespace: t.Name()},
+		Spec: v1beta1.KafkaSpec{
+			Kafka: v1beta1.KafkaClusterSpec{
+				Listeners: v1beta1.KafkaListeners{
+					TLS: &v1beta1.KafkaListenerTLS{Authentication: &v1beta1.KafkaListenerAuthentication{Type: authentication}},
+				},
+				Authorization: &v1beta1.KafkaAuthorization{Type: v1beta1.KafkaSimpleAuthorization},
+			},
+		},
+		Status: v1beta1.KafkaStatus{
+			Conditions: []v1beta1.KafkaCondition{{Type: v1beta1.KafkaConditionTypeReady, Status: corev1.ConditionTrue}},
+			Listeners: []v1beta1.ListenerStatus{
+				{Type: "plain", Addresses: []v1beta1.ListenerAddress{{Host: "my-kafka-kafka-bootstrap", Port: 9092}}},
+				{Type: "tls", Addresses: []v1beta1.ListenerAddress{{Host: "my-kafka-kafka-bootstrap", Port: 9093}}},
+			},
+		},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "my-kafka-cluster-ca-cert", Namespace: t.Name()},
+		Data:       map[string][]byte{"ca.crt": []byte("certificate")},
+	}
+	return kogitoInfra, kafka, caSecret
+}
+
+// setKafkaUserReady simulates Strimzi creating the credentials of the given KafkaUser
+func setKafkaUserReady(t *testing.T, r *ReconcileKogitoInfra, kafkaUser *v1beta1.KafkaUser, data map[string][]byte) {
+	test.AssertFetchMustExist(t, r.client, kafkaUser)
+	kafkaUser.Status = v1beta1.KafkaUserStatus{
+		Username:   kafkaUser.Name,
+		Secret:     kafkaUser.Name,
+		Conditions: []v1beta1.KafkaCondition{{Type: v1beta1.KafkaConditionTypeReady, Status: corev1.ConditionTrue}},
+	}
+	assert.NoError(t, kubernetes.ResourceC(r.client).Update(kafkaUser))
+	userSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: kafkaUser.Name, Namespace: kafkaUser.Namespace}, Data: data}
+	assert.NoError(t, kubernetes.ResourceC(r.client).Create(userSecret))
+}
+
+func Test_Reconcile_KafkaResource_TLSWithScram(t *testing.T) {
+	kogitoInfra, kafka, caSecret := newSecuredKafkaTestResources(t, v1beta1.KafkaScramSha512Authentication)
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, kafka, caSecret).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	// KafkaUser is created, but not ready yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+	kafkaUser := &v1beta1.KafkaUser{ObjectMeta: v1.ObjectMeta{Name: kogitoInfra.Name, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, kafkaUser)
+	assert.Equal(t, v1beta1.KafkaScramSha512Authentication, kafkaUser.Spec.Authentication.Type)
+	assert.Equal(t, "my-kafka", kafkaUser.Labels["strimzi.io/cluster"])
+	assert.NotNil(t, kafkaUser.Spec.Authorization)
+
+	setKafkaUserReady(t, r, kafkaUser, map[string][]byte{infrastructure.KafkaUserPasswordKey: []byte("secret")})
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+
+	credentialSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka-infra-kafka-credential", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, credentialSecret)
+	assert.Equal(t, "certificate", string(credentialSecret.Data[infrastructure.KafkaClusterCACertKey]))
+	assert.Equal(t, kafkaUser.Name, string(credentialSecret.Data[kafkaUserUsernameKey]))
+	assert.Equal(t, "secret", string(credentialSecret.Data[infrastructure.KafkaUserPasswordKey]))
+
+	assert.Equal(t, "my-kafka-kafka-bootstrap:9093", kogitoInfra.Status.AppProps[springKafkaBootstrapAppProp])
+	assert.Equal(t, kafkaSecurityProtocolSaslSSL, kogitoInfra.Status.AppProps[quarkusKafkaSecurityProtocolAppProp])
+	assert.Equal(t, kafkaSaslMechanismScram512, kogitoInfra.Status.AppProps[springKafkaSaslMechanismAppProp])
+	assert.Equal(t, "/home/kogito/certs/kogito-kafka-infra/ca.crt", kogitoInfra.Status.AppProps[quarkusKafkaTrustStoreLocationAppProp])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(getKafkaEnvKey(kafkaSaslPasswordEnvKey, kogitoInfra), credentialSecret.Name, infrastructure.KafkaUserPasswordKey))
+	assert.Len(t, kogitoInfra.Status.Volumes, 1)
+	assert.Equal(t, credentialSecret.Name, kogitoInfra.Status.Volumes[0].SecretName)
+}
+
+func Test_Reconcile_KafkaResource_MutualTLS(t *testing.T) {
+	kogitoInfra, kafka, caSecret := newSecuredKafkaTestResources(t, v1beta1.KafkaTLSAuthentication)
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, kafka, caSecret).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	kafkaUser := &v1beta1.KafkaUser{ObjectMeta: v1.ObjectMeta{Name: kogitoInfra.Name, Namespace: t.Name()}}
+	setKafkaUserReady(t, r, kafkaUser, map[string][]byte{
+		infrastructure.KafkaUserKeystoreKey:         []byte("keystore"),
+		infrastructure.KafkaUserKeystorePasswordKey: []byte("changeit"),
+	})
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+
+	credentialSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka-infra-kafka-credential", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, credentialSecret)
+	assert.Equal(t, "keystore", string(credentialSecret.Data[infrastructure.KafkaUserKeystoreKey]))
+
+	assert.Equal(t, kafkaSecurityProtocolSSL, kogitoInfra.Status.AppProps[springKafkaSecurityProtocolAppProp])
+	assert.Empty(t, kogitoInfra.Status.AppProps[quarkusKafkaSaslMechanismAppProp])
+	assert.Equal(t, "/home/kogito/certs/kogito-kafka-infra/user.p12", kogitoInfra.Status.AppProps[quarkusKafkaKeyStoreLocationAppProp])
+	assert.Equal(t, pkcs12KeyStoreType, kogitoInfra.Status.AppProps[springKafkaKeyStoreTypeAppProp])
+	assert.Equal(t, "${KAFKA_SSL_KEYSTORE_PASSWORD_TEST_RECONCILE_KAFKARESOURCE_MUTUALTLS_KOGITO_KAFKA_INFRA}", kogitoInfra.Status.AppProps[quarkusKafkaKeyStorePasswordAppProp])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(getKafkaEnvKey(kafkaKeyStorePasswordEnvKey, kogitoInfra), credentialSecret.Name, infrastructure.KafkaUserKeystorePasswordKey))
+}
@@ -15,12 +15,17 @@
 package kogitoinfra
 
 import (
+	"fmt"
+
 	keycloakv1alpha1 "github.com/keycloak/keycloak-operator/pkg/apis/keycloak/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,21 +33,75 @@ import (
 )
 
 const (
+	// keycloakCredentialSecretSuffix suffix of the Secret holding the client secrets of the Kogito services bound to the Keycloak infra
+	keycloakCredentialSecretSuffix = "-keycloak-credential"
+	keycloakClientSecretLength     = 32
+	// keycloakClientIDSeparator separates the namespace and the name of the Kogito service in the ID of its Keycloak client,
+	// namespaces can't contain dots
+	keycloakClientIDSeparator = "."
+
 	// keycloakMetricsExtension default extension enabled in Keycloak default installations
 	keycloakMetricsExtension = "https://github.com/aerogear/keycloak-metrics-spi/releases/download/1.0.4/keycloak-metrics-spi-1.0.4.jar"
+
+	// quarkusOidcAuthServerURLAppProp quarkus application property for setting the OpenID Connect server
+	quarkusOidcAuthServerURLAppProp = "quarkus.oidc.auth-server-url"
+	// quarkusOidcClientIDAppProp quarkus application property for setting the OpenID Connect client ID
+	quarkusOidcClientIDAppProp = "quarkus.oidc.client-id"
+	// quarkusOidcClientSecretEnvKey quarkus environment variable for setting the OpenID Connect client secret
+	quarkusOidcClientSecretEnvKey = "QUARKUS_OIDC_CREDENTIALS_SECRET"
+
+	// springResourceServerIssuerURIAppProp spring boot application property for setting the JWT issuer of the resource server
+	springResourceServerIssuerURIAppProp = "spring.security.oauth2.resourceserver.jwt.issuer-uri"
+	// springClientIssuerURIAppProp spring boot application property for setting the OpenID Connect server of the client
+	springClientIssuerURIAppProp = "spring.security.oauth2.client.provider.keycloak.issuer-uri"
+	// springClientIDAppProp spring boot application property for setting the OpenID Connect client ID
+	springClientIDAppProp = "spring.security.oauth2.client.registration.keycloak.client-id"
+	// springClientSecretEnvKey spring boot environment variable for setting the OpenID Connect client secret
+	springClientSecretEnvKey = "SPRING_SECURITY_OAUTH2_CLIENT_REGISTRATION_KEYCLOAK_CLIENTSECRET"
 )
 
+// getKeycloakAppProps creates the application properties to secure the services with the given OpenID Connect server
+func getKeycloakAppProps(authServerURL, clientID string) map[string]string {
+	return map[string]string{
+		quarkusOidcAuthServerURLAppProp:      authServerURL,
+		quarkusOidcClientIDAppProp:           clientID,
+		springResourceServerIssuerURIAppProp: authServerURL,
+		springClientIssuerURIAppProp:         authServerURL,
+		springClientIDAppProp:                clientID,
+	}
+}
+
+// getKeycloakClientSecretEnvVars creates the environment variables holding the OpenID Connect client secret stored in the given Secret
+func getKeycloakClientSecretEnvVars(secretName, secretKey string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		framework.CreateSecretEnvVar(quarkusOidcClientSecretEnvKey, secretName, secretKey),
+		framework.CreateSecretEnvVar(springClientSecretEnvKey, secretName, secretKey),
+	}
+}
+
 // keycloakInfraResource implementation of KogitoInfraResource
 type keycloakInfraResource struct {
 }
 
+// Finalize deletes the Keycloak realm and clients created in another namespace
+func (k *keycloakInfraResource) Finalize(client *client.Client, instance *v1alpha1.KogitoInfra) error {
+	resource := getResolvedResource(instance)
+	if resource == nil || resource.Namespace == instance.Namespace || !infrastructure.IsKeycloakAvailable(client) {
+		return nil
+	}
+	if err := deleteCreatedResources(client, resource.Namespace, instance, &keycloakv1alpha1.KeycloakClientList{}); err != nil {
+		return err
+	}
+	return deleteCreatedResources(client, resource.Namespace, instance, &keycloakv1alpha1.KeycloakRealmList{})
+}
+
 // getKeycloakWatchedObjects provide list of object that needs to be watched to maintain Keycloak kogitoInfra resource
 func getKeycloakWatchedObjects() []framework.WatchedObjects {
 	return []framework.WatchedObjects{
 		{
 			GroupVersion: keycloakv1alpha1.SchemeGroupVersion,
 			AddToScheme:  keycloakv1alpha1.SchemeBuilder.AddToScheme,
-			Objects:      []runtime.Object{&keycloakv1alpha1.Keycloak{}},
+			Objects:      []runtime.Object{&keycloakv1alpha1.Keycloak{}, &keycloakv1alpha1.KeycloakRealm{}, &keycloakv1alpha1.KeycloakClient{}},
 		},
 	}
 }
@@ -65,12 +124,12 @@ func (k *keycloakInfraResource) Reconcile(client *client.Client, instance *v1alp
 		if keycloakInstance, resultErr = loadDeployedKeycloakInstance(client, instance.Spec.Resource.Name, namespace); resultErr != nil {
 			return false, resultErr
 		} else if keycloakInstance == nil {
-			return false, newResourceNotFoundError("Keycloak", instance.Spec.Resource.Name, namespace)
+			return false, newResourceNotFoundError(infrastructure.KeycloakKind, instance.Spec.Resource.Name, namespace)
 		}
 	} else {
 		log.Debugf("Custom Keycloak instance reference is not provided")
 		// check whether Keycloak instance exist
-		keycloakInstance, resultErr := loadDeployedKeycloakInstance(client, infrastructure.KeycloakInstanceName, instance.Namespace)
+		keycloakInstance, resultErr = loadDeployedKeycloakInstance(client, infrastructure.KeycloakInstanceName, instance.Namespace)
 		if resultErr != nil {
 			return false, resultErr
 		}
@@ -84,6 +143,30 @@ func (k *keycloakInfraResource) Reconcile(client *client.Client, instance *v1alp
 			return true, nil
 		}
 	}
+	if !keycloakInstance.Status.Ready || len(keycloakInstance.Status.InternalURL) == 0 {
+		return false, newResourceNotReadyError(instance, fmt.Errorf("Keycloak instance %s not ready yet", keycloakInstance.Name))
+	}
+	if len(keycloakInstance.Labels) == 0 {
+		return false, newInvalidResourceConfigurationError(fmt.Errorf("Keycloak instance %s must have labels to be selected by the Kogito realm", keycloakInstance.Name))
+	}
+
+	realm, resultErr := reconcileKeycloakRealm(client, keycloakInstance, instance, scheme)
+	if resultErr != nil {
+		return false, resultErr
+	}
+	clientSecrets, resultErr := reconcileKeycloakClients(client, realm, instance, scheme)
+	if resultErr != nil {
+		return false, resultErr
+	}
+	secretName, resultErr := syncKeycloakCredentialSecret(client, clientSecrets, instance, scheme)
+	if resultErr != nil {
+		return false, resultErr
+	}
+
+	// clients are bound to each Kogito service, hence the client ID is resolved from the service namespace and name when the service is deployed
+	clientID := getKeycloakClientID(infrastructure.KogitoServiceNamePlaceholder, infrastructure.KogitoServiceNamespacePlaceholder)
+	instance.Status.AppProps = getKeycloakAppProps(infrastructure.GetKeycloakAuthServerURL(keycloakInstance, realm.Spec.Realm.Realm), clientID)
+	instance.Status.Env = getKeycloakClientSecretEnvVars(secretName, clientID)
 	return false, nil
 }
 
@@ -106,7 +189,7 @@ func createNewKeycloakInstance(cli *client.Client, name string, namespace string
 	log.Debug("Going to create kogito Keycloak instance")
 	log.Debugf("Creating default resources for Keycloak installation for Kogito Infra on %s namespace", namespace)
 	keycloakInstance := &keycloakv1alpha1.Keycloak{
-		ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: name},
+		ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{framework.LabelAppKey: name}},
 		Spec: keycloakv1alpha1.KeycloakSpec{
 			Extensions:     []string{keycloakMetricsExtension},
 			Instances:      1,
@@ -123,3 +206,105 @@ func createNewKeycloakInstance(cli *client.Client, name string, namespace string
 	log.Debug("Successfully created Kogito Keycloak instance")
 	return keycloakInstance, nil
 }
+
+// reconcileKeycloakRealm creates the KeycloakRealm for the Kogito services in the Keycloak instance namespace, unless it already exists.
+// An existing KeycloakRealm named after the KogitoInfra instance is used as is.
+func reconcileKeycloakRealm(cli *client.Client, keycloakInstance *keycloakv1alpha1.Keycloak, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (*keycloakv1alpha1.KeycloakRealm, error) {
+	realm := &keycloakv1alpha1.KeycloakRealm{}
+	exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: instance.Name, Namespace: keycloakInstance.Namespace}, realm)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		log.Debugf("Creating Keycloak realm %s", instance.Name)
+		realm = infrastructure.GetKeycloakRealmDefaultResource(
+			instance.Name, keycloakInstance.Namespace, map[string]string{framework.LabelAppKey: instance.Name}, keycloakInstance.Labels)
+		if err := createKeycloakResource(cli, realm, instance, scheme); err != nil {
+			return nil, err
+		}
+	}
+	if realm.Spec.Realm == nil || !realm.Status.Ready {
+		return nil, newResourceNotReadyError(instance, fmt.Errorf("Keycloak realm %s not ready yet", realm.Name))
+	}
+	return realm, nil
+}
+
+// reconcileKeycloakClients creates a KeycloakClient for each Kogito service depending on the KogitoInfra instance, in any allowed namespace,
+// and deletes the clients of the services not depending on it anymore. Returns the client secrets by client ID.
+func reconcileKeycloakClients(cli *client.Client, realm *keycloakv1alpha1.KeycloakRealm, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (map[string]string, error) {
+	consumers, err := getConsumers(cli, instance)
+	if err != nil {
+		return nil, err
+	}
+	var clientIDs []string
+	for _, consumer := range consumers {
+		clientID := getKeycloakClientID(consumer.Name, getConsumerNamespace(consumer, instance))
+		if !util.Contains(clientID, clientIDs) {
+			clientIDs = append(clientIDs, clientID)
+		}
+	}
+
+	labels := map[string]string{framework.LabelAppKey: instance.Name}
+	deployedClients := &keycloakv1alpha1.KeycloakClientList{}
+	if err := kubernetes.ResourceC(cli).ListWithNamespaceAndLabel(realm.Namespace, deployedClients, labels); err != nil {
+		return nil, err
+	}
+	clientSecrets := map[string]string{}
+	for _, deployedClient := range deployedClients.Items {
+		if deployedClient.Spec.Client == nil || !util.Contains(deployedClient.Spec.Client.ClientID, clientIDs) {
+			log.Debugf("Deleting Keycloak client %s, the service doesn't depend on %s anymore", deployedClient.Name, instance.Name)
+			if err := kubernetes.ResourceC(cli).Delete(&deployedClient); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		clientSecrets[deployedClient.Spec.Client.ClientID] = deployedClient.Spec.Client.Secret
+	}
+	for _, clientID := range clientIDs {
+		if _, exists := clientSecrets[clientID]; exists {
+			continue
+		}
+		secret, err := util.GeneratePassword(keycloakClientSecretLength)
+		if err != nil {
+			return nil, err
+		}
+		log.Debugf("Creating Keycloak client %s", clientID)
+		keycloakClient := infrastructure.GetKeycloakClientDefaultResource(
+			fmt.Sprintf("%s-%s", instance.Name, clientID), realm.Namespace, clientID, secret, labels, realm.Labels)
+		if err := createKeycloakResource(cli, keycloakClient, instance, scheme); err != nil {
+			return nil, err
+		}
+		clientSecrets[clientID] = secret
+	}
+	return clientSecrets, nil
+}
+
+// getKeycloakClientID gets the ID of the Keycloak client bound to the given Kogito service, qualified by its namespace
+// to not collide with the services of other namespaces having the same name
+func getKeycloakClientID(name, namespace string) string {
+	return namespace + keycloakClientIDSeparator + name
+}
+
+// createKeycloakResource creates the given Keycloak resource, owned by the KogitoInfra instance when deployed in the same namespace
+func createKeycloakResource(cli *client.Client, resource meta.ResourceObject, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+	if resource.GetNamespace() == instance.Namespace {
+		return kubernetes.ResourceC(cli).CreateForOwner(resource, instance, scheme)
+	}
+	setKogitoInfraCreator(resource, instance)
+	return kubernetes.ResourceC(cli).Create(resource)
+}
+
+// syncKeycloakCredentialSecret stores the client secret of each Kogito service depending on the KogitoInfra in a Secret
+// in the KogitoInfra namespace, keyed by the client ID
+func syncKeycloakCredentialSecret(cli *client.Client, clientSecrets map[string]string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (string, error) {
+	data := map[string][]byte{}
+	for clientID, clientSecret := range clientSecrets {
+		data[clientID] = []byte(clientSecret)
+	}
+
+	secretName := instance.Name + keycloakCredentialSecretSuffix
+	if err := syncInfraSecret(cli, instance, secretName, data, scheme); err != nil {
+		return "", err
+	}
+	return secretName, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	keycloakv1alpha1 "github.com/keycloak/keycloak-operator/pkg/apis/keycloak/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_Reconcile_KeycloakResource_CreateDefault(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-keycloak-infra", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KeycloakAPIVersion,
+				Kind:       infrastructure.KeycloakKind,
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	keycloak := &keycloakv1alpha1.Keycloak{ObjectMeta: v1.ObjectMeta{Name: infrastructure.KeycloakInstanceName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, keycloak)
+	assert.NotEmpty(t, keycloak.Labels)
+
+	// Keycloak not ready yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+	assert.Empty(t, kogitoInfra.Status.AppProps)
+}
+
+func Test_Reconcile_KeycloakResource(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-keycloak-infra",
+			Namespace: t.Name(),
+			UID:       types.UID("infra-uid"),
+		},
+		Spec: v1alpha1.KogitoInfraSpec{
+			AllowedNamespaces: []string{"team-a"},
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KeycloakAPIVersion,
+				Kind:       infrastructure.KeycloakKind,
+				Name:       "sso",
+			},
+		},
+	}
+	keycloak := &keycloakv1alpha1.Keycloak{
+		ObjectMeta: v1.ObjectMeta{Name: "sso", Namespace: t.Name(), Labels: map[string]string{"app": "sso"}},
+		Status:     keycloakv1alpha1.KeycloakStatus{Ready: true, InternalURL: "https://sso.example.com"},
+	}
+	staleClient := &keycloakv1alpha1.KeycloakClient{
+		ObjectMeta: v1.ObjectMeta{Name: "my-keycloak-infra-visas", Namespace: t.Name(), Labels: map[string]string{framework.LabelAppKey: "my-keycloak-infra"}},
+		Spec:       keycloakv1alpha1.KeycloakClientSpec{Client: &keycloakv1alpha1.KeycloakAPIClient{ClientID: "visas"}},
+	}
+	// services with the same name in different namespaces get their own client
+	travels := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "travels", Namespace: t.Name()},
+		Spec:       v1alpha1.KogitoRuntimeSpec{KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{"my-keycloak-infra"}}},
+	}
+	teamATravels := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "travels", Namespace: "team-a"},
+		Spec:       v1alpha1.KogitoRuntimeSpec{KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{t.Name() + "/my-keycloak-infra"}}},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, keycloak, staleClient, travels, teamATravels).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	// realm created, but not ready yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	realm := &keycloakv1alpha1.KeycloakRealm{ObjectMeta: v1.ObjectMeta{Name: "my-keycloak-infra", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, realm)
+	assert.Equal(t, keycloak.Labels, realm.Spec.InstanceSelector.MatchLabels)
+	assert.Equal(t, infrastructure.KeycloakDefaultRealm, realm.Spec.Realm.Realm)
+
+	realm.Status.Ready = true
+	assert.NoError(t, kubernetes.ResourceC(client).Update(realm))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+
+	// a client is created for each service depending on the infra and the stale ones are removed
+	clientID := t.Name() + ".travels"
+	keycloakClient := &keycloakv1alpha1.KeycloakClient{ObjectMeta: v1.ObjectMeta{Name: "my-keycloak-infra-" + clientID, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, keycloakClient)
+	assert.Equal(t, clientID, keycloakClient.Spec.Client.ClientID)
+	assert.Equal(t, realm.Labels, keycloakClient.Spec.RealmSelector.MatchLabels)
+	teamAClient := &keycloakv1alpha1.KeycloakClient{ObjectMeta: v1.ObjectMeta{Name: "my-keycloak-infra-team-a.travels", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, teamAClient)
+	assert.Equal(t, "team-a.travels", teamAClient.Spec.Client.ClientID)
+	exists, err := kubernetes.ResourceC(client).Fetch(staleClient)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	secret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "my-keycloak-infra-keycloak-credential", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, secret)
+	assert.Equal(t, map[string][]byte{
+		clientID:         []byte(keycloakClient.Spec.Client.Secret),
+		"team-a.travels": []byte(teamAClient.Spec.Client.Secret),
+	}, secret.Data)
+
+	placeholderClientID := infrastructure.KogitoServiceNamespacePlaceholder + "." + infrastructure.KogitoServiceNamePlaceholder
+	assert.Equal(t, "https://sso.example.com/auth/realms/kogito", kogitoInfra.Status.AppProps[quarkusOidcAuthServerURLAppProp])
+	assert.Equal(t, placeholderClientID, kogitoInfra.Status.AppProps[quarkusOidcClientIDAppProp])
+	assert.Equal(t, placeholderClientID, kogitoInfra.Status.AppProps[springClientIDAppProp])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(quarkusOidcClientSecretEnvKey, secret.Name, placeholderClientID))
+}
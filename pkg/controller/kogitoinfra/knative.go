@@ -16,42 +16,112 @@ package kogitoinfra
 
 import (
 	"fmt"
+
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
-	"github.com/kiegroup/kogito-cloud-operator/pkg/logger"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 )
 
-// kafkaInfraResource for Knative resources reconciliation
+const (
+	// knativeSinkEnvKey environment variable holding the URL where the services send their events, also injected by the SinkBinding
+	knativeSinkEnvKey = "K_SINK"
+
+	// quarkusKnativeOutgoingConnectorAppProp quarkus application property for setting the connector of the outgoing events
+	quarkusKnativeOutgoingConnectorAppProp = "mp.messaging.outgoing.kogito_outgoing_stream.connector"
+	// quarkusKnativeOutgoingURLAppProp quarkus application property for setting the URL of the outgoing events
+	quarkusKnativeOutgoingURLAppProp = "mp.messaging.outgoing.kogito_outgoing_stream.url"
+
+	quarkusHTTPConnector = "quarkus-http"
+)
+
+// getKnativeAppProps creates the application properties to send the events to the Knative Eventing Broker
+func getKnativeAppProps() map[string]string {
+	return map[string]string{
+		quarkusKnativeOutgoingConnectorAppProp: quarkusHTTPConnector,
+		quarkusKnativeOutgoingURLAppProp:       fmt.Sprintf("${%s}", knativeSinkEnvKey),
+	}
+}
+
+// getKnativeEnvVars creates the environment variables enabling the events sent to the given Knative Eventing Broker URL
+func getKnativeEnvVars(brokerURL string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		framework.CreateEnvVar(enableEventsEnvKey, "true"),
+		framework.CreateEnvVar(knativeSinkEnvKey, brokerURL),
+	}
+}
+
+// knativeInfraResource for Knative resources reconciliation
 type knativeInfraResource struct{}
 
-// Reconcile ...
+// getKnativeWatchedObjects provide list of object that needs to be watched to maintain Knative kogitoInfra resource
+func getKnativeWatchedObjects() []framework.WatchedObjects {
+	return []framework.WatchedObjects{
+		{
+			GroupVersion: eventingv1.SchemeGroupVersion,
+			AddToScheme:  eventingv1.AddToScheme,
+			Objects:      []runtime.Object{&eventingv1.Broker{}},
+		},
+	}
+}
+
+// Reconcile reconcile Kogito infra object
 func (i *knativeInfraResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (requeue bool, resultErr error) {
+	var broker *eventingv1.Broker
 
 	if !infrastructure.IsKnativeEventingAvailable(client) {
 		return false, newResourceAPINotFoundError(&instance.Spec.Resource)
 	}
 
-	if len(instance.Spec.Resource.Name) > 0 {
-		var log = logger.GetLogger("kogitoinfra-knative-reconcile")
-		ns := instance.Spec.Resource.Namespace
-		if len(ns) == 0 {
-			log.Debugf("Namespace not defined, fetching from the current ns: %s", instance.Namespace)
-			ns = instance.Namespace
+	name, namespace := infrastructure.GetKnativeEventingBrokerReference(instance)
+	if broker, resultErr = loadDeployedBroker(client, name, namespace); resultErr != nil {
+		return false, resultErr
+	}
+	if broker == nil {
+		if len(instance.Spec.Resource.Name) > 0 {
+			return false, newResourceNotFoundError(infrastructure.KnativeEventingBrokerKind, name, namespace)
 		}
-		broker := eventingv1.Broker{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: instance.Spec.Resource.Name}}
-		if exists, resultErr := kubernetes.ResourceC(client).Fetch(&broker); resultErr != nil {
+		log.Debugf("Knative Eventing Broker reference is not provided")
+		if resultErr = createNewBroker(client, namespace, instance, scheme); resultErr != nil {
 			return false, resultErr
-		} else if !exists {
-			return false, newResourceNotFoundError(infrastructure.KnativeEventingBrokerKind, broker.Name, broker.Namespace)
 		}
-	} else {
-		return false,
-			fmt.Errorf("No Knative Eventing Broker resource defined in the KogitoInfra CR %s on namespace %s, impossible to continue ", instance.Name, instance.Namespace)
+		return true, nil
+	}
+	if !broker.Status.IsReady() || broker.Status.Address.URL == nil {
+		return false, newResourceNotReadyError(instance, fmt.Errorf("Knative Eventing Broker %s not ready yet", broker.Name))
 	}
+	instance.Status.AppProps = getKnativeAppProps()
+	instance.Status.Env = getKnativeEnvVars(broker.Status.Address.URL.String())
 	return false, nil
 }
+
+func loadDeployedBroker(cli *client.Client, name, namespace string) (*eventingv1.Broker, error) {
+	log.Debug("fetching deployed Knative Eventing Broker")
+	broker := &eventingv1.Broker{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: name, Namespace: namespace}, broker); err != nil {
+		log.Error("Error occurs while fetching Knative Eventing Broker")
+		return nil, err
+	} else if !exists {
+		log.Debug("Knative Eventing Broker does not exist")
+		return nil, nil
+	} else {
+		log.Debug("Knative Eventing Broker found")
+		return broker, nil
+	}
+}
+
+func createNewBroker(cli *client.Client, namespace string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+	log.Debug("Going to create Knative Eventing Broker")
+	broker := infrastructure.GetKnativeEventingBrokerDefaultResource(namespace)
+	if err := kubernetes.ResourceC(cli).CreateForOwner(broker, instance, scheme); err != nil {
+		log.Error("Error occurs while creating Knative Eventing Broker")
+		return err
+	}
+	log.Debug("Knative Eventing Broker created successfully")
+	return nil
+}
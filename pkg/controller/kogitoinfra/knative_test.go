@@ -0,0 +1,159 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func Test_Reconcile_KnativeResource_CreateDefault(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-knative", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KnativeEventingAPIVersion,
+				Kind:       infrastructure.KnativeEventingBrokerKind,
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	broker := &eventingv1.Broker{ObjectMeta: v1.ObjectMeta{Name: infrastructure.KnativeEventingBrokerDefaultName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, broker)
+
+	// broker not ready yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+
+	broker.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+	broker.Status.Address = duckv1.Addressable{URL: apis.HTTP("broker-ingress.knative-eventing.svc.cluster.local")}
+	assert.NoError(t, kubernetes.ResourceC(client).Update(broker))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(knativeSinkEnvKey, "http://broker-ingress.knative-eventing.svc.cluster.local"))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(enableEventsEnvKey, "true"))
+	assert.Equal(t, "${K_SINK}", kogitoInfra.Status.AppProps[quarkusKnativeOutgoingURLAppProp])
+}
+
+func Test_Reconcile_KnativeResource_NotFound(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-knative", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KnativeEventingAPIVersion,
+				Kind:       infrastructure.KnativeEventingBrokerKind,
+				Name:       "my-broker",
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotFound, kogitoInfra.Status.Condition.Reason)
+	exists, err := kubernetes.ResourceC(client).Fetch(&eventingv1.Broker{ObjectMeta: v1.ObjectMeta{Name: infrastructure.KnativeEventingBrokerDefaultName, Namespace: t.Name()}})
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func Test_Reconcile_KnativeResource_Conditions(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-knative", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KnativeEventingAPIVersion,
+				Kind:       infrastructure.KnativeEventingBrokerKind,
+			},
+		},
+	}
+	kogitoRuntime := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "travels", Namespace: t.Name()},
+		Spec:       v1alpha1.KogitoRuntimeSpec{KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{kogitoInfra.Name}}},
+	}
+	dataIndex := &v1alpha1.KogitoSupportingService{
+		ObjectMeta: v1.ObjectMeta{Name: "data-index", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoSupportingServiceSpec{
+			ServiceType: v1alpha1.DataIndex,
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Messaging: v1alpha1.Messaging{Channels: []v1alpha1.MessagingChannel{{Name: "kogito-processinstances-events", Infra: kogitoInfra.Name}}},
+			},
+		},
+	}
+	otherRuntime := &v1alpha1.KogitoRuntime{ObjectMeta: v1.ObjectMeta{Name: "visas", Namespace: t.Name()}}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, kogitoRuntime, dataIndex, otherRuntime).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	// broker just created
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.False(t, kogitoInfra.Status.IsConditionTrue(v1alpha1.ReadyInfraConditionType))
+	assert.False(t, kogitoInfra.Status.IsConditionTrue(v1alpha1.ConfiguredInfraConditionType))
+	assert.Equal(t, &v1alpha1.Resource{
+		APIVersion: infrastructure.KnativeEventingAPIVersion,
+		Kind:       infrastructure.KnativeEventingBrokerKind,
+		Name:       infrastructure.KnativeEventingBrokerDefaultName,
+		Namespace:  t.Name(),
+	}, kogitoInfra.Status.Resource)
+	assert.Equal(t, []v1alpha1.KogitoInfraConsumer{
+		{Kind: kogitoRuntimeKind, Name: kogitoRuntime.Name},
+		{Kind: kogitoSupportingServiceKind, Name: dataIndex.Name},
+	}, kogitoInfra.Status.Consumers)
+
+	broker := &eventingv1.Broker{ObjectMeta: v1.ObjectMeta{Name: infrastructure.KnativeEventingBrokerDefaultName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, broker)
+	broker.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+	broker.Status.Address = duckv1.Addressable{URL: apis.HTTP("broker-ingress.knative-eventing.svc.cluster.local")}
+	assert.NoError(t, kubernetes.ResourceC(client).Update(broker))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.True(t, kogitoInfra.Status.IsConditionTrue(v1alpha1.ReadyInfraConditionType))
+	assert.True(t, kogitoInfra.Status.IsConditionTrue(v1alpha1.ResourceAvailableInfraConditionType))
+	assert.True(t, kogitoInfra.Status.IsConditionTrue(v1alpha1.ConfiguredInfraConditionType))
+
+	// transient unavailability of the broker keeps the infra configured
+	broker.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionFalse}}
+	assert.NoError(t, kubernetes.ResourceC(client).Update(broker))
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.True(t, kogitoInfra.Status.IsConditionTrue(v1alpha1.ConfiguredInfraConditionType))
+	assert.False(t, kogitoInfra.Status.IsConditionTrue(v1alpha1.ResourceAvailableInfraConditionType))
+	ready := kogitoInfra.Status.GetCondition(v1alpha1.ReadyInfraConditionType)
+	assert.Equal(t, corev1.ConditionFalse, ready.Status)
+	assert.Equal(t, v1alpha1.ResourceNotReady, ready.Reason)
+}
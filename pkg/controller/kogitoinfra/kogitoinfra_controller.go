@@ -22,6 +22,8 @@ import (
 
 	appv1alpha1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -68,10 +70,31 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	var watchedObjects []framework.WatchedObjects
+	// Watch for changes to the Kogito services depending on KogitoInfra instances to keep their consumers up to date
+	consumerPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+	}
+	consumerHandler := &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapConsumerToKogitoInfras)}
+	for _, consumer := range []runtime.Object{&appv1alpha1.KogitoRuntime{}, &appv1alpha1.KogitoSupportingService{}} {
+		if err = c.Watch(&source.Kind{Type: consumer}, consumerHandler, consumerPred); err != nil {
+			return err
+		}
+	}
+
+	// Secrets published by the KogitoInfra instances, copied into the namespaces of the services of other namespaces
+	watchedObjects := []framework.WatchedObjects{{Objects: []runtime.Object{&corev1.Secret{}}}}
 	watchedObjects = append(watchedObjects, getInfinispanWatchedObjects()...)
 	watchedObjects = append(watchedObjects, getKafkaWatchedObjects()...)
 	watchedObjects = append(watchedObjects, getKeycloakWatchedObjects()...)
+	watchedObjects = append(watchedObjects, getKnativeWatchedObjects()...)
+	watchedObjects = append(watchedObjects, getPostgresqlWatchedObjects()...)
+	watchedObjects = append(watchedObjects, getMongoDBWatchedObjects()...)
+	watchedObjects = append(watchedObjects, getPrometheusWatchedObjects()...)
+	watchedObjects = append(watchedObjects, getGrafanaWatchedObjects()...)
+	watchedObjects = append(watchedObjects, getJaegerWatchedObjects()...)
+	watchedObjects = append(watchedObjects, getArtemisWatchedObjects()...)
 
 	controllerWatcher := framework.NewControllerWatcher(r.(*ReconcileKogitoInfra).client, mgr, c, &appv1alpha1.KogitoInfra{})
 	if err = controllerWatcher.Watch(watchedObjects...); err != nil {
@@ -81,6 +104,19 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	return nil
 }
 
+// mapConsumerToKogitoInfras enqueues the KogitoInfra instances the changed Kogito service depends on
+func mapConsumerToKogitoInfras(object handler.MapObject) []reconcile.Request {
+	service, ok := object.Object.(appv1alpha1.KogitoService)
+	if !ok {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, infraName := range infrastructure.GetKogitoInfraReferences(service) {
+		requests = append(requests, reconcile.Request{NamespacedName: infrastructure.GetKogitoInfraKey(infraName, object.Meta.GetNamespace())})
+	}
+	return requests
+}
+
 // blank assignment to verify that ReconcileKogitoInfra implements reconcile.Reconciler
 var _ reconcile.Reconciler = &ReconcileKogitoInfra{}
 
@@ -101,26 +137,52 @@ func (r *ReconcileKogitoInfra) Reconcile(request reconcile.Request) (reconcile.R
 	log.Infof("Reconciling KogitoInfra for %s in %s", request.Name, request.Namespace)
 
 	// Fetch the KogitoInfra instance
-	instance, resultErr := infrastructure.MustFetchKogitoInfraInstance(r.client, request.Name, request.Namespace)
-	if resultErr != nil {
+	instance := &appv1alpha1.KogitoInfra{}
+	if exists, resultErr := kubernetes.ResourceC(r.client).FetchWithKey(request.NamespacedName, instance); resultErr != nil {
 		return reconcile.Result{}, resultErr
+	} else if !exists {
+		// Kogito services can reference a KogitoInfra not created yet
+		log.Debugf("KogitoInfra %s not found in %s, skipping reconciliation", request.Name, request.Namespace)
+		return reconcile.Result{}, nil
+	}
+
+	if instance.GetDeletionTimestamp() != nil {
+		if held, err := finalizeKogitoInfra(r.client, instance); err != nil {
+			return reconcile.Result{}, err
+		} else if held {
+			return reconcile.Result{RequeueAfter: reconciliationStandardInterval}, nil
+		}
+		return reconcile.Result{}, nil
+	}
+	if err := addKogitoInfraFinalizer(r.client, instance); err != nil {
+		return reconcile.Result{}, err
 	}
 
 	// make KogitoInfra as self owner so that it will not removed when kogito service referring to it deleted because
 	// kogito services are also become owner of kogitoInfra when reference of infra provided in Kogito services.
+	var resultErr error
 	if resultErr = framework.AddOwnerReference(instance, r.scheme, instance); resultErr != nil {
 		return reconcile.Result{}, resultErr
 	}
 
-	defer updateBaseStatus(r.client, instance, &resultErr)
+	requeue := false
+	defer updateBaseStatus(r.client, instance, &requeue, &resultErr)
 
 	infraResource, resultErr := getKogitoInfraResource(instance)
 	if resultErr != nil {
 		return r.getReconcileResultFor(resultErr, false)
 	}
 
-	requeue, resultErr := infraResource.Reconcile(r.client, instance, r.scheme)
-	return r.getReconcileResultFor(resultErr, requeue)
+	requeue, resultErr = infraResource.Reconcile(r.client, instance, r.scheme)
+	if resultErr == nil {
+		resultErr = syncConsumerSecrets(r.client, instance)
+	}
+	result, err := r.getReconcileResultFor(resultErr, requeue)
+	if instance.Spec.Mapping != nil && err == nil && result.RequeueAfter == 0 {
+		// resources of arbitrary kinds can't be watched, so mapped resources are polled
+		result.RequeueAfter = reconciliationStandardInterval
+	}
+	return result, err
 }
 
 func (r *ReconcileKogitoInfra) getReconcileResultFor(err error, requeue bool) (reconcile.Result, error) {
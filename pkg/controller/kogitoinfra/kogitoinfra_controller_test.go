@@ -22,6 +22,7 @@ import (
 	kafkabetav1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/kafka/v1beta1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
 	"github.com/stretchr/testify/assert"
@@ -127,10 +128,6 @@ func Test_Reconcile_Infinispan(t *testing.T) {
 		},
 	}
 
-	deployedCustomSecret := &corev1.Secret{
-		ObjectMeta: v1.ObjectMeta{Name: "kogito-infinispan-credential", Namespace: t.Name()},
-	}
-
 	infinispanService := &corev1.Service{
 		ObjectMeta: v1.ObjectMeta{Name: "kogito-infinispan", Namespace: t.Name()},
 		Spec: corev1.ServiceSpec{
@@ -143,7 +140,7 @@ func Test_Reconcile_Infinispan(t *testing.T) {
 	}
 
 	client := test.NewFakeClientBuilder().
-		AddK8sObjects(kogitoInfra, deployedInfinispan, deployedCustomSecret, infinispanService).
+		AddK8sObjects(kogitoInfra, deployedInfinispan, infinispanService).
 		Build()
 
 	scheme := meta.GetRegisteredSchema()
@@ -159,4 +156,106 @@ func Test_Reconcile_Infinispan(t *testing.T) {
 	assert.Equal(t, "true", infinispanAppProps["quarkus.infinispan-client.use-auth"])
 	assert.Equal(t, "PLAIN", infinispanAppProps["quarkus.infinispan-client.sasl-mechanism"])
 	assert.Empty(t, infinispanAppProps["quarkus.infinispan-client.auth-realm"])
+	assert.Empty(t, infinispanAppProps["quarkus.infinispan-client.trust-store"])
+	assert.NotContains(t, infinispanAppProps, kogitoInfinispanCachePrefixAppProp)
+	assert.Empty(t, kogitoInfra.Status.Volumes)
+
+	credentialSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "kogito-infinispan-infinispan-credential", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, credentialSecret)
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesInfinispanQuarkus[envVarInfinispanUser], credentialSecret.Name, infrastructure.InfinispanSecretUsernameKey))
+}
+
+func Test_Reconcile_InfinispanResourceNaming(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-infinispan", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.InfinispanAPIVersion,
+				Kind:       infrastructure.InfinispanKind,
+				Name:       "kogito-infinispan",
+				Namespace:  t.Name(),
+			},
+			ResourceNaming: &v1alpha1.ResourceNamingPolicy{Prefix: "$(NAMESPACE)-", Suffix: "-v1"},
+		},
+	}
+	deployedInfinispan := &ispn.Infinispan{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-infinispan", Namespace: t.Name()},
+		Status:     ispn.InfinispanStatus{Conditions: []ispn.InfinispanCondition{{Status: string(v1.ConditionTrue)}}},
+	}
+	infinispanService := &corev1.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-infinispan", Namespace: t.Name()},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{TargetPort: intstr.FromInt(11222)}}},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, deployedInfinispan, infinispanService).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+
+	test.AssertFetchMustExist(t, client, kogitoInfra)
+	// the namespace reference is resolved by each bound service
+	assert.Equal(t, infrastructure.KogitoServiceNamespacePlaceholder+"-", kogitoInfra.Status.AppProps[kogitoInfinispanCachePrefixAppProp])
+	assert.Equal(t, "-v1", kogitoInfra.Status.AppProps[kogitoInfinispanCacheSuffixAppProp])
+}
+
+func Test_Reconcile_InfinispanEncryption(t *testing.T) {
+	deployedInfinispan := &ispn.Infinispan{
+		ObjectMeta: v1.ObjectMeta{Name: "encrypted-infinispan", Namespace: t.Name()},
+		Spec: ispn.InfinispanSpec{
+			Security: ispn.InfinispanSecurity{
+				EndpointSecretName: "encrypted-infinispan-generated-secret",
+				EndpointEncryption: ispn.EndpointEncryption{Type: "Secret", CertSecretName: "encrypted-infinispan-cert"},
+			},
+		},
+		Status: ispn.InfinispanStatus{
+			Conditions: []ispn.InfinispanCondition{{Status: string(v1.ConditionTrue)}},
+		},
+	}
+	endpointSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "encrypted-infinispan-generated-secret", Namespace: t.Name()},
+		Data: map[string][]byte{
+			infrastructure.InfinispanIdentityFileName: []byte("credentials:\n- username: developer\n  password: secret\n"),
+		},
+	}
+	certSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "encrypted-infinispan-cert", Namespace: t.Name()},
+		Data:       map[string][]byte{infrastructure.InfinispanTLSCertKey: []byte("certificate")},
+	}
+	infinispanService := &corev1.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "encrypted-infinispan", Namespace: t.Name()},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{TargetPort: intstr.FromInt(11222)}}},
+	}
+	encryptedInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "encrypted", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.InfinispanAPIVersion,
+				Kind:       infrastructure.InfinispanKind,
+				Name:       deployedInfinispan.Name,
+			},
+		},
+	}
+
+	client := test.NewFakeClientBuilder().
+		AddK8sObjects(encryptedInfra, deployedInfinispan, endpointSecret, certSecret, infinispanService).
+		Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+	test.AssertReconcileMustNotRequeue(t, r, encryptedInfra)
+
+	_, err := kubernetes.ResourceC(client).Fetch(encryptedInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, encryptedInfra.Status.Condition.Type)
+	appProps := encryptedInfra.Status.AppProps
+	assert.Equal(t, "true", appProps[propertiesInfinispanSpring[appPropInfinispanUseSSL]])
+	assert.Equal(t, "/home/kogito/certs/encrypted/tls.crt", appProps[propertiesInfinispanQuarkus[appPropInfinispanTrustStore]])
+	assert.Equal(t, pemTrustStoreType, appProps[propertiesInfinispanQuarkus[appPropInfinispanTrustStoreType]])
+	assert.Equal(t, "/home/kogito/certs/encrypted/tls.crt", appProps[propertiesInfinispanSpring[appPropInfinispanTrustStore]])
+	assert.Equal(t, []v1alpha1.KogitoInfraVolume{
+		{Name: "encrypted-infinispan-credential", SecretName: "encrypted-infinispan-credential", MountPath: "/home/kogito/certs/encrypted"},
+	}, encryptedInfra.Status.Volumes)
+
+	credentialSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "encrypted-infinispan-credential", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, credentialSecret)
+	assert.Equal(t, "developer", string(credentialSecret.Data[infrastructure.InfinispanSecretUsernameKey]))
+	assert.Equal(t, "secret", string(credentialSecret.Data[infrastructure.InfinispanSecretPasswordKey]))
+	assert.Equal(t, "certificate", string(credentialSecret.Data[infrastructure.InfinispanTLSCertKey]))
 }
@@ -16,16 +16,34 @@ package kogitoinfra
 
 import (
 	"fmt"
-
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	kogitoRuntimeKind           = "KogitoRuntime"
+	kogitoSupportingServiceKind = "KogitoSupportingService"
 )
 
 // getKogitoInfraResource identify and return request kogito infra resource on bases of information provided in kogitoInfra value
 func getKogitoInfraResource(instance *v1alpha1.KogitoInfra) (InfraResource, error) {
 	log.Debugf("going to fetch related kogito infra resource for given infra instance : %s", instance.Name)
+	if instance.Spec.External != nil {
+		return &externalInfraResource{}, nil
+	}
+	if instance.Spec.Mapping != nil {
+		return &mappedInfraResource{}, nil
+	}
 	if infraRes, ok := getSupportedInfraResources()[resourceClassForInstance(instance)]; ok {
 		return infraRes, nil
 	}
@@ -46,5 +64,135 @@ func getSupportedInfraResources() map[string]InfraResource {
 		getResourceClass(infrastructure.KafkaKind, infrastructure.KafkaAPIVersion):                           &kafkaInfraResource{},
 		getResourceClass(infrastructure.KeycloakKind, infrastructure.KeycloakAPIVersion):                     &keycloakInfraResource{},
 		getResourceClass(infrastructure.KnativeEventingBrokerKind, infrastructure.KnativeEventingAPIVersion): &knativeInfraResource{},
+		getResourceClass(infrastructure.PostgresqlKind, infrastructure.PostgresqlAPIVersion):                 &postgresqlInfraResource{},
+		getResourceClass(infrastructure.MongoDBKind, infrastructure.MongoDBAPIVersion):                       &mongoDBInfraResource{},
+		getResourceClass(infrastructure.PrometheusKind, infrastructure.PrometheusAPIVersion):                 &prometheusInfraResource{},
+		getResourceClass(infrastructure.GrafanaKind, infrastructure.GrafanaAPIVersion):                       &grafanaInfraResource{},
+		getResourceClass(infrastructure.JaegerKind, infrastructure.JaegerAPIVersion):                         &jaegerInfraResource{},
+		getResourceClass(infrastructure.ArtemisKind, infrastructure.ArtemisAPIVersion):                       &artemisInfraResource{},
+	}
+}
+
+// getDefaultResourceNames gets the names of the resources created by KogitoInfra when no resource name is referenced, by resource class
+func getDefaultResourceNames() map[string]string {
+	return map[string]string{
+		getResourceClass(infrastructure.InfinispanKind, infrastructure.InfinispanAPIVersion):                 infrastructure.InfinispanInstanceName,
+		getResourceClass(infrastructure.KafkaKind, infrastructure.KafkaAPIVersion):                           infrastructure.KafkaInstanceName,
+		getResourceClass(infrastructure.KeycloakKind, infrastructure.KeycloakAPIVersion):                     infrastructure.KeycloakInstanceName,
+		getResourceClass(infrastructure.KnativeEventingBrokerKind, infrastructure.KnativeEventingAPIVersion): infrastructure.KnativeEventingBrokerDefaultName,
+		getResourceClass(infrastructure.PostgresqlKind, infrastructure.PostgresqlAPIVersion):                 infrastructure.PostgresqlInstanceName,
+		getResourceClass(infrastructure.MongoDBKind, infrastructure.MongoDBAPIVersion):                       infrastructure.MongoDBInstanceName,
+		getResourceClass(infrastructure.PrometheusKind, infrastructure.PrometheusAPIVersion):                 infrastructure.PrometheusInstanceName,
+		getResourceClass(infrastructure.GrafanaKind, infrastructure.GrafanaAPIVersion):                       infrastructure.GrafanaInstanceName,
+		getResourceClass(infrastructure.JaegerKind, infrastructure.JaegerAPIVersion):                         infrastructure.JaegerInstanceName,
+		getResourceClass(infrastructure.ArtemisKind, infrastructure.ArtemisAPIVersion):                       infrastructure.ArtemisInstanceName,
+	}
+}
+
+// getResolvedResource gets the resource backing the given KogitoInfra instance, defaulting its name and namespace
+// to the ones of the resource created by KogitoInfra. Returns nil for external infrastructure.
+func getResolvedResource(instance *v1alpha1.KogitoInfra) *v1alpha1.Resource {
+	if instance.Spec.External != nil || len(instance.Spec.Resource.Kind) == 0 {
+		return nil
+	}
+	resource := instance.Spec.Resource
+	if len(resource.Name) == 0 {
+		resource.Name = getDefaultResourceNames()[resourceClassForInstance(instance)]
+	}
+	if len(resource.Namespace) == 0 {
+		resource.Namespace = instance.Namespace
+	}
+	return &resource
+}
+
+// getConsumers gets the Kogito services depending on the given KogitoInfra instance, either referencing it in their infra
+// or binding their messaging channels to it, in its namespace and in the namespaces allowed to reference it
+func getConsumers(cli *client.Client, instance *v1alpha1.KogitoInfra) ([]v1alpha1.KogitoInfraConsumer, error) {
+	var services []v1alpha1.KogitoService
+	for _, namespace := range getConsumerNamespaces(instance) {
+		runtimes := &v1alpha1.KogitoRuntimeList{}
+		if err := kubernetes.ResourceC(cli).ListWithNamespace(namespace, runtimes); err != nil {
+			return nil, err
+		}
+		for i := range runtimes.Items {
+			services = append(services, &runtimes.Items[i])
+		}
+		supportingServices := &v1alpha1.KogitoSupportingServiceList{}
+		if err := kubernetes.ResourceC(cli).ListWithNamespace(namespace, supportingServices); err != nil {
+			return nil, err
+		}
+		for i := range supportingServices.Items {
+			services = append(services, &supportingServices.Items[i])
+		}
+	}
+	var consumers []v1alpha1.KogitoInfraConsumer
+	for _, service := range services {
+		if !infrastructure.IsKogitoInfraReferenced(service, instance) || !infrastructure.IsKogitoInfraNamespaceAllowed(instance, service.GetNamespace()) {
+			continue
+		}
+		consumer := v1alpha1.KogitoInfraConsumer{Kind: kogitoSupportingServiceKind, Name: service.GetName()}
+		if _, isRuntime := service.(*v1alpha1.KogitoRuntime); isRuntime {
+			consumer.Kind = kogitoRuntimeKind
+		}
+		if service.GetNamespace() != instance.Namespace {
+			consumer.Namespace = service.GetNamespace()
+		}
+		consumers = append(consumers, consumer)
+	}
+	sort.SliceStable(consumers, func(i, j int) bool {
+		if consumers[i].Kind != consumers[j].Kind {
+			return consumers[i].Kind < consumers[j].Kind
+		}
+		if consumers[i].Namespace != consumers[j].Namespace {
+			return consumers[i].Namespace < consumers[j].Namespace
+		}
+		return consumers[i].Name < consumers[j].Name
+	})
+	return consumers, nil
+}
+
+// getConsumerNamespaces gets the namespaces where the Kogito services depending on the given KogitoInfra instance can be,
+// an empty namespace standing for every namespace
+func getConsumerNamespaces(instance *v1alpha1.KogitoInfra) []string {
+	if util.Contains(v1alpha1.AllNamespaces, instance.Spec.AllowedNamespaces) {
+		return []string{""}
+	}
+	namespaces := []string{instance.Namespace}
+	for _, namespace := range instance.Spec.AllowedNamespaces {
+		if !util.Contains(namespace, namespaces) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// getConsumerNamespace gets the namespace of the given Kogito service depending on the KogitoInfra instance
+func getConsumerNamespace(consumer v1alpha1.KogitoInfraConsumer, instance *v1alpha1.KogitoInfra) string {
+	if len(consumer.Namespace) == 0 {
+		return instance.Namespace
+	}
+	return consumer.Namespace
+}
+
+// syncInfraSecret creates the Secret owned by the KogitoInfra instance holding the given data in its namespace,
+// or updates it when the data changed
+func syncInfraSecret(cli *client.Client, instance *v1alpha1.KogitoInfra, name string, data map[string][]byte, scheme *runtime.Scheme) error {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: instance.Namespace}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(secret)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugf("Creating new secret %s", secret.Name)
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = data
+		return kubernetes.ResourceC(cli).CreateForOwner(secret, instance, scheme)
+	}
+	// Secrets without data are fetched with nil data
+	if len(secret.Data)+len(data) == 0 || reflect.DeepEqual(secret.Data, data) {
+		return nil
 	}
+	log.Debugf("Data of KogitoInfra %s changed, updating secret %s", instance.Name, secret.Name)
+	secret.Data = data
+	return kubernetes.ResourceC(cli).Update(secret)
 }
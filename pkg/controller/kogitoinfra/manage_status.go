@@ -24,7 +24,7 @@ import (
 )
 
 // updateBaseStatus updates the base status for the KogitoInfra instance
-func updateBaseStatus(client *client.Client, instance *v1alpha1.KogitoInfra, err *error) {
+func updateBaseStatus(client *client.Client, instance *v1alpha1.KogitoInfra, requeue *bool, err *error) {
 	log.Info("Updating Kogito Infra status")
 	if *err != nil {
 		if reasonForError(*err) == v1alpha1.ReconciliationFailure {
@@ -35,6 +35,13 @@ func updateBaseStatus(client *client.Client, instance *v1alpha1.KogitoInfra, err
 		setResourceSuccess(instance)
 		log.Info("Kogito Infra successfully reconciled")
 	}
+	setConditions(instance, *requeue, *err)
+	instance.Status.Resource = getResolvedResource(instance)
+	if consumers, resultErr := getConsumers(client, instance); resultErr != nil {
+		log.Errorf("Error while listing the consumers of KogitoInfra %s: %v", instance.Name, resultErr)
+	} else {
+		instance.Status.Consumers = consumers
+	}
 	log.Infof("Updating kogitoInfra value with new properties : %s", instance.Name)
 	if resultErr := kubernetes.ResourceC(client).UpdateStatus(instance); resultErr != nil {
 		log.Errorf("reconciliationError occurs while update kogitoInfra values: %v", resultErr)
@@ -42,6 +49,55 @@ func updateBaseStatus(client *client.Client, instance *v1alpha1.KogitoInfra, err
 	log.Info("Successfully Update Kogito Infra status")
 }
 
+// setConditions sets the ResourceAvailable, Configured and Ready conditions for the result of the reconciliation.
+// When the backing resource becomes unavailable, the Configured condition is kept so that the bound services
+// keep running with the properties resolved before.
+func setConditions(instance *v1alpha1.KogitoInfra, requeue bool, err error) {
+	if err == nil {
+		if requeue {
+			// the backing resource has just been created
+			setCondition(instance, v1alpha1.ResourceAvailableInfraConditionType, corev1.ConditionFalse, v1alpha1.ResourceNotReady, "Waiting for the resource to be provisioned")
+			if instance.Status.GetCondition(v1alpha1.ConfiguredInfraConditionType) == nil {
+				setCondition(instance, v1alpha1.ConfiguredInfraConditionType, corev1.ConditionFalse, v1alpha1.ResourceNotReady, "Waiting for the resource to be provisioned")
+			}
+		} else {
+			setCondition(instance, v1alpha1.ResourceAvailableInfraConditionType, corev1.ConditionTrue, "", "")
+			setCondition(instance, v1alpha1.ConfiguredInfraConditionType, corev1.ConditionTrue, "", "")
+		}
+	} else {
+		reason := reasonForError(err)
+		switch reason {
+		case v1alpha1.ResourceNotFound, v1alpha1.ResourceAPINotFound, v1alpha1.ResourceNotReady:
+			setCondition(instance, v1alpha1.ResourceAvailableInfraConditionType, corev1.ConditionFalse, reason, err.Error())
+			if instance.Status.GetCondition(v1alpha1.ConfiguredInfraConditionType) == nil {
+				setCondition(instance, v1alpha1.ConfiguredInfraConditionType, corev1.ConditionFalse, reason, err.Error())
+			}
+		default:
+			setCondition(instance, v1alpha1.ConfiguredInfraConditionType, corev1.ConditionFalse, reason, err.Error())
+			if instance.Status.GetCondition(v1alpha1.ResourceAvailableInfraConditionType) == nil {
+				setCondition(instance, v1alpha1.ResourceAvailableInfraConditionType, corev1.ConditionUnknown, reason, err.Error())
+			}
+		}
+	}
+	for _, conditionType := range []v1alpha1.KogitoInfraConditionType{v1alpha1.ResourceAvailableInfraConditionType, v1alpha1.ConfiguredInfraConditionType} {
+		if condition := instance.Status.GetCondition(conditionType); condition.Status != corev1.ConditionTrue {
+			setCondition(instance, v1alpha1.ReadyInfraConditionType, corev1.ConditionFalse, condition.Reason, condition.Message)
+			return
+		}
+	}
+	setCondition(instance, v1alpha1.ReadyInfraConditionType, corev1.ConditionTrue, "", "")
+}
+
+func setCondition(instance *v1alpha1.KogitoInfra, conditionType v1alpha1.KogitoInfraConditionType, status corev1.ConditionStatus, reason v1alpha1.KogitoInfraConditionReason, message string) {
+	instance.Status.SetCondition(v1alpha1.KogitoInfraCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: instance.Generation,
+	})
+}
+
 // setResourceFailed sets the instance as failed
 func setResourceFailed(instance *v1alpha1.KogitoInfra, err error) {
 	if instance.Status.Condition.Message != err.Error() {
@@ -0,0 +1,219 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	// mappedSecretSuffix suffix of the Secret holding the keys copied from the Secrets referenced by a mapped resource
+	mappedSecretSuffix = "-mapped-secret"
+
+	defaultMappingReadyValue = "True"
+)
+
+// mappedInfraResource implementation of KogitoInfraResource for resources of any kind, bound through a declarative mapping
+type mappedInfraResource struct {
+}
+
+// Reconcile reconcile Kogito infra object
+func (m *mappedInfraResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (requeue bool, resultErr error) {
+	mapping := instance.Spec.Mapping
+	if resultErr = validateResourceMapping(instance); resultErr != nil {
+		return false, newInvalidResourceConfigurationError(resultErr)
+	}
+	resource, resultErr := loadMappedResource(client, instance)
+	if resultErr != nil {
+		return false, resultErr
+	}
+	if ready, err := isMappedResourceReady(mapping, resource); err != nil {
+		return false, err
+	} else if !ready {
+		return false, newResourceNotReadyError(instance, fmt.Errorf("%s %s not ready yet. Waiting for %s to be %s", resource.GetKind(), resource.GetName(), mapping.ReadinessPath, getMappingReadyValue(mapping)))
+	}
+
+	appProps := map[string]string{}
+	for _, property := range mapping.AppProps {
+		if appProps[property.Name], resultErr = evaluateMappingTemplate(property.Value, resource, instance); resultErr != nil {
+			return false, resultErr
+		}
+	}
+	var envVars []corev1.EnvVar
+	for _, property := range mapping.Env {
+		value, err := evaluateMappingTemplate(property.Value, resource, instance)
+		if err != nil {
+			return false, err
+		}
+		envVars = append(envVars, framework.CreateEnvVar(property.Name, value))
+	}
+	if len(mapping.SecretEnv) > 0 {
+		secretName, err := syncMappedSecret(client, resource, instance, scheme)
+		if err != nil {
+			return false, err
+		}
+		for _, secretEnv := range mapping.SecretEnv {
+			envVars = append(envVars, framework.CreateSecretEnvVar(secretEnv.Name, secretName, secretEnv.Name))
+		}
+	}
+	instance.Status.AppProps = appProps
+	instance.Status.Env = envVars
+	instance.Status.Volumes = nil
+	log.Debugf("Following app properties are set infra status : %s", appProps)
+	return false, nil
+}
+
+// validateResourceMapping verifies that the resource to map is fully referenced and that the templates can be parsed
+func validateResourceMapping(instance *v1alpha1.KogitoInfra) error {
+	resource := instance.Spec.Resource
+	if len(resource.APIVersion) == 0 || len(resource.Kind) == 0 || len(resource.Name) == 0 {
+		return fmt.Errorf("apiVersion, kind and name of the resource must be provided to apply a mapping")
+	}
+	if _, err := schema.ParseGroupVersion(resource.APIVersion); err != nil {
+		return err
+	}
+	mapping := instance.Spec.Mapping
+	templates := []string{mapping.ReadinessPath}
+	for _, property := range append(append([]v1alpha1.MappedProperty{}, mapping.AppProps...), mapping.Env...) {
+		templates = append(templates, property.Value)
+	}
+	for _, secretEnv := range mapping.SecretEnv {
+		templates = append(templates, secretEnv.SecretName)
+	}
+	for _, template := range templates {
+		if err := jsonpath.New("mapping").Parse(template); err != nil {
+			return fmt.Errorf("invalid template %s in the resource mapping: %v", template, err)
+		}
+	}
+	return nil
+}
+
+// loadMappedResource loads the resource referenced by the given KogitoInfra, in its namespace if not provided
+func loadMappedResource(cli *client.Client, instance *v1alpha1.KogitoInfra) (*unstructured.Unstructured, error) {
+	namespace := instance.Spec.Resource.Namespace
+	if len(namespace) == 0 {
+		namespace = instance.Namespace
+	}
+	if !infrastructure.IsNamespaceWatched(namespace) {
+		return nil, newInvalidResourceConfigurationError(fmt.Errorf("%s %s can't be mapped, the operator doesn't watch namespace %s", instance.Spec.Resource.Kind, instance.Spec.Resource.Name, namespace))
+	}
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion(instance.Spec.Resource.APIVersion)
+	resource.SetKind(instance.Spec.Resource.Kind)
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: instance.Spec.Resource.Name, Namespace: namespace}, resource); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, newResourceAPINotFoundError(&instance.Spec.Resource)
+		} else if errors.IsForbidden(err) {
+			return nil, newMappingForbiddenError(instance.Spec.Resource.APIVersion, instance.Spec.Resource.Kind, instance.Spec.Resource.Name, namespace)
+		}
+		return nil, err
+	} else if !exists {
+		return nil, newResourceNotFoundError(instance.Spec.Resource.Kind, instance.Spec.Resource.Name, namespace)
+	}
+	return resource, nil
+}
+
+// newMappingForbiddenError reports that the operator lacks the permissions to read a resource of the mapping,
+// since the resources of arbitrary kinds are not covered by the operator role and retrying won't help until they are granted
+func newMappingForbiddenError(apiVersion, kind, name, namespace string) reconciliationError {
+	group := schema.FromAPIVersionAndKind(apiVersion, kind).Group
+	return newInvalidResourceConfigurationError(fmt.Errorf("the operator is not allowed to read %s %s in namespace %s. "+
+		"Grant the kogito-operator service account the get permission on %s of API group \"%s\"", kind, name, namespace, kind, group))
+}
+
+// isMappedResourceReady checks the readiness template of the mapping, missing fields meaning that the resource is not ready
+func isMappedResourceReady(mapping *v1alpha1.ResourceMapping, resource *unstructured.Unstructured) (bool, error) {
+	if len(mapping.ReadinessPath) == 0 {
+		return true, nil
+	}
+	readiness := jsonpath.New("readiness").AllowMissingKeys(true)
+	if err := readiness.Parse(mapping.ReadinessPath); err != nil {
+		return false, err
+	}
+	value := &bytes.Buffer{}
+	if err := readiness.Execute(value, resource.Object); err != nil {
+		return false, err
+	}
+	return value.String() == getMappingReadyValue(mapping), nil
+}
+
+func getMappingReadyValue(mapping *v1alpha1.ResourceMapping) string {
+	if len(mapping.ReadyValue) == 0 {
+		return defaultMappingReadyValue
+	}
+	return mapping.ReadyValue
+}
+
+// evaluateMappingTemplate evaluates the given template against the resource.
+// Fields not found in the resource are expected to be set later by its operator, so the resource is not ready yet.
+func evaluateMappingTemplate(template string, resource *unstructured.Unstructured, instance *v1alpha1.KogitoInfra) (string, error) {
+	j := jsonpath.New("mapping")
+	if err := j.Parse(template); err != nil {
+		return "", err
+	}
+	value := &bytes.Buffer{}
+	if err := j.Execute(value, resource.Object); err != nil {
+		return "", newResourceNotReadyError(instance, fmt.Errorf("%s %s not ready yet. Unable to resolve %s: %v", resource.GetKind(), resource.GetName(), template, err))
+	}
+	return value.String(), nil
+}
+
+// syncMappedSecret copies the keys of the Secrets referenced by the mapped resource in a Secret owned by the KogitoInfra,
+// keyed by the names of the environment variables
+func syncMappedSecret(cli *client.Client, resource *unstructured.Unstructured, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (string, error) {
+	data := map[string][]byte{}
+	for _, secretEnv := range instance.Spec.Mapping.SecretEnv {
+		secretName, err := evaluateMappingTemplate(secretEnv.SecretName, resource, instance)
+		if err != nil {
+			return "", err
+		}
+		referenced := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: resource.GetNamespace()}}
+		if exists, err := kubernetes.ResourceC(cli).Fetch(referenced); err != nil {
+			if errors.IsForbidden(err) {
+				return "", newMappingForbiddenError("v1", "Secret", secretName, referenced.Namespace)
+			}
+			return "", err
+		} else if !exists {
+			return "", newResourceNotReadyError(instance, fmt.Errorf("secret %s referenced by %s %s not created yet", secretName, resource.GetKind(), resource.GetName()))
+		}
+		value, found := referenced.Data[secretEnv.Key]
+		if !found {
+			return "", newInvalidResourceConfigurationError(fmt.Errorf("key %s not found in secret %s referenced by %s %s", secretEnv.Key, secretName, resource.GetKind(), resource.GetName()))
+		}
+		data[secretEnv.Name] = value
+	}
+
+	secretName := instance.Name + mappedSecretSuffix
+	if err := syncInfraSecret(cli, instance, secretName, data, scheme); err != nil {
+		return "", err
+	}
+	return secretName, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_Reconcile_MappedResource(t *testing.T) {
+	database := &unstructured.Unstructured{}
+	database.SetAPIVersion("db.example.com/v1")
+	database.SetKind("Database")
+	database.SetName("orders")
+	database.SetNamespace("databases")
+	credentials := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "orders-credentials", Namespace: "databases"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "orders-db", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{APIVersion: "db.example.com/v1", Kind: "Database", Name: "orders", Namespace: "databases"},
+			Mapping: &v1alpha1.ResourceMapping{
+				ReadinessPath: `{.status.phase}`,
+				ReadyValue:    "Running",
+				AppProps: []v1alpha1.MappedProperty{
+					{Name: "quarkus.datasource.jdbc.url", Value: "jdbc:postgresql://{.status.host}:{.status.port}/orders"},
+				},
+				Env: []v1alpha1.MappedProperty{{Name: "ENABLE_PERSISTENCE", Value: "true"}},
+				SecretEnv: []v1alpha1.MappedSecretEnvVar{
+					{Name: "QUARKUS_DATASOURCE_PASSWORD", SecretName: "{.status.credentialsSecret}", Key: "password"},
+				},
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, database, credentials).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	// database not ready yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+
+	assert.NoError(t, unstructured.SetNestedMap(database.Object, map[string]interface{}{
+		"phase":             "Running",
+		"host":              "orders.databases.svc",
+		"port":              "5432",
+		"credentialsSecret": credentials.Name,
+	}, "status"))
+	assert.NoError(t, kubernetes.ResourceC(client).Update(database))
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	assert.Equal(t, "jdbc:postgresql://orders.databases.svc:5432/orders", kogitoInfra.Status.AppProps["quarkus.datasource.jdbc.url"])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar("ENABLE_PERSISTENCE", "true"))
+	mappedSecretName := kogitoInfra.Name + mappedSecretSuffix
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar("QUARKUS_DATASOURCE_PASSWORD", mappedSecretName, "QUARKUS_DATASOURCE_PASSWORD"))
+
+	mappedSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: mappedSecretName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, mappedSecret)
+	assert.Equal(t, []byte("secret"), mappedSecret.Data["QUARKUS_DATASOURCE_PASSWORD"])
+}
+
+func Test_Reconcile_MappedResource_InvalidMapping(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "orders-db", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{APIVersion: "db.example.com/v1", Kind: "Database"},
+			Mapping:  &v1alpha1.ResourceMapping{ReadinessPath: `{.status.phase}`},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.InvalidResourceConfiguration, kogitoInfra.Status.Condition.Reason)
+
+	kogitoInfra.Spec.Resource.Name = "orders"
+	kogitoInfra.Spec.Mapping.AppProps = []v1alpha1.MappedProperty{{Name: "quarkus.datasource.jdbc.url", Value: "{.status.host"}}
+	assert.NoError(t, kubernetes.ResourceC(client).Update(kogitoInfra))
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.InvalidResourceConfiguration, kogitoInfra.Status.Condition.Reason)
+}
+
+func Test_Reconcile_MappedResource_NamespaceNotWatched(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "orders-db", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{APIVersion: "db.example.com/v1", Kind: "Database", Name: "orders", Namespace: "databases"},
+			Mapping:  &v1alpha1.ResourceMapping{ReadinessPath: `{.status.phase}`},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+	assert.NoError(t, os.Setenv(k8sutil.WatchNamespaceEnvVar, t.Name()))
+	defer os.Unsetenv(k8sutil.WatchNamespaceEnvVar)
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.InvalidResourceConfiguration, kogitoInfra.Status.Condition.Reason)
+	assert.Contains(t, kogitoInfra.Status.Condition.Message, "doesn't watch namespace databases")
+}
+
+func Test_newMappingForbiddenError(t *testing.T) {
+	err := newMappingForbiddenError("db.example.com/v1", "Database", "orders", "databases")
+	assert.Equal(t, v1alpha1.InvalidResourceConfiguration, reasonForError(err))
+	assert.Contains(t, err.Error(), `get permission on Database of API group "db.example.com"`)
+}
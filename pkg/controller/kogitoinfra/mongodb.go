@@ -0,0 +1,227 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	mongodbv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/mongodb/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// mongoDBCredentialSecretSuffix suffix of the Secret holding the MongoDB connection string used by the Kogito services
+	mongoDBCredentialSecretSuffix = "-mongodb-credential"
+	// mongoDBConnectionStringSecretKey key of the MongoDB connection string in the credential Secret
+	mongoDBConnectionStringSecretKey = "connection-string"
+	// mongoDBPasswordSecretSuffix suffix of the Secret holding the generated password of the default MongoDB user
+	mongoDBPasswordSecretSuffix = "-password"
+	mongoDBPasswordLength       = 16
+
+	// appPropMongoDBDatabase application property for setting the MongoDB database
+	appPropMongoDBDatabase int = iota
+	// envVarMongoDBConnectionString environment variable for setting the MongoDB connection string
+	envVarMongoDBConnectionString
+)
+
+var (
+	//MongoDB variables for the KogitoInfra deployed infrastructure.
+	//For Quarkus: https://quarkus.io/guides/mongodb#configuration-reference
+	//For Spring: https://docs.spring.io/spring-boot/docs/current/reference/html/appendix-application-properties.html#data-properties
+
+	// propertiesMongoDBQuarkus MongoDB properties for quarkus runtime
+	propertiesMongoDBQuarkus = map[int]string{
+		appPropMongoDBDatabase: "quarkus.mongodb.database",
+
+		envVarMongoDBConnectionString: "QUARKUS_MONGODB_CONNECTION_STRING",
+	}
+	// propertiesMongoDBSpring MongoDB properties for spring boot runtime
+	propertiesMongoDBSpring = map[int]string{
+		appPropMongoDBDatabase: "spring.data.mongodb.database",
+
+		envVarMongoDBConnectionString: "SPRING_DATA_MONGODB_URI",
+	}
+)
+
+// getMongoDBAppProps creates the application properties to use the given MongoDB database
+func getMongoDBAppProps(database string) map[string]string {
+	return map[string]string{
+		propertiesMongoDBQuarkus[appPropMongoDBDatabase]: database,
+		propertiesMongoDBSpring[appPropMongoDBDatabase]:  database,
+	}
+}
+
+// getMongoDBEnvVars creates the environment variables enabling the persistence with the connection string stored in the given Secret
+func getMongoDBEnvVars(secretName, connectionStringKey string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		framework.CreateEnvVar(enablePersistenceEnvKey, "true"),
+		framework.CreateSecretEnvVar(propertiesMongoDBSpring[envVarMongoDBConnectionString], secretName, connectionStringKey),
+		framework.CreateSecretEnvVar(propertiesMongoDBQuarkus[envVarMongoDBConnectionString], secretName, connectionStringKey),
+	}
+}
+
+// mongoDBInfraResource implementation of KogitoInfraResource
+type mongoDBInfraResource struct {
+}
+
+// getMongoDBWatchedObjects provide list of object that needs to be watched to maintain MongoDB kogitoInfra resource
+func getMongoDBWatchedObjects() []framework.WatchedObjects {
+	return []framework.WatchedObjects{
+		{
+			GroupVersion: mongodbv1.SchemeGroupVersion,
+			AddToScheme:  mongodbv1.SchemeBuilder.AddToScheme,
+			Objects:      []runtime.Object{&mongodbv1.MongoDB{}},
+		},
+	}
+}
+
+// Reconcile reconcile Kogito infra object
+func (m *mongoDBInfraResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (requeue bool, resultErr error) {
+	var mongoDBInstance *mongodbv1.MongoDB
+
+	if !infrastructure.IsMongoDBAvailable(client) {
+		return false, newResourceAPINotFoundError(&instance.Spec.Resource)
+	}
+
+	if len(instance.Spec.Resource.Name) > 0 {
+		log.Debugf("Custom MongoDB instance reference is provided")
+		namespace := instance.Spec.Resource.Namespace
+		if len(namespace) == 0 {
+			namespace = instance.Namespace
+			log.Debugf("Namespace is not provided for custom resource, taking instance namespace(%s) as default", namespace)
+		}
+		if mongoDBInstance, resultErr = loadDeployedMongoDBInstance(client, instance.Spec.Resource.Name, namespace); resultErr != nil {
+			return false, resultErr
+		} else if mongoDBInstance == nil {
+			return false, newResourceNotFoundError(infrastructure.MongoDBKind, instance.Spec.Resource.Name, namespace)
+		}
+	} else {
+		log.Debugf("Custom MongoDB instance reference is not provided")
+		mongoDBInstance, resultErr = loadDeployedMongoDBInstance(client, infrastructure.MongoDBInstanceName, instance.Namespace)
+		if resultErr != nil {
+			return false, resultErr
+		}
+		if mongoDBInstance == nil {
+			// if not exist then create new MongoDB instance. MongoDB operator creates the replica set, users & service resources
+			if resultErr = createNewMongoDBInstance(client, infrastructure.MongoDBInstanceName, instance.Namespace, instance, scheme); resultErr != nil {
+				return false, resultErr
+			}
+			return true, nil
+		}
+	}
+	if mongoDBInstance.Status.Phase != mongodbv1.MongoDBPhaseRunning {
+		return false, newResourceNotReadyError(instance, fmt.Errorf("MongoDB instance %s not ready yet. Waiting for phase %s", mongoDBInstance.Name, mongodbv1.MongoDBPhaseRunning))
+	}
+	user, resultErr := infrastructure.GetMongoDBUser(mongoDBInstance)
+	if resultErr != nil {
+		return false, newResourceNotReadyError(instance, resultErr)
+	}
+	secretName, resultErr := syncMongoDBCredentialSecret(client, mongoDBInstance, user, instance, scheme)
+	if resultErr != nil {
+		return false, resultErr
+	}
+	instance.Status.AppProps = getMongoDBAppProps(infrastructure.GetMongoDBDatabase(user))
+	instance.Status.Env = getMongoDBEnvVars(secretName, mongoDBConnectionStringSecretKey)
+	return false, nil
+}
+
+func loadDeployedMongoDBInstance(cli *client.Client, name string, namespace string) (*mongodbv1.MongoDB, error) {
+	log.Debug("fetching deployed kogito MongoDB instance")
+	mongoDBInstance := &mongodbv1.MongoDB{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: name, Namespace: namespace}, mongoDBInstance); err != nil {
+		log.Error("Error occurs while fetching kogito MongoDB instance")
+		return nil, err
+	} else if !exists {
+		log.Debug("Kogito MongoDB instance does not exist")
+		return nil, nil
+	} else {
+		log.Debug("Kogito MongoDB instance found")
+		return mongoDBInstance, nil
+	}
+}
+
+func createNewMongoDBInstance(cli *client.Client, name, namespace string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+	log.Debug("Going to create kogito MongoDB instance")
+	passwordSecretName, err := createMongoDBPasswordSecret(cli, name+mongoDBPasswordSecretSuffix, namespace, instance, scheme)
+	if err != nil {
+		return err
+	}
+	mongoDBInstance := infrastructure.GetMongoDBDefaultResource(name, namespace, passwordSecretName)
+	if err := framework.SetOwner(instance, scheme, mongoDBInstance); err != nil {
+		return err
+	}
+	if err := kubernetes.ResourceC(cli).Create(mongoDBInstance); err != nil {
+		log.Error("Error occurs while creating kogito MongoDB instance")
+		return err
+	}
+	log.Debug("Kogito MongoDB instance created successfully")
+	return nil
+}
+
+// createMongoDBPasswordSecret creates the Secret holding a generated password for the default MongoDB user, unless it already exists
+func createMongoDBPasswordSecret(cli *client.Client, name, namespace string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (string, error) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	if exists, err := kubernetes.ResourceC(cli).Fetch(secret); err != nil {
+		return "", err
+	} else if exists {
+		return secret.Name, nil
+	}
+	password, err := util.GeneratePassword(mongoDBPasswordLength)
+	if err != nil {
+		return "", err
+	}
+	log.Debugf("Creating new secret %s", secret.Name)
+	secret.Type = corev1.SecretTypeOpaque
+	secret.Data = map[string][]byte{infrastructure.MongoDBSecretPasswordKey: []byte(password)}
+	if err := kubernetes.ResourceC(cli).CreateForOwner(secret, instance, scheme); err != nil {
+		return "", err
+	}
+	return secret.Name, nil
+}
+
+// syncMongoDBCredentialSecret builds the connection string from the MongoDB replica set status and the user password
+// and stores it in a Secret in the KogitoInfra namespace, since the replica set might be deployed in another namespace.
+func syncMongoDBCredentialSecret(cli *client.Client, mongoDBInstance *mongodbv1.MongoDB, user *mongodbv1.MongoDBUser, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (string, error) {
+	passwordSecret := &corev1.Secret{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: user.PasswordSecretRef.Name, Namespace: mongoDBInstance.Namespace}, passwordSecret); err != nil {
+		return "", err
+	} else if !exists {
+		return "", newResourceNotFoundError("Secret", user.PasswordSecretRef.Name, mongoDBInstance.Namespace)
+	}
+	passwordKey := user.PasswordSecretRef.Key
+	if len(passwordKey) == 0 {
+		passwordKey = infrastructure.MongoDBSecretPasswordKey
+	}
+	connectionString, err := infrastructure.GetMongoDBConnectionString(mongoDBInstance, user, string(passwordSecret.Data[passwordKey]))
+	if err != nil {
+		return "", newResourceNotReadyError(instance, err)
+	}
+	data := map[string][]byte{mongoDBConnectionStringSecretKey: []byte(connectionString)}
+
+	secretName := instance.Name + mongoDBCredentialSecretSuffix
+	if err := syncInfraSecret(cli, instance, secretName, data, scheme); err != nil {
+		return "", err
+	}
+	return secretName, nil
+}
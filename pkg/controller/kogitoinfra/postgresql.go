@@ -0,0 +1,207 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	postgresqlv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/postgresql/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// postgresqlCredentialSecretSuffix suffix of the Secret holding the PostgreSQL credentials used by the Kogito services
+	postgresqlCredentialSecretSuffix = "-postgresql-credential"
+
+	// appPropPostgresqlDBKind application property for setting the datasource database kind
+	appPropPostgresqlDBKind int = iota
+	// appPropPostgresqlReactiveURL application property for setting the reactive datasource URL, only required by Quarkus
+	appPropPostgresqlReactiveURL
+	// appPropPostgresqlJdbcURL application property for setting the JDBC datasource URL
+	appPropPostgresqlJdbcURL
+	// envVarPostgresqlUser environment variable for setting the datasource username
+	envVarPostgresqlUser
+	// envVarPostgresqlPassword environment variable for setting the datasource password
+	envVarPostgresqlPassword
+
+	postgresqlDBKind = "postgresql"
+)
+
+var (
+	//PostgreSQL variables for the KogitoInfra deployed infrastructure.
+	//For Quarkus: https://quarkus.io/guides/datasource#configuration-reference
+	//For Spring: https://docs.spring.io/spring-boot/docs/current/reference/html/appendix-application-properties.html#data-properties
+
+	// propertiesPostgresqlQuarkus PostgreSQL properties for quarkus runtime
+	propertiesPostgresqlQuarkus = map[int]string{
+		appPropPostgresqlDBKind:      "quarkus.datasource.db-kind",
+		appPropPostgresqlReactiveURL: "quarkus.datasource.reactive.url",
+		appPropPostgresqlJdbcURL:     "quarkus.datasource.jdbc.url",
+
+		envVarPostgresqlUser:     "QUARKUS_DATASOURCE_USERNAME",
+		envVarPostgresqlPassword: "QUARKUS_DATASOURCE_PASSWORD",
+	}
+	// propertiesPostgresqlSpring PostgreSQL properties for spring boot runtime
+	propertiesPostgresqlSpring = map[int]string{
+		appPropPostgresqlJdbcURL: "spring.datasource.url",
+
+		envVarPostgresqlUser:     "SPRING_DATASOURCE_USERNAME",
+		envVarPostgresqlPassword: "SPRING_DATASOURCE_PASSWORD",
+	}
+)
+
+// getPostgresqlAppProps creates the application properties to connect to the given PostgreSQL database, where uri is host:port
+func getPostgresqlAppProps(uri, database string) map[string]string {
+	return map[string]string{
+		propertiesPostgresqlQuarkus[appPropPostgresqlDBKind]:      postgresqlDBKind,
+		propertiesPostgresqlQuarkus[appPropPostgresqlReactiveURL]: fmt.Sprintf("postgresql://%s/%s", uri, database),
+		propertiesPostgresqlQuarkus[appPropPostgresqlJdbcURL]:     fmt.Sprintf("jdbc:postgresql://%s/%s", uri, database),
+		propertiesPostgresqlSpring[appPropPostgresqlJdbcURL]:      fmt.Sprintf("jdbc:postgresql://%s/%s", uri, database),
+	}
+}
+
+// getPostgresqlEnvVars creates the environment variables enabling the persistence with the credentials stored in the given Secret
+func getPostgresqlEnvVars(secretName, usernameKey, passwordKey string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		framework.CreateEnvVar(enablePersistenceEnvKey, "true"),
+		framework.CreateSecretEnvVar(propertiesPostgresqlSpring[envVarPostgresqlUser], secretName, usernameKey),
+		framework.CreateSecretEnvVar(propertiesPostgresqlQuarkus[envVarPostgresqlUser], secretName, usernameKey),
+		framework.CreateSecretEnvVar(propertiesPostgresqlSpring[envVarPostgresqlPassword], secretName, passwordKey),
+		framework.CreateSecretEnvVar(propertiesPostgresqlQuarkus[envVarPostgresqlPassword], secretName, passwordKey),
+	}
+}
+
+// postgresqlInfraResource implementation of KogitoInfraResource
+type postgresqlInfraResource struct {
+}
+
+// getPostgresqlWatchedObjects provide list of object that needs to be watched to maintain PostgreSQL kogitoInfra resource
+func getPostgresqlWatchedObjects() []framework.WatchedObjects {
+	return []framework.WatchedObjects{
+		{
+			GroupVersion: postgresqlv1.SchemeGroupVersion,
+			AddToScheme:  postgresqlv1.SchemeBuilder.AddToScheme,
+			Objects:      []runtime.Object{&postgresqlv1.Postgresql{}},
+		},
+	}
+}
+
+// Reconcile reconcile Kogito infra object
+func (p *postgresqlInfraResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (requeue bool, resultErr error) {
+	var postgresqlInstance *postgresqlv1.Postgresql
+
+	if !infrastructure.IsPostgresqlAvailable(client) {
+		return false, newResourceAPINotFoundError(&instance.Spec.Resource)
+	}
+
+	if len(instance.Spec.Resource.Name) > 0 {
+		log.Debugf("Custom PostgreSQL instance reference is provided")
+		namespace := instance.Spec.Resource.Namespace
+		if len(namespace) == 0 {
+			namespace = instance.Namespace
+			log.Debugf("Namespace is not provided for custom resource, taking instance namespace(%s) as default", namespace)
+		}
+		if postgresqlInstance, resultErr = loadDeployedPostgresqlInstance(client, instance.Spec.Resource.Name, namespace); resultErr != nil {
+			return false, resultErr
+		} else if postgresqlInstance == nil {
+			return false, newResourceNotFoundError(infrastructure.PostgresqlKind, instance.Spec.Resource.Name, namespace)
+		}
+	} else {
+		log.Debugf("Custom PostgreSQL instance reference is not provided")
+		postgresqlInstance, resultErr = loadDeployedPostgresqlInstance(client, infrastructure.PostgresqlInstanceName, instance.Namespace)
+		if resultErr != nil {
+			return false, resultErr
+		}
+		if postgresqlInstance == nil {
+			// if not exist then create new PostgreSQL instance. PostgreSQL operator creates the cluster, credential secrets & service resources
+			if resultErr = createNewPostgresqlInstance(client, infrastructure.PostgresqlInstanceName, instance.Namespace, instance, scheme); resultErr != nil {
+				return false, resultErr
+			}
+			return true, nil
+		}
+	}
+	if postgresqlInstance.Status.PostgresClusterStatus != postgresqlv1.PostgresqlClusterStatusRunning {
+		return false, newResourceNotReadyError(instance, fmt.Errorf("PostgreSQL instance %s not ready yet. Waiting for status %s", postgresqlInstance.Name, postgresqlv1.PostgresqlClusterStatusRunning))
+	}
+	database, owner, resultErr := infrastructure.GetPostgresqlDatabase(postgresqlInstance)
+	if resultErr != nil {
+		return false, newResourceNotReadyError(instance, resultErr)
+	}
+	secretName, resultErr := syncPostgresqlCredentialSecret(client, postgresqlInstance, owner, instance, scheme)
+	if resultErr != nil {
+		return false, resultErr
+	}
+	instance.Status.AppProps = getPostgresqlAppProps(infrastructure.GetPostgresqlServiceURI(postgresqlInstance), database)
+	instance.Status.Env = getPostgresqlEnvVars(secretName, infrastructure.PostgresqlSecretUsernameKey, infrastructure.PostgresqlSecretPasswordKey)
+	return false, nil
+}
+
+func loadDeployedPostgresqlInstance(cli *client.Client, name string, namespace string) (*postgresqlv1.Postgresql, error) {
+	log.Debug("fetching deployed kogito PostgreSQL instance")
+	postgresqlInstance := &postgresqlv1.Postgresql{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: name, Namespace: namespace}, postgresqlInstance); err != nil {
+		log.Error("Error occurs while fetching kogito PostgreSQL instance")
+		return nil, err
+	} else if !exists {
+		log.Debug("Kogito PostgreSQL instance does not exist")
+		return nil, nil
+	} else {
+		log.Debug("Kogito PostgreSQL instance found")
+		return postgresqlInstance, nil
+	}
+}
+
+func createNewPostgresqlInstance(cli *client.Client, name, namespace string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+	log.Debug("Going to create kogito PostgreSQL instance")
+	postgresqlInstance := infrastructure.GetPostgresqlDefaultResource(name, namespace)
+	if err := framework.SetOwner(instance, scheme, postgresqlInstance); err != nil {
+		return err
+	}
+	if err := kubernetes.ResourceC(cli).Create(postgresqlInstance); err != nil {
+		log.Error("Error occurs while creating kogito PostgreSQL instance")
+		return err
+	}
+	log.Debug("Kogito PostgreSQL instance created successfully")
+	return nil
+}
+
+// syncPostgresqlCredentialSecret copies the credentials of the database owner generated by the PostgreSQL operator
+// to a Secret in the KogitoInfra namespace, since the PostgreSQL cluster might be deployed in another namespace.
+func syncPostgresqlCredentialSecret(cli *client.Client, postgresqlInstance *postgresqlv1.Postgresql, owner string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (string, error) {
+	operatorSecretName := infrastructure.GetPostgresqlCredentialSecretName(postgresqlInstance, owner)
+	operatorSecret := &corev1.Secret{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: operatorSecretName, Namespace: postgresqlInstance.Namespace}, operatorSecret); err != nil {
+		return "", err
+	} else if !exists {
+		return "", newResourceNotReadyError(instance, fmt.Errorf("PostgreSQL credential secret %s not created yet", operatorSecretName))
+	}
+	data := map[string][]byte{
+		infrastructure.PostgresqlSecretUsernameKey: operatorSecret.Data[infrastructure.PostgresqlSecretUsernameKey],
+		infrastructure.PostgresqlSecretPasswordKey: operatorSecret.Data[infrastructure.PostgresqlSecretPasswordKey],
+	}
+
+	secretName := instance.Name + postgresqlCredentialSecretSuffix
+	if err := syncInfraSecret(cli, instance, secretName, data, scheme); err != nil {
+		return "", err
+	}
+	return secretName, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	postgresqlv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/postgresql/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Reconcile_PostgresqlResource_CreateDefault(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-postgresql", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.PostgresqlAPIVersion,
+				Kind:       infrastructure.PostgresqlKind,
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	postgresql := &postgresqlv1.Postgresql{ObjectMeta: v1.ObjectMeta{Name: infrastructure.PostgresqlInstanceName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, postgresql)
+	assert.Equal(t, map[string]string{"kogito": "kogito"}, postgresql.Spec.Databases)
+
+	// cluster not running yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+}
+
+func Test_Reconcile_PostgresqlResource(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "my-postgresql-infra", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.PostgresqlAPIVersion,
+				Kind:       infrastructure.PostgresqlKind,
+				Name:       "acid-processes",
+				Namespace:  "databases",
+			},
+		},
+	}
+	postgresql := &postgresqlv1.Postgresql{
+		ObjectMeta: v1.ObjectMeta{Name: "acid-processes", Namespace: "databases"},
+		Spec: postgresqlv1.PostgresqlSpec{
+			TeamID:    "acid",
+			Databases: map[string]string{"processes": "process_owner"},
+		},
+		Status: postgresqlv1.PostgresqlStatus{PostgresClusterStatus: postgresqlv1.PostgresqlClusterStatusRunning},
+	}
+	operatorSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "process-owner.acid-processes.credentials.postgresql.acid.zalan.do", Namespace: "databases"},
+		Data:       map[string][]byte{"username": []byte("process_owner"), "password": []byte("secret")},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, postgresql, operatorSecret).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+	assert.Equal(t, "postgresql://acid-processes.databases:5432/processes", kogitoInfra.Status.AppProps[propertiesPostgresqlQuarkus[appPropPostgresqlReactiveURL]])
+	assert.Equal(t, "jdbc:postgresql://acid-processes.databases:5432/processes", kogitoInfra.Status.AppProps[propertiesPostgresqlSpring[appPropPostgresqlJdbcURL]])
+
+	// credentials are copied in the KogitoInfra namespace
+	secret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: "my-postgresql-infra-postgresql-credential", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, secret)
+	assert.Equal(t, []byte("secret"), secret.Data["password"])
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateSecretEnvVar(propertiesPostgresqlQuarkus[envVarPostgresqlPassword], secret.Name, "password"))
+	assert.Contains(t, kogitoInfra.Status.Env, framework.CreateEnvVar(enablePersistenceEnvKey, "true"))
+
+	// rotated credentials are synced
+	operatorSecret.Data["password"] = []byte("rotated")
+	assert.NoError(t, kubernetes.ResourceC(client).Update(operatorSecret))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	test.AssertFetchMustExist(t, client, secret)
+	assert.Equal(t, []byte("rotated"), secret.Data["password"])
+}
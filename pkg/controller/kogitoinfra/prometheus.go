@@ -0,0 +1,104 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+
+	monv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// prometheusInfraResource for Prometheus resources reconciliation
+type prometheusInfraResource struct{}
+
+// getPrometheusWatchedObjects provide list of object that needs to be watched to maintain Prometheus kogitoInfra resource
+func getPrometheusWatchedObjects() []framework.WatchedObjects {
+	return []framework.WatchedObjects{
+		{
+			GroupVersion: monv1.SchemeGroupVersion,
+			AddToScheme:  monv1.SchemeBuilder.AddToScheme,
+			Objects:      []runtime.Object{&monv1.Prometheus{}},
+		},
+	}
+}
+
+// Reconcile reconcile Kogito infra object
+func (p *prometheusInfraResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) (requeue bool, resultErr error) {
+	var prometheus *monv1.Prometheus
+
+	if !infrastructure.IsPrometheusAvailable(client) {
+		return false, newResourceAPINotFoundError(&instance.Spec.Resource)
+	}
+
+	resource := getResolvedResource(instance)
+	if prometheus, resultErr = loadDeployedPrometheus(client, resource.Name, resource.Namespace); resultErr != nil {
+		return false, resultErr
+	}
+	if prometheus == nil {
+		if len(instance.Spec.Resource.Name) > 0 {
+			return false, newResourceNotFoundError(infrastructure.PrometheusKind, resource.Name, resource.Namespace)
+		}
+		log.Debugf("Prometheus reference is not provided")
+		if resultErr = createNewPrometheus(client, resource.Name, resource.Namespace, instance, scheme); resultErr != nil {
+			return false, resultErr
+		}
+		return true, nil
+	}
+	if !infrastructure.IsPrometheusReady(prometheus) {
+		return false, newResourceNotReadyError(instance, fmt.Errorf("Prometheus %s not ready yet", prometheus.Name))
+	}
+	return false, nil
+}
+
+func loadDeployedPrometheus(cli *client.Client, name, namespace string) (*monv1.Prometheus, error) {
+	log.Debug("fetching deployed Prometheus")
+	prometheus := &monv1.Prometheus{}
+	if exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: name, Namespace: namespace}, prometheus); err != nil {
+		log.Error("Error occurs while fetching Prometheus")
+		return nil, err
+	} else if !exists {
+		log.Debug("Prometheus does not exist")
+		return nil, nil
+	} else {
+		log.Debug("Prometheus found")
+		return prometheus, nil
+	}
+}
+
+func createNewPrometheus(cli *client.Client, name, namespace string, instance *v1alpha1.KogitoInfra, scheme *runtime.Scheme) error {
+	log.Debug("Going to create Prometheus")
+	serviceAccount, role, roleBinding := infrastructure.GetPrometheusDefaultRBACResources(name, namespace)
+	for _, resource := range []meta.ResourceObject{serviceAccount, role, roleBinding} {
+		if _, err := kubernetes.ResourceC(cli).CreateIfNotExistsForOwner(resource, instance, scheme); err != nil {
+			log.Errorf("Error occurs while creating the permissions of Prometheus %s", name)
+			return err
+		}
+	}
+	prometheus := infrastructure.GetPrometheusDefaultResource(name, namespace)
+	if err := kubernetes.ResourceC(cli).CreateForOwner(prometheus, instance, scheme); err != nil {
+		log.Error("Error occurs while creating Prometheus")
+		return err
+	}
+	log.Debug("Prometheus created successfully")
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	monv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Reconcile_PrometheusResource_CreateDefault(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-prometheus", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.PrometheusAPIVersion,
+				Kind:       infrastructure.PrometheusKind,
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).SupportPrometheus().Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	prometheus := &monv1.Prometheus{ObjectMeta: v1.ObjectMeta{Name: infrastructure.PrometheusInstanceName, Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, prometheus)
+	assert.Equal(t, infrastructure.GetMonitoringLabels(), prometheus.Spec.ServiceMonitorSelector.MatchLabels)
+	assert.Equal(t, infrastructure.GetMonitoringLabels(), prometheus.Spec.RuleSelector.MatchLabels)
+	assert.Equal(t, infrastructure.PrometheusInstanceName, prometheus.Spec.ServiceAccountName)
+	test.AssertFetchMustExist(t, client, &corev1.ServiceAccount{ObjectMeta: v1.ObjectMeta{Name: infrastructure.PrometheusInstanceName, Namespace: t.Name()}})
+	test.AssertFetchMustExist(t, client, &rbacv1.Role{ObjectMeta: v1.ObjectMeta{Name: infrastructure.PrometheusInstanceName, Namespace: t.Name()}})
+	test.AssertFetchMustExist(t, client, &rbacv1.RoleBinding{ObjectMeta: v1.ObjectMeta{Name: infrastructure.PrometheusInstanceName, Namespace: t.Name()}})
+
+	// prometheus not ready yet
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceNotReady, kogitoInfra.Status.Condition.Reason)
+
+	prometheus.Status = &monv1.PrometheusStatus{Replicas: 1, AvailableReplicas: 1}
+	assert.NoError(t, kubernetes.ResourceC(client).Update(prometheus))
+	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
+	_, err = kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.SuccessInfraConditionType, kogitoInfra.Status.Condition.Type)
+}
+
+func Test_Reconcile_PrometheusResource_APINotAvailable(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-prometheus", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.PrometheusAPIVersion,
+				Kind:       infrastructure.PrometheusKind,
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	r := &ReconcileKogitoInfra{client: client, scheme: meta.GetRegisteredSchema()}
+
+	test.AssertReconcile(t, r, kogitoInfra)
+	_, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ResourceAPINotFound, kogitoInfra.Status.Condition.Reason)
+}
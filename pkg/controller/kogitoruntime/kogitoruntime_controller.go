@@ -83,6 +83,11 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		{
 			Objects: []runtime.Object{&corev1.Service{}, &appsv1.Deployment{}, &corev1.ConfigMap{}},
 		},
+		{
+			Objects:      []runtime.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
+			EventHandler: services.NewReferencedObjectsEventHandler(r.(*ReconcileKogitoRuntime).client, "KogitoRuntime"),
+			Predicate:    services.ReferencedObjectsPredicate,
+		},
 	}
 	controllerWatcher := framework.NewControllerWatcher(r.(*ReconcileKogitoRuntime).client, mgr, c, &appv1alpha1.KogitoRuntime{})
 	if err = controllerWatcher.Watch(watchedObjects...); err != nil {
@@ -33,10 +33,11 @@ type ExplainabilitySupportingServiceResource struct {
 func (*ExplainabilitySupportingServiceResource) Reconcile(client *client.Client, instance *v1alpha1.KogitoSupportingService, scheme *runtime.Scheme) (reconcileAfter time.Duration, err error) {
 	log.Info("Reconciling KogitoExplainability")
 	definition := services.ServiceDefinition{
-		DefaultImageName: infrastructure.DefaultExplainabilityImageName,
-		Request:          controller.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}},
-		KafkaTopics:      explainabilitykafkaTopics,
-		HealthCheckProbe: services.QuarkusHealthCheckProbe,
+		DefaultImageName:    infrastructure.DefaultExplainabilityImageName,
+		Request:             controller.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}},
+		KafkaTopics:         explainabilitykafkaTopics,
+		ProducedKafkaTopics: explainabilityProducedKafkaTopics,
+		HealthCheckProbe:    services.QuarkusHealthCheckProbe,
 	}
 	return services.NewServiceDeployer(definition, instance, client, scheme).Deploy()
 }
@@ -44,5 +45,9 @@ func (*ExplainabilitySupportingServiceResource) Reconcile(client *client.Client,
 // Collection of kafka topics that should be handled by the Explainability service
 var explainabilitykafkaTopics = []string{
 	"trusty-explainability-request",
+}
+
+// Collection of kafka topics that should be handled by the Explainability service, produced by it
+var explainabilityProducedKafkaTopics = []string{
 	"trusty-explainability-result",
 }
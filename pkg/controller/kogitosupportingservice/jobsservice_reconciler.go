@@ -38,17 +38,17 @@ func (*JobsServiceSupportingServiceResource) Reconcile(client *client.Client, in
 		return
 	}
 	definition := services.ServiceDefinition{
-		DefaultImageName: infrastructure.DefaultJobsServiceImageName,
-		Request:          controller.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}},
-		SingleReplica:    true,
-		HealthCheckProbe: services.QuarkusHealthCheckProbe,
-		KafkaTopics:      jobsServicekafkaTopics,
+		DefaultImageName:    infrastructure.DefaultJobsServiceImageName,
+		Request:             controller.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}},
+		SingleReplica:       true,
+		HealthCheckProbe:    services.QuarkusHealthCheckProbe,
+		ProducedKafkaTopics: jobsServicekafkaTopics,
 	}
 
 	return services.NewServiceDeployer(definition, instance, client, scheme).Deploy()
 }
 
-// Collection of kafka topics that should be handled by the Jobs service
+// Collection of kafka topics that should be handled by the Jobs service, produced by it
 var jobsServicekafkaTopics = []string{
 	"kogito-job-service-job-status-events",
 }
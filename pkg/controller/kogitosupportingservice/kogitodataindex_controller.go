@@ -133,6 +133,11 @@ func addDataIndex(mgr manager.Manager, r reconcile.Reconciler) error {
 			},
 		},
 		{Objects: []runtime.Object{&corev1.Service{}, &appsv1.Deployment{}, &corev1.ConfigMap{}}},
+		{
+			Objects:      []runtime.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
+			EventHandler: services.NewReferencedObjectsEventHandler(r.(*ReconcileKogitoDataIndex).client, "KogitoSupportingService"),
+			Predicate:    services.ReferencedObjectsPredicate,
+		},
 	}
 	if err = controllerWatcher.Watch(watchedObjects...); err != nil {
 		return err
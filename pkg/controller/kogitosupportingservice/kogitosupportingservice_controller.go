@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure/services"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/logger"
 	imgv1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
@@ -108,6 +109,11 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 			},
 		},
 		{Objects: []runtime.Object{&corev1.Service{}, &appsv1.Deployment{}, &corev1.ConfigMap{}}},
+		{
+			Objects:      []runtime.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
+			EventHandler: services.NewReferencedObjectsEventHandler(r.(*ReconcileKogitoSupportingService).client, "KogitoSupportingService"),
+			Predicate:    services.ReferencedObjectsPredicate,
+		},
 	}
 	if err = controllerWatcher.Watch(watchedObjects...); err != nil {
 		return err
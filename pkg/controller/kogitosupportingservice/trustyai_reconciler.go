@@ -37,10 +37,11 @@ func (*TrustyAISupportingServiceResource) Reconcile(client *client.Client, insta
 		return
 	}
 	definition := services.ServiceDefinition{
-		DefaultImageName: infrastructure.DefaultTrustyImageName,
-		Request:          controller.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}},
-		KafkaTopics:      trustyAiKafkaTopics,
-		HealthCheckProbe: services.QuarkusHealthCheckProbe,
+		DefaultImageName:    infrastructure.DefaultTrustyImageName,
+		Request:             controller.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}},
+		KafkaTopics:         trustyAiKafkaTopics,
+		ProducedKafkaTopics: trustyAiProducedKafkaTopics,
+		HealthCheckProbe:    services.QuarkusHealthCheckProbe,
 	}
 	return services.NewServiceDeployer(definition, instance, client, scheme).Deploy()
 }
@@ -50,5 +51,9 @@ var trustyAiKafkaTopics = []string{
 	"kogito-tracing-decision",
 	"kogito-tracing-model",
 	"trusty-explainability-result",
+}
+
+// Collection of kafka topics that should be handled by the Trusty service, produced by it
+var trustyAiProducedKafkaTopics = []string{
 	"trusty-explainability-request",
 }
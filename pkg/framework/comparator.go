@@ -18,6 +18,7 @@ import (
 	"github.com/RHsyseng/operator-utils/pkg/resource"
 	"github.com/RHsyseng/operator-utils/pkg/resource/compare"
 	monv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	grafanav1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
 	appsv1 "github.com/openshift/api/apps/v1"
 	buildv1 "github.com/openshift/api/build/v1"
@@ -116,6 +117,19 @@ func containAllLabels(deployed resource.KubernetesResource, requested resource.K
 	return true
 }
 
+func containAllAnnotations(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+	deployedAnnotations := deployed.GetAnnotations()
+	requestedAnnotations := requested.GetAnnotations()
+
+	for key, value := range requestedAnnotations {
+		if deployedAnnotations[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
 // CreateDeploymentConfigComparator creates a new comparator for DeploymentConfig using Trigger and RollingParams
 func CreateDeploymentConfigComparator() func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
 	return func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
@@ -223,7 +237,7 @@ func CreateConfigMapComparator() func(deployed resource.KubernetesResource, requ
 		cmDeployed := deployed.(*v1.ConfigMap)
 		cmRequested := requested.(*v1.ConfigMap).DeepCopy()
 
-		if !containAllLabels(cmDeployed, cmRequested) {
+		if !containAllLabels(cmDeployed, cmRequested) || !containAllAnnotations(cmDeployed, cmRequested) {
 			return false
 		}
 
@@ -287,12 +301,32 @@ func sortContainersByName(pod *v1.PodTemplateSpec) {
 	})
 }
 
-// CreateServiceMonitorComparator creates a new comparator for ServiceMonitor using Label
+// CreateServiceMonitorComparator creates a new comparator for ServiceMonitor using Label and the scrape configuration
 func CreateServiceMonitorComparator() func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
 	return func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
 		smDeployed := deployed.(*monv1.ServiceMonitor)
 		smRequested := requested.(*monv1.ServiceMonitor).DeepCopy()
 
-		return containAllLabels(smDeployed, smRequested)
+		if !containAllLabels(smDeployed, smRequested) {
+			return false
+		}
+
+		return reflect.DeepEqual(smDeployed.Spec, smRequested.Spec)
+	}
+}
+
+// CreateGrafanaDashboardComparator creates a new comparator for GrafanaDashboard using Label and the dashboard definition
+func CreateGrafanaDashboardComparator() func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+	return func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+		dashboardDeployed := deployed.(*grafanav1.GrafanaDashboard)
+		dashboardRequested := requested.(*grafanav1.GrafanaDashboard).DeepCopy()
+
+		if !containAllLabels(dashboardDeployed, dashboardRequested) {
+			return false
+		}
+
+		return dashboardDeployed.Spec.Name == dashboardRequested.Spec.Name &&
+			dashboardDeployed.Spec.Json == dashboardRequested.Spec.Json &&
+			reflect.DeepEqual(dashboardDeployed.Spec.ConfigMapRef, dashboardRequested.Spec.ConfigMapRef)
 	}
 }
@@ -16,6 +16,8 @@ package framework
 
 import (
 	"github.com/RHsyseng/operator-utils/pkg/resource"
+	monv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	grafanav1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
 	appsv1 "github.com/openshift/api/apps/v1"
 	buildv1 "github.com/openshift/api/build/v1"
 	routev1 "github.com/openshift/api/route/v1"
@@ -886,3 +888,141 @@ func Test_CreateServiceComparator(t *testing.T) {
 		})
 	}
 }
+
+func Test_CreateGrafanaDashboardComparator(t *testing.T) {
+	type args struct {
+		deployed  resource.KubernetesResource
+		requested resource.KubernetesResource
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  reflect.Type
+		want1 bool
+	}{
+		{
+			"Equals",
+			args{
+				deployed: &grafanav1.GrafanaDashboard{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec:       grafanav1.GrafanaDashboardSpec{Name: "dashboard.json", Json: "{}"},
+				},
+				requested: &grafanav1.GrafanaDashboard{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec:       grafanav1.GrafanaDashboardSpec{Name: "dashboard.json", Json: "{}"},
+				},
+			},
+			reflect.TypeOf(grafanav1.GrafanaDashboard{}),
+			true,
+		},
+		{
+			"DifferentJson",
+			args{
+				deployed: &grafanav1.GrafanaDashboard{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec:       grafanav1.GrafanaDashboardSpec{Name: "dashboard.json", Json: "{}"},
+				},
+				requested: &grafanav1.GrafanaDashboard{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec:       grafanav1.GrafanaDashboardSpec{Name: "dashboard.json", Json: `{"title": "new"}`},
+				},
+			},
+			reflect.TypeOf(grafanav1.GrafanaDashboard{}),
+			false,
+		},
+		{
+			"DifferentConfigMapRef",
+			args{
+				deployed: &grafanav1.GrafanaDashboard{
+					Spec: grafanav1.GrafanaDashboardSpec{
+						Name:         "dashboard.json",
+						ConfigMapRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "dashboards"}, Key: "dashboard.json"},
+					},
+				},
+				requested: &grafanav1.GrafanaDashboard{
+					Spec: grafanav1.GrafanaDashboardSpec{
+						Name:         "dashboard.json",
+						ConfigMapRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "my-dashboards"}, Key: "dashboard.json"},
+					},
+				},
+			},
+			reflect.TypeOf(grafanav1.GrafanaDashboard{}),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 :=
+				NewComparatorBuilder().
+					WithType(tt.want).
+					UseDefaultComparator().
+					WithCustomComparator(CreateGrafanaDashboardComparator()).
+					Build()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("createGrafanaDashboardComparator() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1(tt.args.deployed, tt.args.requested), tt.want1) {
+				t.Errorf("createGrafanaDashboardComparator() got1 = %v, want %v", got1(tt.args.deployed, tt.args.requested), tt.want1)
+			}
+		})
+	}
+}
+
+func Test_CreateServiceMonitorComparator(t *testing.T) {
+	type args struct {
+		deployed  resource.KubernetesResource
+		requested resource.KubernetesResource
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  reflect.Type
+		want1 bool
+	}{
+		{
+			"Equals",
+			args{
+				deployed: &monv1.ServiceMonitor{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec:       monv1.ServiceMonitorSpec{Endpoints: []monv1.Endpoint{{Path: "/metrics", Interval: "30s"}}},
+				},
+				requested: &monv1.ServiceMonitor{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec:       monv1.ServiceMonitorSpec{Endpoints: []monv1.Endpoint{{Path: "/metrics", Interval: "30s"}}},
+				},
+			},
+			reflect.TypeOf(monv1.ServiceMonitor{}),
+			true,
+		},
+		{
+			"DifferentEndpoint",
+			args{
+				deployed: &monv1.ServiceMonitor{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec:       monv1.ServiceMonitorSpec{Endpoints: []monv1.Endpoint{{Path: "/metrics"}}},
+				},
+				requested: &monv1.ServiceMonitor{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec:       monv1.ServiceMonitorSpec{Endpoints: []monv1.Endpoint{{Path: "/metrics", Interval: "30s"}}},
+				},
+			},
+			reflect.TypeOf(monv1.ServiceMonitor{}),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 :=
+				NewComparatorBuilder().
+					WithType(tt.want).
+					WithCustomComparator(CreateServiceMonitorComparator()).
+					Build()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("createServiceMonitorComparator() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1(tt.args.deployed, tt.args.requested), tt.want1) {
+				t.Errorf("createServiceMonitorComparator() got1 = %v, want %v", got1(tt.args.deployed, tt.args.requested), tt.want1)
+			}
+		})
+	}
+}
@@ -116,6 +116,16 @@ func CreateSecretEnvVar(variableName, secretName, secretKey string) corev1.EnvVa
 	}
 }
 
+// CreateFieldRefEnvVar will create EnvVar value to hold the given field of the pod, for example metadata.labels['app']
+func CreateFieldRefEnvVar(variableName, fieldPath string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: variableName,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: fieldPath},
+		},
+	}
+}
+
 // DiffEnvVar returns elements in `env1` that are not in `env2`
 func DiffEnvVar(env1 []corev1.EnvVar, env2 []corev1.EnvVar) []corev1.EnvVar {
 	eMap := make(map[string]corev1.EnvVar, len(env2))
@@ -82,6 +82,12 @@ func Test_CreateSecretEnvVar(t *testing.T) {
 	assert.Equal(t, "name", envVar.ValueFrom.SecretKeyRef.LocalObjectReference.Name)
 }
 
+func Test_CreateFieldRefEnvVar(t *testing.T) {
+	envVar := CreateFieldRefEnvVar("var", "metadata.labels['app']")
+	assert.Equal(t, "var", envVar.Name)
+	assert.Equal(t, "metadata.labels['app']", envVar.ValueFrom.FieldRef.FieldPath)
+}
+
 func TestDiffEnvVar(t *testing.T) {
 	type args struct {
 		env1 []corev1.EnvVar
@@ -38,6 +38,12 @@ const (
 	LabelKeyOrgKiePersistence = LabelKeyOrgKie + "persistence"
 	// LabelKeyOrgKiePersistenceRequired is the label key to check if persistence is enabled or not
 	LabelKeyOrgKiePersistenceRequired = LabelKeyOrgKiePersistence + labelNamespaceSep + "required"
+	// LabelKeyOrgKieMessaging is the label key for Messaging metadata
+	LabelKeyOrgKieMessaging = LabelKeyOrgKie + "messaging"
+	// LabelKeyOrgKieMessagingConsumed is the label key listing the channels consumed by the service, separated by comma
+	LabelKeyOrgKieMessagingConsumed = LabelKeyOrgKieMessaging + labelNamespaceSep + "consumed"
+	// LabelKeyOrgKieMessagingProduced is the label key listing the channels produced by the service, separated by comma
+	LabelKeyOrgKieMessagingProduced = LabelKeyOrgKieMessaging + labelNamespaceSep + "produced"
 
 	// LabelKeyPrometheus is the label key for Prometheus metadata
 	LabelKeyPrometheus = "prometheus.io"
@@ -93,7 +99,7 @@ func MergeImageMetadataWithDeploymentConfig(dc *appsv1.DeploymentConfig, dockerI
 
 	added := false
 	for key, value := range dockerImage.Config.Labels {
-		if strings.Contains(key, LabelKeyOrgKie) && !strings.Contains(key, LabelKeyOrgKiePersistence) {
+		if strings.Contains(key, LabelKeyOrgKie) && !strings.Contains(key, LabelKeyOrgKiePersistence) && !strings.Contains(key, LabelKeyOrgKieMessaging) {
 			splitedKey := strings.Split(key, labelNamespaceSep)
 			// we're only interested on keys like org.kie/something
 			if len(splitedKey) > 1 {
@@ -201,3 +207,22 @@ func IsPersistenceEnabled(dockerImage *dockerv10.DockerImage) (enabled bool) {
 	}
 	return enabled
 }
+
+// ExtractMessagingChannelsFromImage retrieves the channels consumed and produced by the service from the org.kie/messaging labels of the dockerImage
+func ExtractMessagingChannelsFromImage(dockerImage *dockerv10.DockerImage) (consumed []string, produced []string) {
+	if !dockerImageHasLabels(dockerImage) {
+		return nil, nil
+	}
+	return splitImageLabelValues(dockerImage.Config.Labels[LabelKeyOrgKieMessagingConsumed]),
+		splitImageLabelValues(dockerImage.Config.Labels[LabelKeyOrgKieMessagingProduced])
+}
+
+func splitImageLabelValues(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, dockerLabelServicesSep) {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			values = append(values, item)
+		}
+	}
+	return values
+}
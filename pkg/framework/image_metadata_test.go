@@ -311,3 +311,24 @@ func TestIsPersistenceEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractMessagingChannelsFromImage(t *testing.T) {
+	dockerImage := &dockerv10.DockerImage{
+		Config: &dockerv10.DockerConfig{Labels: map[string]string{
+			LabelKeyOrgKieMessagingConsumed: "travellers, visas",
+			LabelKeyOrgKieMessagingProduced: "processedtravellers",
+		}},
+	}
+	consumed, produced := ExtractMessagingChannelsFromImage(dockerImage)
+	assert.Equal(t, []string{"travellers", "visas"}, consumed)
+	assert.Equal(t, []string{"processedtravellers"}, produced)
+
+	consumed, produced = ExtractMessagingChannelsFromImage(&dockerv10.DockerImage{})
+	assert.Empty(t, consumed)
+	assert.Empty(t, produced)
+
+	// messaging labels are not copied to the deployment
+	dc := &appsv1.DeploymentConfig{Spec: appsv1.DeploymentConfigSpec{Template: &v12.PodTemplateSpec{}}}
+	assert.False(t, MergeImageMetadataWithDeploymentConfig(dc, dockerImage))
+	assert.Empty(t, dc.Labels)
+}
@@ -65,3 +65,16 @@ func AddOwnerReference(owner resource.KubernetesResource, scheme *runtime.Scheme
 	}
 	return nil
 }
+
+// RemoveOwnerReference removes the given owner from the OwnerReferences of the given resources
+func RemoveOwnerReference(owner resource.KubernetesResource, resources ...resource.KubernetesResource) {
+	for _, res := range resources {
+		var owners []v1.OwnerReference
+		for _, resOwner := range res.GetOwnerReferences() {
+			if resOwner.UID != owner.GetUID() {
+				owners = append(owners, resOwner)
+			}
+		}
+		res.SetOwnerReferences(owners)
+	}
+}
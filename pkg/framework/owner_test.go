@@ -103,3 +103,17 @@ func TestAddOwnerReference(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, owned.OwnerReferences, 1)
 }
+
+func TestRemoveOwnerReference(t *testing.T) {
+	scheme := meta.GetRegisteredSchema()
+	owner := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: t.Name(), UID: test.GenerateUID()}}
+	anotherOwner := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "another-deployment", Namespace: t.Name(), UID: test.GenerateUID()}}
+	owned := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config-map", Namespace: t.Name(), UID: test.GenerateUID()}}
+	assert.NoError(t, AddOwnerReference(owner, scheme, owned))
+	assert.NoError(t, AddOwnerReference(anotherOwner, scheme, owned))
+
+	RemoveOwnerReference(owner, owned)
+	assert.Len(t, owned.OwnerReferences, 1)
+	assert.False(t, IsOwner(owned, owner))
+	assert.True(t, IsOwner(owned, anotherOwner))
+}
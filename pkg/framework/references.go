@@ -0,0 +1,110 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReferencedObjectKind is the kind of an object referenced by a Pod template
+type ReferencedObjectKind string
+
+const (
+	// SecretReferenceKind kind for Secrets referenced by a Pod template
+	SecretReferenceKind ReferencedObjectKind = "Secret"
+	// ConfigMapReferenceKind kind for ConfigMaps referenced by a Pod template
+	ConfigMapReferenceKind ReferencedObjectKind = "ConfigMap"
+)
+
+// ReferencedObject is a Secret or a ConfigMap referenced by a Pod template
+type ReferencedObject struct {
+	Kind ReferencedObjectKind
+	Name string
+}
+
+// GetPodReferencedObjects gets every Secret and ConfigMap referenced by the given Pod template through environment variables or volumes.
+// The returned slice is sorted by kind and name without duplicates.
+func GetPodReferencedObjects(template *corev1.PodTemplateSpec) []ReferencedObject {
+	references := map[ReferencedObject]bool{}
+	add := func(kind ReferencedObjectKind, name string) {
+		if len(name) > 0 {
+			references[ReferencedObject{Kind: kind, Name: name}] = true
+		}
+	}
+	containers := append(append([]corev1.Container{}, template.Spec.InitContainers...), template.Spec.Containers...)
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				add(SecretReferenceKind, env.ValueFrom.SecretKeyRef.Name)
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				add(ConfigMapReferenceKind, env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				add(SecretReferenceKind, envFrom.SecretRef.Name)
+			}
+			if envFrom.ConfigMapRef != nil {
+				add(ConfigMapReferenceKind, envFrom.ConfigMapRef.Name)
+			}
+		}
+	}
+	for _, volume := range template.Spec.Volumes {
+		if volume.Secret != nil {
+			add(SecretReferenceKind, volume.Secret.SecretName)
+		}
+		if volume.ConfigMap != nil {
+			add(ConfigMapReferenceKind, volume.ConfigMap.Name)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					add(SecretReferenceKind, source.Secret.Name)
+				}
+				if source.ConfigMap != nil {
+					add(ConfigMapReferenceKind, source.ConfigMap.Name)
+				}
+			}
+		}
+	}
+
+	sortedReferences := make([]ReferencedObject, 0, len(references))
+	for reference := range references {
+		sortedReferences = append(sortedReferences, reference)
+	}
+	sort.Slice(sortedReferences, func(i, j int) bool {
+		if sortedReferences[i].Kind != sortedReferences[j].Kind {
+			return sortedReferences[i].Kind < sortedReferences[j].Kind
+		}
+		return sortedReferences[i].Name < sortedReferences[j].Name
+	})
+	return sortedReferences
+}
+
+// IsReferencedByPod verifies if the object with the given kind and name is referenced by the Pod template
+func IsReferencedByPod(template *corev1.PodTemplateSpec, kind ReferencedObjectKind, name string) bool {
+	for _, reference := range GetPodReferencedObjects(template) {
+		if reference.Kind == kind && reference.Name == name {
+			return true
+		}
+	}
+	return false
+}
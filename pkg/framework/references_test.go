@@ -0,0 +1,54 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestGetPodReferencedObjects(t *testing.T) {
+	template := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Env: []corev1.EnvVar{
+						CreateSecretEnvVar("PASSWORD", "credentials", "password"),
+						CreateEnvVar("PLAIN", "value"),
+						{Name: "FROM_CM", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}, Key: "key"}}},
+					},
+					EnvFrom: []corev1.EnvFromSource{
+						{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}}},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "certs"}}},
+				{VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-props"}}}},
+			},
+		},
+	}
+	references := GetPodReferencedObjects(template)
+	assert.Equal(t, []ReferencedObject{
+		{Kind: ConfigMapReferenceKind, Name: "app-props"},
+		{Kind: ConfigMapReferenceKind, Name: "settings"},
+		{Kind: SecretReferenceKind, Name: "certs"},
+		{Kind: SecretReferenceKind, Name: "credentials"},
+	}, references)
+	assert.True(t, IsReferencedByPod(template, SecretReferenceKind, "certs"))
+	assert.False(t, IsReferencedByPod(template, ConfigMapReferenceKind, "certs"))
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	artemisv1beta1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/artemis/v1beta1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ArtemisKind CRD Kind for ActiveMQ Artemis brokers (as defined by ActiveMQ Artemis operator)
+	ArtemisKind = "ActiveMQArtemis"
+	// ArtemisAddressKind CRD Kind for ActiveMQ Artemis addresses (as defined by ActiveMQ Artemis operator)
+	ArtemisAddressKind = "ActiveMQArtemisAddress"
+
+	// ArtemisInstanceName is the default name for the ActiveMQ Artemis broker managed by KogitoInfra
+	ArtemisInstanceName = "kogito-artemis"
+
+	// ArtemisAMQPDefaultPort is the port of the AMQP acceptor of the brokers
+	ArtemisAMQPDefaultPort = 5672
+	// ArtemisCredentialsUserKey key of the username in the Secret created by ActiveMQ Artemis operator for the broker credentials
+	ArtemisCredentialsUserKey = "AMQ_USER"
+	// ArtemisCredentialsPasswordKey key of the password in the Secret created by ActiveMQ Artemis operator for the broker credentials
+	ArtemisCredentialsPasswordKey = "AMQ_PASSWORD"
+
+	// artemisAMQPAcceptorName name of the AMQP acceptor, the broker operator exposes it with a Service for each broker pod
+	artemisAMQPAcceptorName = "amqp"
+	// artemisCredentialsSecretSuffix suffix of the Secret created by ActiveMQ Artemis operator holding the broker credentials
+	artemisCredentialsSecretSuffix = "-credentials-secret"
+	// artemisAddressServiceNamespaceLabel labels the addresses created for a Kogito service with the service namespace,
+	// along with the app label holding the service name
+	artemisAddressServiceNamespaceLabel = "kogito.kie.org/service-namespace"
+	// artemisFQQNSeparator separates the address and the queue names in a fully qualified queue name
+	artemisFQQNSeparator = "::"
+)
+
+var (
+	// ArtemisAPIVersion CRD API group version for ActiveMQ Artemis brokers (as defined by ActiveMQ Artemis operator)
+	ArtemisAPIVersion = artemisv1beta1.SchemeGroupVersion.String()
+)
+
+// IsArtemisAvailable checks whether ActiveMQ Artemis CRD is available or not
+func IsArtemisAvailable(cli *client.Client) bool {
+	return cli.HasServerGroup(artemisv1beta1.SchemeGroupVersion.Group)
+}
+
+// IsArtemisResource checks if provided KogitoInfra instance is for ActiveMQ Artemis resource
+func IsArtemisResource(instance *v1alpha1.KogitoInfra) bool {
+	return instance.Spec.Resource.APIVersion == ArtemisAPIVersion && instance.Spec.Resource.Kind == ArtemisKind
+}
+
+// IsAMQPResource checks if provided KogitoInfra instance provides an AMQP broker, either an ActiveMQ Artemis resource or an external broker
+func IsAMQPResource(instance *v1alpha1.KogitoInfra) bool {
+	return IsArtemisResource(instance) || (instance.Spec.External != nil && instance.Spec.External.AMQP != nil)
+}
+
+// GetArtemisDefaultResource returns an ActiveMQ Artemis broker with a single pod accepting AMQP connections from authenticated clients
+func GetArtemisDefaultResource(name, namespace string) *artemisv1beta1.ActiveMQArtemis {
+	return &artemisv1beta1.ActiveMQArtemis{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: artemisv1beta1.ActiveMQArtemisSpec{
+			DeploymentPlan: artemisv1beta1.DeploymentPlan{
+				Size:         1,
+				RequireLogin: true,
+			},
+			Acceptors: []artemisv1beta1.AcceptorType{
+				{
+					Name:      artemisAMQPAcceptorName,
+					Protocols: artemisv1beta1.AMQPProtocol,
+					Port:      ArtemisAMQPDefaultPort,
+				},
+			},
+		},
+	}
+}
+
+// IsArtemisReady checks if the given ActiveMQ Artemis broker has ready pods
+func IsArtemisReady(artemis *artemisv1beta1.ActiveMQArtemis) bool {
+	return len(artemis.Status.PodStatus.Ready) > 0
+}
+
+// GetArtemisAMQPAcceptor gets the acceptor of the given ActiveMQ Artemis broker accepting AMQP connections, nil if not found
+func GetArtemisAMQPAcceptor(artemis *artemisv1beta1.ActiveMQArtemis) *artemisv1beta1.AcceptorType {
+	for i, acceptor := range artemis.Spec.Acceptors {
+		for _, protocol := range strings.Split(acceptor.Protocols, ",") {
+			if p := strings.ToLower(strings.TrimSpace(protocol)); p == artemisv1beta1.AMQPProtocol || p == "all" {
+				return &artemis.Spec.Acceptors[i]
+			}
+		}
+	}
+	return nil
+}
+
+// GetArtemisAMQPHost gets the host of the Service exposing the given acceptor of the first pod of the ActiveMQ Artemis broker
+func GetArtemisAMQPHost(artemis *artemisv1beta1.ActiveMQArtemis, acceptor *artemisv1beta1.AcceptorType) string {
+	return fmt.Sprintf("%s-%s-0-svc.%s.svc", artemis.Name, acceptor.Name, artemis.Namespace)
+}
+
+// GetArtemisCredentialsSecretName gets the name of the Secret created by ActiveMQ Artemis operator holding the broker credentials
+func GetArtemisCredentialsSecretName(artemis *artemisv1beta1.ActiveMQArtemis) string {
+	return artemis.Name + artemisCredentialsSecretSuffix
+}
+
+// GetArtemisQueueName gets the name of the queue receiving the messages sent to the given address for the given Kogito service.
+// Each consumer service gets its own queue, so that every service receives all the messages of the address.
+func GetArtemisQueueName(addressName, serviceName, serviceNamespace string) string {
+	return fmt.Sprintf("%s.%s.%s", addressName, serviceNamespace, serviceName)
+}
+
+// GetArtemisFQQN gets the fully qualified name of the given queue, used by AMQP clients to consume from it
+func GetArtemisFQQN(addressName, queueName string) string {
+	return addressName + artemisFQQNSeparator + queueName
+}
+
+// GetArtemisAddressLabels gets the labels of the addresses created for the given Kogito service
+func GetArtemisAddressLabels(serviceName, serviceNamespace string) map[string]string {
+	return map[string]string{
+		framework.LabelAppKey:               serviceName,
+		artemisAddressServiceNamespaceLabel: serviceNamespace,
+	}
+}
+
+// GetArtemisAddress returns the ActiveMQ Artemis address with the given name for the given Kogito service, applied to the given broker.
+// When a queue name is provided, a multicast queue is created as well and removed from the broker along with the address resource.
+func GetArtemisAddress(name, namespace, artemisName, addressName, queueName, serviceName, serviceNamespace string) *artemisv1beta1.ActiveMQArtemisAddress {
+	return &artemisv1beta1.ActiveMQArtemisAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    GetArtemisAddressLabels(serviceName, serviceNamespace),
+		},
+		Spec: artemisv1beta1.ActiveMQArtemisAddressSpec{
+			AddressName:              addressName,
+			QueueName:                queueName,
+			RoutingType:              artemisv1beta1.MulticastRoutingType,
+			RemoveFromBrokerOnDelete: len(queueName) > 0,
+			ApplyToCrNames:           []string{artemisName},
+		},
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+
+	grafanav1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GrafanaKind CRD Kind for Grafana instances (as defined by Grafana Operator)
+	GrafanaKind = "Grafana"
+
+	// GrafanaInstanceName is the default name for the Grafana instance managed by KogitoInfra, also used for its Prometheus data source
+	GrafanaInstanceName = "kogito-grafana"
+
+	grafanaPrometheusDataSourceName = "Prometheus"
+)
+
+var (
+	// GrafanaAPIVersion CRD API group version for Grafana instances (as defined by Grafana Operator)
+	GrafanaAPIVersion = grafanav1.SchemeGroupVersion.String()
+)
+
+// IsGrafanaAvailable checks if Grafana CRD is available in the cluster
+func IsGrafanaAvailable(cli *client.Client) bool {
+	return cli.HasServerGroup(grafanav1.SchemeGroupVersion.Group)
+}
+
+// GetGrafanaDefaultResource returns a Grafana instance importing the dashboards created for the Kogito services of its namespace
+func GetGrafanaDefaultResource(name, namespace string) *grafanav1.Grafana {
+	return &grafanav1.Grafana{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: grafanav1.GrafanaSpec{
+			DashboardLabelSelector: []*metav1.LabelSelector{{MatchLabels: GetMonitoringLabels()}},
+			Compat:                 &grafanav1.GrafanaCompat{},
+		},
+	}
+}
+
+// GetGrafanaDefaultDataSource returns the default Grafana data source querying the Prometheus instances of the given namespace
+func GetGrafanaDefaultDataSource(name, namespace string) *grafanav1.GrafanaDataSource {
+	return &grafanav1.GrafanaDataSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: grafanav1.GrafanaDataSourceSpec{
+			Name: fmt.Sprintf("%s.yaml", name),
+			Datasources: []grafanav1.GrafanaDataSourceFields{
+				{
+					Name:      grafanaPrometheusDataSourceName,
+					Type:      "prometheus",
+					Access:    "proxy",
+					Url:       fmt.Sprintf("http://%s.%s.svc:%d", PrometheusOperatedServiceName, namespace, PrometheusDefaultPort),
+					IsDefault: true,
+				},
+			},
+		},
+	}
+}
+
+// IsGrafanaReady checks if the Grafana Operator has reconciled the given Grafana instance without failures
+func IsGrafanaReady(grafana *grafanav1.Grafana) bool {
+	return grafana.Status.Phase == grafanav1.PhaseReconciling
+}
@@ -16,6 +16,7 @@ package infrastructure
 
 import (
 	"fmt"
+	"strings"
 
 	ispn "github.com/infinispan/infinispan-operator/pkg/apis/infinispan/v1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
@@ -46,6 +47,11 @@ const (
 
 	// InfinispanInstanceName is the default name for Infinispan managed by KogitoInfra
 	InfinispanInstanceName = "kogito-infinispan"
+
+	// InfinispanTLSCertKey is the key of the server certificate in the Secret referenced by the Infinispan endpoint encryption
+	InfinispanTLSCertKey = "tls.crt"
+	// infinispanNoneEncryptionType disables the Infinispan endpoint encryption
+	infinispanNoneEncryptionType = "None"
 )
 
 var (
@@ -118,6 +124,12 @@ func FetchKogitoInfinispanInstanceURI(cli *client.Client, instanceName string, n
 	}
 }
 
+// IsInfinispanEncryptionEnabled checks if the endpoint of the given Infinispan instance is encrypted with the certificate of a Secret
+func IsInfinispanEncryptionEnabled(infinispanInstance *ispn.Infinispan) bool {
+	encryption := infinispanInstance.Spec.Security.EndpointEncryption
+	return len(encryption.CertSecretName) > 0 && len(encryption.Type) > 0 && !strings.EqualFold(encryption.Type, infinispanNoneEncryptionType)
+}
+
 // GetInfinispanCredential gets the credential of the Infinispan server deployed with the Kogito Operator
 func GetInfinispanCredential(cli *client.Client, infinispanInstance *ispn.Infinispan) (*InfinispanCredential, error) {
 	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: infinispanInstance.Spec.Security.EndpointSecretName, Namespace: infinispanInstance.Namespace}}
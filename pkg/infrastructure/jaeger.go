@@ -0,0 +1,71 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+
+	jaegerv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/jaeger/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// JaegerKind CRD Kind for Jaeger instances (as defined by Jaeger operator)
+	JaegerKind = "Jaeger"
+
+	// JaegerInstanceName is the default name for the Jaeger instance managed by KogitoInfra
+	JaegerInstanceName = "kogito-jaeger"
+
+	// jaegerCollectorServiceSuffix suffix of the Service created by Jaeger operator in front of the collector
+	jaegerCollectorServiceSuffix = "-collector"
+	jaegerCollectorOTLPPort      = 4317
+	jaegerCollectorHTTPPort      = 14268
+	jaegerCollectorHTTPPath      = "/api/traces"
+)
+
+var (
+	// JaegerAPIVersion CRD API group version for Jaeger instances (as defined by Jaeger operator)
+	JaegerAPIVersion = jaegerv1.SchemeGroupVersion.String()
+)
+
+// IsJaegerAvailable checks whether Jaeger CRD is available or not
+func IsJaegerAvailable(cli *client.Client) bool {
+	return cli.HasServerGroup(jaegerv1.SchemeGroupVersion.Group)
+}
+
+// GetJaegerDefaultResource returns an all-in-one Jaeger instance, with in-memory storage, receiving traces through OTLP as well
+func GetJaegerDefaultResource(name, namespace string) *jaegerv1.Jaeger {
+	return &jaegerv1.Jaeger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: jaegerv1.JaegerSpec{
+			Strategy: jaegerv1.JaegerAllInOneStrategy,
+			AllInOne: jaegerv1.JaegerAllInOneSpec{Options: map[string]string{"collector.otlp.enabled": "true"}},
+		},
+	}
+}
+
+// GetJaegerCollectorOTLPEndpoint gets the OTLP gRPC endpoint of the collector of the given Jaeger instance
+func GetJaegerCollectorOTLPEndpoint(jaeger *jaegerv1.Jaeger) string {
+	return fmt.Sprintf("http://%s%s.%s.svc:%d", jaeger.Name, jaegerCollectorServiceSuffix, jaeger.Namespace, jaegerCollectorOTLPPort)
+}
+
+// GetJaegerCollectorHTTPEndpoint gets the endpoint of the collector of the given Jaeger instance receiving traces in Jaeger Thrift format over HTTP
+func GetJaegerCollectorHTTPEndpoint(jaeger *jaegerv1.Jaeger) string {
+	return fmt.Sprintf("http://%s%s.%s.svc:%d%s", jaeger.Name, jaegerCollectorServiceSuffix, jaeger.Namespace, jaegerCollectorHTTPPort, jaegerCollectorHTTPPath)
+}
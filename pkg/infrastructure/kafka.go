@@ -16,7 +16,11 @@ package infrastructure
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/kafka/v1beta1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
@@ -33,11 +37,33 @@ const (
 	defaultKafkaTopicPartition = 1
 	defaultKafkaTopicReplicas  = 1
 
+	kafkaTopicRetentionMsConfig   = "retention.ms"
+	kafkaTopicCleanupPolicyConfig = "cleanup.policy"
+
 	// KafkaKind refers to Kafka Kind as defined by Strimzi
 	KafkaKind = "Kafka"
 
 	// KafkaInstanceName is the default name for the Kafka cluster managed by KogitoInfra
 	KafkaInstanceName = "kogito-kafka"
+
+	// KafkaClusterCACertKey is the key of the cluster CA certificate in the Secret created by Strimzi
+	KafkaClusterCACertKey = "ca.crt"
+	// KafkaUserPasswordKey is the key of the SCRAM-SHA-512 password in the KafkaUser Secret created by Strimzi
+	KafkaUserPasswordKey = "password"
+	// KafkaUserKeystoreKey is the key of the PKCS #12 keystore in the KafkaUser Secret created by Strimzi for TLS client authentication
+	KafkaUserKeystoreKey = "user.p12"
+	// KafkaUserKeystorePasswordKey is the key of the PKCS #12 keystore password in the KafkaUser Secret created by Strimzi
+	KafkaUserKeystorePasswordKey = "user.password"
+
+	kafkaPlainListenerType   = "plain"
+	kafkaTLSListenerType     = "tls"
+	kafkaClusterCACertSuffix = "-cluster-ca-cert"
+	kafkaACLWildcardResource = "*"
+	kafkaACLLiteralPattern   = "literal"
+
+	// KafkaTopicServicesAnnotation lists the services, as namespace/name, using the KafkaTopic created by the operator
+	KafkaTopicServicesAnnotation = "kogito.kie.org/services"
+	kafkaTopicServicesSeparator  = ","
 )
 
 var (
@@ -85,17 +111,13 @@ func GetKafkaDefaultResource(name, namespace string, defaultReplicas int32) *v1b
 	}
 }
 
-// GetKafkaTopic returns a Kafka topic resource with default configuration
-func GetKafkaTopic(name, namespace, kafkaBroker string) *v1beta1.KafkaTopic {
-
-	labels := make(map[string]string)
-	labels[strimziBrokerLabel] = kafkaBroker
-
-	return &v1beta1.KafkaTopic{
+// GetKafkaTopic returns a Kafka topic resource with the given settings, using the default configuration for the ones not provided
+func GetKafkaTopic(name, namespace, kafkaBroker string, settings *v1alpha1.KafkaTopicSettings) *v1beta1.KafkaTopic {
+	kafkaTopic := &v1beta1.KafkaTopic{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    GetKafkaTopicLabels(kafkaBroker),
 		},
 		Spec: v1beta1.KafkaTopicSpec{
 			Partitions: defaultKafkaTopicPartition,
@@ -103,14 +125,43 @@ func GetKafkaTopic(name, namespace, kafkaBroker string) *v1beta1.KafkaTopic {
 			TopicName:  name,
 		},
 	}
+	if settings == nil {
+		return kafkaTopic
+	}
+	if settings.Partitions > 0 {
+		kafkaTopic.Spec.Partitions = settings.Partitions
+	}
+	if settings.ReplicationFactor > 0 {
+		kafkaTopic.Spec.Replicas = settings.ReplicationFactor
+	}
+	config := map[string]string{}
+	if settings.RetentionMs != nil {
+		config[kafkaTopicRetentionMsConfig] = strconv.FormatInt(*settings.RetentionMs, 10)
+	}
+	if len(settings.CleanupPolicy) > 0 {
+		config[kafkaTopicCleanupPolicyConfig] = string(settings.CleanupPolicy)
+	}
+	if len(config) > 0 {
+		kafkaTopic.Spec.Config = config
+	}
+	return kafkaTopic
+}
+
+// GetKafkaTopicLabels gets the labels of the Kafka topics managed by the given Kafka instance
+func GetKafkaTopicLabels(kafkaBroker string) map[string]string {
+	return map[string]string{strimziBrokerLabel: kafkaBroker}
 }
 
 // ResolveKafkaServerURI returns the uri of the kafka instance
 func ResolveKafkaServerURI(kafka *v1beta1.Kafka) (string, error) {
 	log.Debugf("Resolving kafka URI for given kafka instance %s", kafka.Name)
+	listenerType := kafkaPlainListenerType
+	if IsKafkaTLSEnabled(kafka) {
+		listenerType = kafkaTLSListenerType
+	}
 	if len(kafka.Status.Listeners) > 0 {
 		for _, listenerStatus := range kafka.Status.Listeners {
-			if listenerStatus.Type == "plain" && len(listenerStatus.Addresses) > 0 {
+			if listenerStatus.Type == listenerType && len(listenerStatus.Addresses) > 0 {
 				for _, listenerAddress := range listenerStatus.Addresses {
 					if len(listenerAddress.Host) > 0 && listenerAddress.Port > 0 {
 						kafkaURI := fmt.Sprintf("%s:%d", listenerAddress.Host, listenerAddress.Port)
@@ -125,6 +176,88 @@ func ResolveKafkaServerURI(kafka *v1beta1.Kafka) (string, error) {
 	return "", fmt.Errorf("not able resolve URI for given kafka instance %s", kafka.Name)
 }
 
+// IsKafkaTLSEnabled checks if the given Kafka instance exposes a TLS listener, used by the Kogito services when available
+func IsKafkaTLSEnabled(kafka *v1beta1.Kafka) bool {
+	return kafka.Spec.Kafka.Listeners.TLS != nil
+}
+
+// GetKafkaAuthentication gets the client authentication required by the listener of the given Kafka instance
+// used by the Kogito services. Returns an empty value if no authentication is required.
+func GetKafkaAuthentication(kafka *v1beta1.Kafka) v1beta1.KafkaAuthenticationType {
+	authentication := kafka.Spec.Kafka.Listeners.Plain.Authentication
+	if IsKafkaTLSEnabled(kafka) {
+		authentication = kafka.Spec.Kafka.Listeners.TLS.Authentication
+	}
+	if authentication == nil {
+		return ""
+	}
+	return authentication.Type
+}
+
+// GetKafkaClusterCACertSecretName gets the name of the Secret holding the cluster CA certificate created by Strimzi
+func GetKafkaClusterCACertSecretName(kafka *v1beta1.Kafka) string {
+	return kafka.Name + kafkaClusterCACertSuffix
+}
+
+// GetKafkaUserDefaultResource returns a KafkaUser for the given Kafka instance with the given authentication.
+// When the Kafka instance enables authorization, the user is allowed to consume from any consumer group and
+// the access to the topics is granted with AddKafkaUserTopicACLs.
+func GetKafkaUserDefaultResource(name string, kafka *v1beta1.Kafka, authentication v1beta1.KafkaAuthenticationType) *v1beta1.KafkaUser {
+	kafkaUser := &v1beta1.KafkaUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: kafka.Namespace,
+			Labels:    map[string]string{strimziBrokerLabel: kafka.Name},
+		},
+		Spec: v1beta1.KafkaUserSpec{
+			Authentication: v1beta1.KafkaUserAuthentication{Type: authentication},
+		},
+	}
+	if kafka.Spec.Kafka.Authorization != nil {
+		kafkaUser.Spec.Authorization = &v1beta1.KafkaUserAuthorization{
+			Type: v1beta1.KafkaSimpleAuthorization,
+			ACLs: []v1beta1.KafkaUserACL{
+				{
+					Resource:  v1beta1.KafkaUserACLResource{Type: v1beta1.KafkaACLGroupResource, Name: kafkaACLWildcardResource, PatternType: kafkaACLLiteralPattern},
+					Operation: v1beta1.KafkaACLReadOperation,
+				},
+			},
+		}
+	}
+	return kafkaUser
+}
+
+// AddKafkaUserTopicACLs grants the given KafkaUser the access to consume from and publish to the given topics.
+// Returns true if the KafkaUser has been changed.
+func AddKafkaUserTopicACLs(kafkaUser *v1beta1.KafkaUser, topics ...string) bool {
+	if kafkaUser.Spec.Authorization == nil {
+		return false
+	}
+	changed := false
+	for _, topic := range topics {
+		for _, operation := range []v1beta1.KafkaACLOperation{v1beta1.KafkaACLReadOperation, v1beta1.KafkaACLWriteOperation, v1beta1.KafkaACLDescribeOperation} {
+			acl := v1beta1.KafkaUserACL{
+				Resource:  v1beta1.KafkaUserACLResource{Type: v1beta1.KafkaACLTopicResource, Name: topic, PatternType: kafkaACLLiteralPattern},
+				Operation: operation,
+			}
+			if !hasKafkaUserACL(kafkaUser, acl) {
+				kafkaUser.Spec.Authorization.ACLs = append(kafkaUser.Spec.Authorization.ACLs, acl)
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func hasKafkaUserACL(kafkaUser *v1beta1.KafkaUser, acl v1beta1.KafkaUserACL) bool {
+	for _, existing := range kafkaUser.Spec.Authorization.ACLs {
+		if existing == acl {
+			return true
+		}
+	}
+	return false
+}
+
 // getKafkaInstanceWithName fetches the Kafka instance of the given name
 func getKafkaInstanceWithName(name string, namespace string, client *client.Client) (*v1beta1.Kafka, error) {
 	log.Debugf("Fetching kafka instance for given instance %s", name)
@@ -138,3 +271,32 @@ func getKafkaInstanceWithName(name string, namespace string, client *client.Clie
 	log.Debugf("Kafka instance (%s) not found in namespace %s", name, namespace)
 	return nil, nil
 }
+
+// GetKafkaTopicServiceKey gets the key identifying the service with the given namespace and name in the KafkaTopics it uses
+func GetKafkaTopicServiceKey(namespace, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+// IsManagedKafkaTopic checks if the KafkaTopic has been created by the operator, so it tracks the services using it
+func IsManagedKafkaTopic(kafkaTopic *v1beta1.KafkaTopic) bool {
+	_, managed := kafkaTopic.Annotations[KafkaTopicServicesAnnotation]
+	return managed
+}
+
+// GetKafkaTopicServices gets the keys of the services using the KafkaTopic created by the operator
+func GetKafkaTopicServices(kafkaTopic *v1beta1.KafkaTopic) []string {
+	services := kafkaTopic.Annotations[KafkaTopicServicesAnnotation]
+	if len(services) == 0 {
+		return nil
+	}
+	return strings.Split(services, kafkaTopicServicesSeparator)
+}
+
+// SetKafkaTopicServices sets the keys of the services using the KafkaTopic created by the operator
+func SetKafkaTopicServices(kafkaTopic *v1beta1.KafkaTopic, services []string) {
+	if kafkaTopic.Annotations == nil {
+		kafkaTopic.Annotations = map[string]string{}
+	}
+	sort.Strings(services)
+	kafkaTopic.Annotations[KafkaTopicServicesAnnotation] = strings.Join(services, kafkaTopicServicesSeparator)
+}
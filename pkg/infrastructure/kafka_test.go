@@ -18,6 +18,7 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/kafka/v1beta1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"reflect"
@@ -127,6 +128,31 @@ func Test_resolveKafkaServerURI(t *testing.T) {
 			},
 			"kafka:9092",
 		},
+		{
+			"ResolveKafkaServerURIWithTLS",
+			args{
+				&v1beta1.Kafka{
+					Spec: v1beta1.KafkaSpec{
+						Kafka: v1beta1.KafkaClusterSpec{
+							Listeners: v1beta1.KafkaListeners{TLS: &v1beta1.KafkaListenerTLS{}},
+						},
+					},
+					Status: v1beta1.KafkaStatus{
+						Listeners: []v1beta1.ListenerStatus{
+							{
+								Type:      "plain",
+								Addresses: []v1beta1.ListenerAddress{{Host: "kafka", Port: 9092}},
+							},
+							{
+								Type:      "tls",
+								Addresses: []v1beta1.ListenerAddress{{Host: "kafka", Port: 9093}},
+							},
+						},
+					},
+				},
+			},
+			"kafka:9093",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -136,3 +162,30 @@ func Test_resolveKafkaServerURI(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetKafkaAuthentication(t *testing.T) {
+	kafka := &v1beta1.Kafka{}
+	assert.Empty(t, GetKafkaAuthentication(kafka))
+
+	kafka.Spec.Kafka.Listeners.Plain.Authentication = &v1beta1.KafkaListenerAuthentication{Type: v1beta1.KafkaScramSha512Authentication}
+	assert.Equal(t, v1beta1.KafkaScramSha512Authentication, GetKafkaAuthentication(kafka))
+
+	kafka.Spec.Kafka.Listeners.TLS = &v1beta1.KafkaListenerTLS{Authentication: &v1beta1.KafkaListenerAuthentication{Type: v1beta1.KafkaTLSAuthentication}}
+	assert.Equal(t, v1beta1.KafkaTLSAuthentication, GetKafkaAuthentication(kafka))
+}
+
+func Test_AddKafkaUserTopicACLs(t *testing.T) {
+	kafka := &v1beta1.Kafka{ObjectMeta: v1.ObjectMeta{Name: "kafka", Namespace: t.Name()}}
+	kafkaUser := GetKafkaUserDefaultResource("kogito-kafka", kafka, v1beta1.KafkaScramSha512Authentication)
+	assert.Equal(t, "kafka", kafkaUser.Labels["strimzi.io/cluster"])
+	assert.Nil(t, kafkaUser.Spec.Authorization)
+	assert.False(t, AddKafkaUserTopicACLs(kafkaUser, "topic1"))
+
+	kafka.Spec.Kafka.Authorization = &v1beta1.KafkaAuthorization{Type: v1beta1.KafkaSimpleAuthorization}
+	kafkaUser = GetKafkaUserDefaultResource("kogito-kafka", kafka, v1beta1.KafkaScramSha512Authentication)
+	assert.Len(t, kafkaUser.Spec.Authorization.ACLs, 1)
+	assert.True(t, AddKafkaUserTopicACLs(kafkaUser, "topic1", "topic2"))
+	assert.Len(t, kafkaUser.Spec.Authorization.ACLs, 7)
+	assert.False(t, AddKafkaUserTopicACLs(kafkaUser, "topic2"))
+	assert.Len(t, kafkaUser.Spec.Authorization.ACLs, 7)
+}
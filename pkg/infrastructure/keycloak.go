@@ -15,8 +15,11 @@
 package infrastructure
 
 import (
+	"fmt"
+
 	"github.com/keycloak/keycloak-operator/pkg/apis/keycloak/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -25,6 +28,11 @@ const (
 
 	// KeycloakInstanceName is the default instance name for Keycloak CR managed by Kogito
 	KeycloakInstanceName = "kogito-keycloak"
+
+	// KeycloakDefaultRealm is the default realm created for the Kogito services
+	KeycloakDefaultRealm = "kogito"
+
+	keycloakClientAuthenticatorType = "client-secret"
 )
 
 var (
@@ -38,3 +46,50 @@ var (
 func IsKeycloakAvailable(client *client.Client) bool {
 	return client.HasServerGroup(keycloakServerGroup)
 }
+
+// GetKeycloakRealmDefaultResource returns a KeycloakRealm for the Kogito services in the Keycloak instance matching the given labels
+func GetKeycloakRealmDefaultResource(name, namespace string, labels, instanceLabels map[string]string) *v1alpha1.KeycloakRealm {
+	return &v1alpha1.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: v1alpha1.KeycloakRealmSpec{
+			InstanceSelector: &metav1.LabelSelector{MatchLabels: instanceLabels},
+			Realm: &v1alpha1.KeycloakAPIRealm{
+				ID:      KeycloakDefaultRealm,
+				Realm:   KeycloakDefaultRealm,
+				Enabled: true,
+			},
+		},
+	}
+}
+
+// GetKeycloakClientDefaultResource returns a confidential KeycloakClient in the KeycloakRealm matching the given labels
+func GetKeycloakClientDefaultResource(name, namespace, clientID, secret string, labels, realmLabels map[string]string) *v1alpha1.KeycloakClient {
+	return &v1alpha1.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: v1alpha1.KeycloakClientSpec{
+			RealmSelector: &metav1.LabelSelector{MatchLabels: realmLabels},
+			Client: &v1alpha1.KeycloakAPIClient{
+				ClientID:                  clientID,
+				Name:                      clientID,
+				Enabled:                   true,
+				ClientAuthenticatorType:   keycloakClientAuthenticatorType,
+				Secret:                    secret,
+				StandardFlowEnabled:       true,
+				DirectAccessGrantsEnabled: true,
+			},
+		},
+	}
+}
+
+// GetKeycloakAuthServerURL gets the OpenID Connect server URL of the given realm served by the Keycloak instance
+func GetKeycloakAuthServerURL(keycloak *v1alpha1.Keycloak, realm string) string {
+	return fmt.Sprintf("%s/auth/realms/%s", keycloak.Status.InternalURL, realm)
+}
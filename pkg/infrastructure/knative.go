@@ -15,7 +15,9 @@
 package infrastructure
 
 import (
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/eventing/pkg/apis/eventing"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 )
@@ -23,6 +25,11 @@ import (
 const (
 	// KnativeEventingBrokerKind is the Kind description for Knative Eventing Brokers
 	KnativeEventingBrokerKind = "Broker"
+	// KnativeEventingTriggerKind is the Kind description for Knative Eventing Triggers
+	KnativeEventingTriggerKind = "Trigger"
+
+	// KnativeEventingBrokerDefaultName is the name of the Broker created by KogitoInfra when none is referenced, as defined by Knative Eventing
+	KnativeEventingBrokerDefaultName = "default"
 )
 
 var (
@@ -34,3 +41,27 @@ var (
 func IsKnativeEventingAvailable(client *client.Client) bool {
 	return client.HasServerGroup(eventing.GroupName)
 }
+
+// GetKnativeEventingBrokerDefaultResource returns the default Knative Eventing Broker using the default Broker class of the cluster
+func GetKnativeEventingBrokerDefaultResource(namespace string) *eventingv1.Broker {
+	return &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      KnativeEventingBrokerDefaultName,
+			Namespace: namespace,
+		},
+	}
+}
+
+// GetKnativeEventingBrokerReference gets the name and namespace of the Broker referenced by the given KogitoInfra instance,
+// defaulting to the Broker created by KogitoInfra in its own namespace
+func GetKnativeEventingBrokerReference(instance *v1alpha1.KogitoInfra) (name, namespace string) {
+	name = instance.Spec.Resource.Name
+	namespace = instance.Spec.Resource.Namespace
+	if len(name) == 0 {
+		name = KnativeEventingBrokerDefaultName
+	}
+	if len(namespace) == 0 {
+		namespace = instance.Namespace
+	}
+	return name, namespace
+}
@@ -19,27 +19,172 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"strings"
 )
 
-// MustFetchKogitoInfraInstance loads a given infra instance by name and namespace.
-// If the KogitoInfra resource is not present, an error is raised.
+// KogitoServiceNamePlaceholder is replaced by the name of the Kogito service in the application properties and
+// environment variables published by a KogitoInfra instance, for infrastructure resources bound to each service (e.g. OIDC clients)
+const KogitoServiceNamePlaceholder = "{{kogito.service.name}}"
+
+// KogitoServiceNamespacePlaceholder is replaced by the namespace of the Kogito service in the application properties and
+// environment variables published by a KogitoInfra instance, for infrastructure resources isolated by namespace (e.g. Infinispan caches)
+const KogitoServiceNamespacePlaceholder = "{{kogito.service.namespace}}"
+
+// kogitoInfraReferenceSeparator separates the namespace and the name of a KogitoInfra of another namespace referenced by a Kogito service
+const kogitoInfraReferenceSeparator = "/"
+
+// resourceNamingNamespaceReference is replaced by the namespace of the Kogito service in the KogitoInfra resource naming policy
+const resourceNamingNamespaceReference = "$(NAMESPACE)"
+
+// MustFetchKogitoInfraInstance loads a given infra instance referenced by a Kogito service in the given namespace.
+// The reference is either the name of a KogitoInfra in the same namespace or namespace/name for a KogitoInfra of another namespace.
+// If the KogitoInfra resource is not present or doesn't allow the given namespace to reference it, an error is raised.
 func MustFetchKogitoInfraInstance(client *client.Client, name string, namespace string) (*v1alpha1.KogitoInfra, error) {
 	log.Debugf("going to fetch deployed kogito infra instance %s", name)
+	key := GetKogitoInfraKey(name, namespace)
+	if !IsNamespaceWatched(key.Namespace) {
+		return nil, fmt.Errorf("kogito Infra resource with name %s can't be referenced, the operator doesn't watch namespace %s. "+
+			"Install the operator watching all namespaces or add %s to its WATCH_NAMESPACE", key.Name, key.Namespace, key.Namespace)
+	}
 	instance := &v1alpha1.KogitoInfra{}
-	if exists, resultErr := kubernetes.ResourceC(client).FetchWithKey(types.NamespacedName{Name: name, Namespace: namespace}, instance); resultErr != nil {
+	if exists, resultErr := kubernetes.ResourceC(client).FetchWithKey(key, instance); resultErr != nil {
 		log.Errorf("Error occurs while fetching deployed kogito infra instance %s", name)
 		return nil, resultErr
 	} else if !exists {
-		return nil, fmt.Errorf("kogito Infra resource with name %s not found in namespace %s", name, namespace)
+		return nil, fmt.Errorf("kogito Infra resource with name %s not found in namespace %s", key.Name, key.Namespace)
+	} else if !IsKogitoInfraNamespaceAllowed(instance, namespace) {
+		return nil, fmt.Errorf("kogito Infra resource with name %s in namespace %s doesn't allow services of namespace %s to reference it", key.Name, key.Namespace, namespace)
 	} else {
 		log.Debugf("Successfully fetch deployed kogito infra reference %s", name)
 		return instance, nil
 	}
 }
 
-// IsKafkaResource checks if provided KogitoInfra instance is for kafka resource
+// IsNamespaceWatched checks if the operator watches the given namespace, either listed in WATCH_NAMESPACE or because it watches all of them
+func IsNamespaceWatched(namespace string) bool {
+	watched := GetWatchedNamespaces(util.GetOSEnv(k8sutil.WatchNamespaceEnvVar, ""))
+	return len(watched) == 0 || util.Contains(namespace, watched)
+}
+
+// GetWatchedNamespaces parses the comma separated namespaces of the given WATCH_NAMESPACE value, none meaning all namespaces are watched
+func GetWatchedNamespaces(watchNamespace string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(watchNamespace, ",") {
+		if namespace = strings.TrimSpace(namespace); len(namespace) > 0 {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// GetKogitoInfraKey resolves the KogitoInfra referenced by a Kogito service in the given namespace,
+// either by name in the same namespace or as namespace/name
+func GetKogitoInfraKey(reference, namespace string) types.NamespacedName {
+	if i := strings.Index(reference, kogitoInfraReferenceSeparator); i >= 0 {
+		return types.NamespacedName{Namespace: reference[:i], Name: reference[i+1:]}
+	}
+	return types.NamespacedName{Namespace: namespace, Name: reference}
+}
+
+// IsKogitoInfraNamespaceAllowed checks if the Kogito services of the given namespace can reference the KogitoInfra instance
+func IsKogitoInfraNamespaceAllowed(instance *v1alpha1.KogitoInfra, namespace string) bool {
+	return namespace == instance.Namespace ||
+		util.Contains(v1alpha1.AllNamespaces, instance.Spec.AllowedNamespaces) ||
+		util.Contains(namespace, instance.Spec.AllowedNamespaces)
+}
+
+// IsKogitoInfraReferenced checks if the given Kogito service depends on the KogitoInfra instance
+func IsKogitoInfraReferenced(service v1alpha1.KogitoService, instance *v1alpha1.KogitoInfra) bool {
+	for _, reference := range GetKogitoInfraReferences(service) {
+		if GetKogitoInfraKey(reference, service.GetNamespace()) == (types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetKogitoInfraSecretName gets the name of a Secret published by the KogitoInfra instance for the Kogito services of the given namespace.
+// Pods can't reference Secrets of other namespaces, so the Secrets are copied into the namespaces of the services, prefixed by the KogitoInfra namespace.
+func GetKogitoInfraSecretName(instance *v1alpha1.KogitoInfra, secretName, namespace string) string {
+	if namespace == instance.Namespace {
+		return secretName
+	}
+	return instance.Namespace + "-" + secretName
+}
+
+// GetKogitoInfraSecretNames gets the names of the Secrets referenced by the environment variables and volumes published by the KogitoInfra instance
+func GetKogitoInfraSecretNames(instance *v1alpha1.KogitoInfra) []string {
+	var secretNames []string
+	for _, env := range instance.Status.Env {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && !util.Contains(env.ValueFrom.SecretKeyRef.Name, secretNames) {
+			secretNames = append(secretNames, env.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	for _, volume := range instance.Status.Volumes {
+		if !util.Contains(volume.SecretName, secretNames) {
+			secretNames = append(secretNames, volume.SecretName)
+		}
+	}
+	return secretNames
+}
+
+// GetKogitoInfraEnvs gets the environment variables published by the KogitoInfra instance for the Kogito services of the given namespace
+func GetKogitoInfraEnvs(instance *v1alpha1.KogitoInfra, namespace string) []corev1.EnvVar {
+	envs := make([]corev1.EnvVar, 0, len(instance.Status.Env))
+	for _, env := range instance.Status.Env {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			env.ValueFrom = env.ValueFrom.DeepCopy()
+			env.ValueFrom.SecretKeyRef.Name = GetKogitoInfraSecretName(instance, env.ValueFrom.SecretKeyRef.Name, namespace)
+		}
+		envs = append(envs, env)
+	}
+	return envs
+}
+
+// GetKogitoInfraVolumes gets the volumes published by the KogitoInfra instance for the Kogito services of the given namespace
+func GetKogitoInfraVolumes(instance *v1alpha1.KogitoInfra, namespace string) []v1alpha1.KogitoInfraVolume {
+	volumes := make([]v1alpha1.KogitoInfraVolume, 0, len(instance.Status.Volumes))
+	for _, volume := range instance.Status.Volumes {
+		volume.SecretName = GetKogitoInfraSecretName(instance, volume.SecretName, namespace)
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
+
+// GetInfraResourceName gets the name of a resource shared by the Kogito services, such as a Kafka topic,
+// applying the resource naming policy of the given KogitoInfra for a service in the given namespace
+func GetInfraResourceName(instance *v1alpha1.KogitoInfra, name, namespace string) string {
+	naming := instance.Spec.ResourceNaming
+	if naming == nil {
+		return name
+	}
+	return strings.ReplaceAll(naming.Prefix, resourceNamingNamespaceReference, namespace) +
+		name +
+		strings.ReplaceAll(naming.Suffix, resourceNamingNamespaceReference, namespace)
+}
+
+// GetInfraResourceNamingAffixes gets the prefix and the suffix of the given KogitoInfra resource naming policy, to be published
+// in its status. The namespace reference is replaced by the KogitoServiceNamespacePlaceholder, resolved for each bound service.
+func GetInfraResourceNamingAffixes(instance *v1alpha1.KogitoInfra) (prefix, suffix string) {
+	naming := instance.Spec.ResourceNaming
+	if naming == nil {
+		return "", ""
+	}
+	return strings.ReplaceAll(naming.Prefix, resourceNamingNamespaceReference, KogitoServiceNamespacePlaceholder),
+		strings.ReplaceAll(naming.Suffix, resourceNamingNamespaceReference, KogitoServiceNamespacePlaceholder)
+}
+
+// IsKafkaResource checks if provided KogitoInfra instance provides a kafka cluster, either a Strimzi resource or an external cluster
 func IsKafkaResource(instance *v1alpha1.KogitoInfra) bool {
+	return IsStrimziKafkaResource(instance) || (instance.Spec.External != nil && instance.Spec.External.Kafka != nil)
+}
+
+// IsStrimziKafkaResource checks if provided KogitoInfra instance is for kafka resource, whose topics are managed through Strimzi
+func IsStrimziKafkaResource(instance *v1alpha1.KogitoInfra) bool {
 	return instance.Spec.Resource.APIVersion == KafkaAPIVersion && instance.Spec.Resource.Kind == KafkaKind
 }
 
@@ -47,3 +192,20 @@ func IsKafkaResource(instance *v1alpha1.KogitoInfra) bool {
 func IsKnativeEventingResource(instance *v1alpha1.KogitoInfra) bool {
 	return instance.Spec.Resource.APIVersion == KnativeEventingAPIVersion && instance.Spec.Resource.Kind == KnativeEventingBrokerKind
 }
+
+// GetKogitoInfraReferences gets the names of the KogitoInfra instances the given Kogito service depends on,
+// either referenced in its infra or bound to its messaging channels
+func GetKogitoInfraReferences(service v1alpha1.KogitoService) []string {
+	var references []string
+	for _, infraName := range service.GetSpec().GetInfra() {
+		if !util.Contains(infraName, references) {
+			references = append(references, infraName)
+		}
+	}
+	for _, channel := range service.GetSpec().GetMessaging().Channels {
+		if !util.Contains(channel.Infra, references) {
+			references = append(references, channel.Infra)
+		}
+	}
+	return references
+}
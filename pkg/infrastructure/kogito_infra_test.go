@@ -0,0 +1,116 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestMustFetchKogitoInfraInstance_OtherNamespace(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-kafka", Namespace: "kogito-infra"},
+		Spec:       v1alpha1.KogitoInfraSpec{AllowedNamespaces: []string{"team-a"}},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+
+	infra, err := MustFetchKogitoInfraInstance(cli, "kogito-infra/kogito-kafka", "team-a")
+	assert.NoError(t, err)
+	assert.Equal(t, "kogito-infra", infra.Namespace)
+
+	_, err = MustFetchKogitoInfraInstance(cli, "kogito-infra/kogito-kafka", "team-b")
+	assert.Error(t, err)
+	_, err = MustFetchKogitoInfraInstance(cli, "kogito-kafka", "team-a")
+	assert.Error(t, err)
+
+	kogitoInfra.Spec.AllowedNamespaces = []string{v1alpha1.AllNamespaces}
+	cli = test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	_, err = MustFetchKogitoInfraInstance(cli, "kogito-infra/kogito-kafka", "team-b")
+	assert.NoError(t, err)
+}
+
+func TestMustFetchKogitoInfraInstance_NamespaceNotWatched(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-kafka", Namespace: "kogito-infra"},
+		Spec:       v1alpha1.KogitoInfraSpec{AllowedNamespaces: []string{v1alpha1.AllNamespaces}},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
+	assert.NoError(t, os.Setenv(k8sutil.WatchNamespaceEnvVar, "team-a"))
+	defer os.Unsetenv(k8sutil.WatchNamespaceEnvVar)
+
+	_, err := MustFetchKogitoInfraInstance(cli, "kogito-infra/kogito-kafka", "team-a")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't watch namespace kogito-infra")
+
+	assert.NoError(t, os.Setenv(k8sutil.WatchNamespaceEnvVar, "team-a, kogito-infra"))
+	_, err = MustFetchKogitoInfraInstance(cli, "kogito-infra/kogito-kafka", "team-a")
+	assert.NoError(t, err)
+}
+
+func TestGetWatchedNamespaces(t *testing.T) {
+	assert.Empty(t, GetWatchedNamespaces(""))
+	assert.Equal(t, []string{"team-a"}, GetWatchedNamespaces("team-a"))
+	assert.Equal(t, []string{"team-a", "kogito-infra"}, GetWatchedNamespaces(" team-a, kogito-infra,"))
+}
+
+func TestIsKafkaResource(t *testing.T) {
+	strimzi := &v1alpha1.KogitoInfra{Spec: v1alpha1.KogitoInfraSpec{Resource: v1alpha1.Resource{APIVersion: KafkaAPIVersion, Kind: KafkaKind}}}
+	assert.True(t, IsKafkaResource(strimzi))
+	assert.True(t, IsStrimziKafkaResource(strimzi))
+
+	external := &v1alpha1.KogitoInfra{Spec: v1alpha1.KogitoInfraSpec{External: &v1alpha1.ExternalInfra{Kafka: &v1alpha1.ExternalKafka{BootstrapServers: "kafka:9092"}}}}
+	assert.True(t, IsKafkaResource(external))
+	assert.False(t, IsStrimziKafkaResource(external))
+
+	externalInfinispan := &v1alpha1.KogitoInfra{Spec: v1alpha1.KogitoInfraSpec{External: &v1alpha1.ExternalInfra{Infinispan: &v1alpha1.ExternalInfinispan{}}}}
+	assert.False(t, IsKafkaResource(externalInfinispan))
+}
+
+func TestGetKogitoInfraKey(t *testing.T) {
+	assert.Equal(t, types.NamespacedName{Name: "kogito-kafka", Namespace: "team-a"}, GetKogitoInfraKey("kogito-kafka", "team-a"))
+	assert.Equal(t, types.NamespacedName{Name: "kogito-kafka", Namespace: "kogito-infra"}, GetKogitoInfraKey("kogito-infra/kogito-kafka", "team-a"))
+}
+
+func TestGetKogitoInfraEnvsAndVolumes_OtherNamespace(t *testing.T) {
+	kogitoInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-kafka", Namespace: "kogito-infra"},
+		Status: v1alpha1.KogitoInfraStatus{
+			Env: []corev1.EnvVar{
+				framework.CreateEnvVar("ENABLE_EVENTS", "true"),
+				framework.CreateSecretEnvVar("KAFKA_PASSWORD", "kogito-kafka-credential", "password"),
+			},
+			Volumes: []v1alpha1.KogitoInfraVolume{{Name: "kogito-kafka-credential", SecretName: "kogito-kafka-credential", MountPath: "/certs"}},
+		},
+	}
+	assert.ElementsMatch(t, []string{"kogito-kafka-credential"}, GetKogitoInfraSecretNames(kogitoInfra))
+
+	envs := GetKogitoInfraEnvs(kogitoInfra, "team-a")
+	assert.Equal(t, framework.CreateEnvVar("ENABLE_EVENTS", "true"), envs[0])
+	assert.Equal(t, "kogito-infra-kogito-kafka-credential", envs[1].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "kogito-kafka-credential", kogitoInfra.Status.Env[1].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "kogito-infra-kogito-kafka-credential", GetKogitoInfraVolumes(kogitoInfra, "team-a")[0].SecretName)
+
+	assert.Equal(t, kogitoInfra.Status.Env, GetKogitoInfraEnvs(kogitoInfra, "kogito-infra"))
+	assert.Equal(t, kogitoInfra.Status.Volumes, GetKogitoInfraVolumes(kogitoInfra, "kogito-infra"))
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	mongodbv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/mongodb/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MongoDBKind CRD Kind for MongoDB replica set (as defined by MongoDB Community Operator)
+	MongoDBKind = "MongoDB"
+
+	// MongoDBInstanceName is the default name for the MongoDB replica set managed by KogitoInfra
+	MongoDBInstanceName = "kogito-mongodb"
+
+	// MongoDBDefaultUser is the user created in the default MongoDB replica set managed by KogitoInfra
+	MongoDBDefaultUser = "kogito"
+	// MongoDBDefaultDatabase is the database used by the Kogito services
+	MongoDBDefaultDatabase = "kogito"
+	// MongoDBSecretPasswordKey is the default secret password key referenced by the MongoDB users
+	MongoDBSecretPasswordKey = "password"
+
+	mongoDBDefaultAuthDatabase = "admin"
+	mongoDBDefaultVersion      = "4.2.6"
+	mongoDBURIScheme           = "mongodb://"
+)
+
+var (
+	// MongoDBAPIVersion CRD API group version for MongoDB replica set (as defined by MongoDB Community Operator)
+	MongoDBAPIVersion = mongodbv1.SchemeGroupVersion.String()
+)
+
+// IsMongoDBAvailable checks whether MongoDB CRD is available or not
+func IsMongoDBAvailable(cli *client.Client) bool {
+	return cli.HasServerGroup(mongodbv1.SchemeGroupVersion.Group)
+}
+
+// GetMongoDBDefaultResource returns a MongoDB replica set with a single member and the default Kogito user,
+// authenticated with the password stored in the given Secret
+func GetMongoDBDefaultResource(name, namespace, passwordSecretName string) *mongodbv1.MongoDB {
+	return &mongodbv1.MongoDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: mongodbv1.MongoDBSpec{
+			Members: 1,
+			Type:    mongodbv1.MongoDBReplicaSetType,
+			Version: mongoDBDefaultVersion,
+			Security: mongodbv1.Security{
+				Authentication: mongodbv1.Authentication{Modes: []mongodbv1.AuthMode{mongodbv1.MongoDBScramAuthMode}},
+			},
+			Users: []mongodbv1.MongoDBUser{
+				{
+					Name:              MongoDBDefaultUser,
+					DB:                mongoDBDefaultAuthDatabase,
+					PasswordSecretRef: mongodbv1.SecretKeyReference{Name: passwordSecretName, Key: MongoDBSecretPasswordKey},
+					Roles: []mongodbv1.Role{
+						{Name: "dbOwner", DB: MongoDBDefaultDatabase},
+					},
+					ScramCredentialsSecretName: MongoDBDefaultUser,
+				},
+			},
+		},
+	}
+}
+
+// GetMongoDBUser gets the user used by the Kogito services in the given MongoDB replica set.
+// The default Kogito user is preferred, otherwise the first user is taken.
+func GetMongoDBUser(mongoDB *mongodbv1.MongoDB) (*mongodbv1.MongoDBUser, error) {
+	if len(mongoDB.Spec.Users) == 0 {
+		return nil, fmt.Errorf("no user defined in the MongoDB instance %s", mongoDB.Name)
+	}
+	for _, user := range mongoDB.Spec.Users {
+		if user.Name == MongoDBDefaultUser {
+			return &user, nil
+		}
+	}
+	return &mongoDB.Spec.Users[0], nil
+}
+
+// GetMongoDBConnectionString builds the connection string to the given MongoDB replica set with the user credentials
+func GetMongoDBConnectionString(mongoDB *mongodbv1.MongoDB, user *mongodbv1.MongoDBUser, password string) (string, error) {
+	if !strings.HasPrefix(mongoDB.Status.MongoURI, mongoDBURIScheme) {
+		return "", fmt.Errorf("MongoDB instance %s has no valid URI in its status: %s", mongoDB.Name, mongoDB.Status.MongoURI)
+	}
+	hosts := strings.TrimSuffix(strings.TrimPrefix(mongoDB.Status.MongoURI, mongoDBURIScheme), "/")
+	authDB := user.DB
+	if len(authDB) == 0 {
+		authDB = mongoDBDefaultAuthDatabase
+	}
+	return fmt.Sprintf("%s%s@%s/?authSource=%s&replicaSet=%s",
+		mongoDBURIScheme, url.UserPassword(user.Name, password).String(), hosts, authDB, mongoDB.Name), nil
+}
+
+// GetMongoDBDatabase gets the database used by the Kogito services, that is the first database the given user has a role on,
+// apart from the authentication database
+func GetMongoDBDatabase(user *mongodbv1.MongoDBUser) string {
+	for _, role := range user.Roles {
+		if len(role.DB) > 0 && role.DB != mongoDBDefaultAuthDatabase {
+			return role.DB
+		}
+	}
+	return MongoDBDefaultDatabase
+}
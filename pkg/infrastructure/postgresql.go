@@ -0,0 +1,104 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	postgresqlv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/postgresql/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PostgresqlKind CRD Kind for PostgreSQL cluster (as defined by Zalando PostgreSQL Operator)
+	PostgresqlKind = "postgresql"
+
+	// PostgresqlInstanceName is the default name for the PostgreSQL cluster managed by KogitoInfra.
+	// The name of a PostgreSQL cluster must start with the team ID.
+	PostgresqlInstanceName = postgresqlDefaultTeamID + "-postgresql"
+
+	// PostgresqlSecretUsernameKey is the secret username key set in the PostgreSQL operator credential secret
+	PostgresqlSecretUsernameKey = "username"
+	// PostgresqlSecretPasswordKey is the secret password key set in the PostgreSQL operator credential secret
+	PostgresqlSecretPasswordKey = "password"
+	// PostgresqlDefaultPort default PostgreSQL port
+	PostgresqlDefaultPort = 5432
+
+	postgresqlDefaultTeamID   = "kogito"
+	postgresqlDefaultUser     = "kogito"
+	postgresqlDefaultDatabase = "kogito"
+	postgresqlDefaultVersion  = "12"
+	postgresqlDefaultVolume   = "1Gi"
+	// postgresqlCredentialSecretFormat is the format of the credential Secrets created by the PostgreSQL operator: user.cluster.credentials...
+	postgresqlCredentialSecretFormat = "%s.%s.credentials.postgresql.acid.zalan.do"
+)
+
+var (
+	// PostgresqlAPIVersion CRD API group version for PostgreSQL cluster (as defined by Zalando PostgreSQL Operator)
+	PostgresqlAPIVersion = postgresqlv1.SchemeGroupVersion.String()
+)
+
+// IsPostgresqlAvailable checks whether PostgreSQL CRD is available or not
+func IsPostgresqlAvailable(cli *client.Client) bool {
+	return cli.HasServerGroup(postgresqlv1.SchemeGroupVersion.Group)
+}
+
+// GetPostgresqlDefaultResource returns a PostgreSQL cluster with a single instance, owning the default Kogito database
+func GetPostgresqlDefaultResource(name, namespace string) *postgresqlv1.Postgresql {
+	return &postgresqlv1.Postgresql{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: postgresqlv1.PostgresqlSpec{
+			TeamID:            postgresqlDefaultTeamID,
+			NumberOfInstances: 1,
+			Volume:            postgresqlv1.Volume{Size: postgresqlDefaultVolume},
+			PostgresqlParam:   postgresqlv1.PostgresqlParam{PgVersion: postgresqlDefaultVersion},
+			Users:             map[string]postgresqlv1.UserFlags{postgresqlDefaultUser: {}},
+			Databases:         map[string]string{postgresqlDefaultDatabase: postgresqlDefaultUser},
+		},
+	}
+}
+
+// GetPostgresqlDatabase gets the database used by the Kogito services and its owner in the given PostgreSQL cluster.
+// The default Kogito database is preferred, otherwise the first database in alphabetical order is taken.
+func GetPostgresqlDatabase(postgresql *postgresqlv1.Postgresql) (database, owner string, err error) {
+	if owner, ok := postgresql.Spec.Databases[postgresqlDefaultDatabase]; ok {
+		return postgresqlDefaultDatabase, owner, nil
+	}
+	var databases []string
+	for database := range postgresql.Spec.Databases {
+		databases = append(databases, database)
+	}
+	if len(databases) == 0 {
+		return "", "", fmt.Errorf("no database defined in the PostgreSQL cluster %s", postgresql.Name)
+	}
+	sort.Strings(databases)
+	return databases[0], postgresql.Spec.Databases[databases[0]], nil
+}
+
+// GetPostgresqlCredentialSecretName gets the name of the Secret created by the PostgreSQL operator holding the credentials of the given user
+func GetPostgresqlCredentialSecretName(postgresql *postgresqlv1.Postgresql, username string) string {
+	return fmt.Sprintf(postgresqlCredentialSecretFormat, strings.ReplaceAll(username, "_", "-"), postgresql.Name)
+}
+
+// GetPostgresqlServiceURI gets the host:port of the primary service of the given PostgreSQL cluster
+func GetPostgresqlServiceURI(postgresql *postgresqlv1.Postgresql) string {
+	return fmt.Sprintf("%s.%s:%d", postgresql.Name, postgresql.Namespace, PostgresqlDefaultPort)
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"testing"
+
+	postgresqlv1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/postgresql/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPostgresqlDatabase(t *testing.T) {
+	tests := []struct {
+		name      string
+		databases map[string]string
+		database  string
+		owner     string
+		wantErr   bool
+	}{
+		{"Default database", map[string]string{"app": "app_owner", "kogito": "kogito"}, "kogito", "kogito", false},
+		{"First database", map[string]string{"orders": "orders_owner", "app": "app_owner"}, "app", "app_owner", false},
+		{"No database", nil, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postgresql := &postgresqlv1.Postgresql{
+				ObjectMeta: metav1.ObjectMeta{Name: "acid-cluster"},
+				Spec:       postgresqlv1.PostgresqlSpec{Databases: tt.databases},
+			}
+			database, owner, err := GetPostgresqlDatabase(postgresql)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.database, database)
+			assert.Equal(t, tt.owner, owner)
+		})
+	}
+}
+
+func TestGetPostgresqlCredentialSecretName(t *testing.T) {
+	postgresql := &postgresqlv1.Postgresql{ObjectMeta: metav1.ObjectMeta{Name: "acid-cluster"}}
+	assert.Equal(t, "app-owner.acid-cluster.credentials.postgresql.acid.zalan.do", GetPostgresqlCredentialSecretName(postgresql, "app_owner"))
+}
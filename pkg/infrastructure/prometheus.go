@@ -0,0 +1,100 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	monv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/operator"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PrometheusKind CRD Kind for Prometheus instances (as defined by Prometheus Operator)
+	PrometheusKind = "Prometheus"
+
+	// PrometheusInstanceName is the default name for the Prometheus instance managed by KogitoInfra,
+	// also used for its ServiceAccount, Role and RoleBinding
+	PrometheusInstanceName = "kogito-prometheus"
+
+	// PrometheusOperatedServiceName is the Service created by Prometheus Operator in front of the Prometheus instances of a namespace
+	PrometheusOperatedServiceName = "prometheus-operated"
+	// PrometheusDefaultPort is the port of the Prometheus web server
+	PrometheusDefaultPort = 9090
+
+	monitoringLabelKey = "name"
+)
+
+var (
+	// PrometheusAPIVersion CRD API group version for Prometheus instances (as defined by Prometheus Operator)
+	PrometheusAPIVersion = monv1.SchemeGroupVersion.String()
+)
+
+// IsPrometheusAvailable checks if Prometheus CRD is available in the cluster
+func IsPrometheusAvailable(cli *client.Client) bool {
+	return cli.HasServerGroup(monv1.SchemeGroupVersion.Group)
+}
+
+// GetMonitoringLabels gets the labels set on the ServiceMonitors, PrometheusRules and Grafana dashboards created for the Kogito services,
+// selected by the Prometheus and Grafana instances managed by KogitoInfra
+func GetMonitoringLabels() map[string]string {
+	return map[string]string{monitoringLabelKey: operator.Name}
+}
+
+// GetPrometheusDefaultResource returns a Prometheus instance scraping the ServiceMonitors and evaluating the PrometheusRules
+// created for the Kogito services of its namespace
+func GetPrometheusDefaultResource(name, namespace string) *monv1.Prometheus {
+	return &monv1.Prometheus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: monv1.PrometheusSpec{
+			ServiceAccountName:     name,
+			ServiceMonitorSelector: &metav1.LabelSelector{MatchLabels: GetMonitoringLabels()},
+			RuleSelector:           &metav1.LabelSelector{MatchLabels: GetMonitoringLabels()},
+		},
+	}
+}
+
+// GetPrometheusDefaultRBACResources returns the ServiceAccount, Role and RoleBinding granting the Prometheus instance with the given name
+// the permissions to discover the targets of the ServiceMonitors in its namespace
+func GetPrometheusDefaultRBACResources(name, namespace string) (*corev1.ServiceAccount, *rbacv1.Role, *rbacv1.RoleBinding) {
+	objectMeta := metav1.ObjectMeta{Name: name, Namespace: namespace}
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: objectMeta}
+	role := &rbacv1.Role{
+		ObjectMeta: objectMeta,
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"services", "endpoints", "pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: objectMeta,
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace}},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+	}
+	return serviceAccount, role, roleBinding
+}
+
+// IsPrometheusReady checks if the given Prometheus instance has available replicas
+func IsPrometheusReady(prometheus *monv1.Prometheus) bool {
+	return prometheus.Status != nil && prometheus.Status.AvailableReplicas > 0
+}
@@ -33,6 +33,8 @@ const (
 
 	// AppPropContentHashKey is the annotation key for the content hash of application.properties
 	AppPropContentHashKey = "appPropContentHash"
+	// AppPropManagedKeysKey is the annotation key for the application.properties keys injected by the operator
+	AppPropManagedKeysKey = "appPropManagedKeys"
 	// AppPropVolumeName is the name of the volume for application.properties
 	AppPropVolumeName = "app-prop-config"
 
@@ -42,6 +44,7 @@ const (
 	appPropFilePath                 = "/home/kogito/config"
 
 	appPropConcatPattern = "%s\n%s=%s"
+	appPropKeySeparator  = ","
 )
 
 // getAppPropConfigMapContentHash calculates the hash of the application.properties contents in the ConfigMap
@@ -56,9 +59,19 @@ func getAppPropConfigMapContentHash(service v1alpha1.KogitoService, appProps map
 	}
 
 	appPropsToApply := getAppPropsFromConfigMap(configMap, exist)
-	if err = mergo.Merge(&appPropsToApply, appProps, mergo.WithOverride); err != nil {
-		return "", nil, err
+	var managedKeys []string
+	if len(service.GetSpec().GetPropertiesConfigMap()) > 0 {
+		managedKeys = mergeUserAppProps(appPropsToApply, appProps, getAppPropManagedKeys(configMap))
+	} else {
+		removeStaleAppProps(appPropsToApply, appProps, getAppPropManagedKeys(configMap))
+		if err = mergo.Merge(&appPropsToApply, appProps, mergo.WithOverride); err != nil {
+			return "", nil, err
+		}
+		for key := range appProps {
+			managedKeys = append(managedKeys, key)
+		}
 	}
+	setAppPropManagedKeys(configMap, managedKeys)
 
 	appPropContent := defaultAppPropContent
 	if len(appPropsToApply) > 0 {
@@ -80,6 +93,56 @@ func getAppPropConfigMapContentHash(service v1alpha1.KogitoService, appProps map
 	return contentHash, configMap, nil
 }
 
+// mergeUserAppProps does a three-way merge of the properties managed by the operator into a ConfigMap provided by the user.
+// Keys previously injected by the operator that are not required anymore are removed, keys authored by the user are never overwritten
+// nor claimed by the operator, even if they hold the same value.
+// Returns the keys managed by the operator after the merge.
+func mergeUserAppProps(deployedAppProps, appProps map[string]string, previousManagedKeys map[string]bool) (managedKeys []string) {
+	removeStaleAppProps(deployedAppProps, appProps, previousManagedKeys)
+	for key, value := range appProps {
+		if _, exists := deployedAppProps[key]; exists && !previousManagedKeys[key] {
+			log.Debugf("Property %s is defined by the user in the provided ConfigMap, skipping it", key)
+			continue
+		}
+		deployedAppProps[key] = value
+		managedKeys = append(managedKeys, key)
+	}
+	return managedKeys
+}
+
+// removeStaleAppProps removes from the deployed properties the keys previously injected by the operator that are not required anymore
+func removeStaleAppProps(deployedAppProps, appProps map[string]string, previousManagedKeys map[string]bool) {
+	for key := range previousManagedKeys {
+		if _, required := appProps[key]; !required {
+			delete(deployedAppProps, key)
+		}
+	}
+}
+
+// getAppPropManagedKeys reads the application.properties keys managed by the operator from the ConfigMap annotations
+func getAppPropManagedKeys(configMap *corev1.ConfigMap) map[string]bool {
+	managedKeys := map[string]bool{}
+	if value, ok := configMap.Annotations[AppPropManagedKeysKey]; ok && len(value) > 0 {
+		for _, key := range strings.Split(value, appPropKeySeparator) {
+			managedKeys[key] = true
+		}
+	}
+	return managedKeys
+}
+
+// setAppPropManagedKeys records the application.properties keys managed by the operator in the ConfigMap annotations
+func setAppPropManagedKeys(configMap *corev1.ConfigMap, managedKeys []string) {
+	if len(managedKeys) == 0 {
+		delete(configMap.Annotations, AppPropManagedKeysKey)
+		return
+	}
+	sort.Strings(managedKeys)
+	if configMap.Annotations == nil {
+		configMap.Annotations = map[string]string{}
+	}
+	configMap.Annotations[AppPropManagedKeysKey] = strings.Join(managedKeys, appPropKeySeparator)
+}
+
 // getAppPropConfigMapName gets the name of the config map for application.properties
 func getAppPropConfigMapName(service v1alpha1.KogitoService) string {
 	if len(service.GetSpec().GetPropertiesConfigMap()) > 0 {
@@ -127,7 +190,7 @@ func getAppPropsFromConfigMap(configMap *corev1.ConfigMap, exist bool) map[strin
 		if _, ok := configMap.Data[ConfigMapApplicationPropertyKey]; ok {
 			props := strings.Split(configMap.Data[ConfigMapApplicationPropertyKey], "\n")
 			for _, p := range props {
-				ps := strings.Split(p, "=")
+				ps := strings.SplitN(p, "=", 2)
 				if len(ps) > 1 {
 					appProps[strings.TrimSpace(ps[0])] = strings.TrimSpace(ps[1])
 				}
@@ -18,6 +18,7 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -135,8 +136,9 @@ func TestGetAppPropConfigMapContentHash(t *testing.T) {
 			"bb2bea2d5b08e3d93142da5b17ed2af0",
 			&corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      service.Name + appPropConfigMapSuffix,
-					Namespace: service.Namespace,
+					Name:        service.Name + appPropConfigMapSuffix,
+					Namespace:   service.Namespace,
+					Annotations: map[string]string{AppPropManagedKeysKey: "test1,test2,test3"},
 				},
 				Data: map[string]string{
 					ConfigMapApplicationPropertyKey: "\ntest1=abc\ntest2=def\ntest3=ghi",
@@ -202,8 +204,9 @@ func TestGetAppPropConfigMapContentHash(t *testing.T) {
 					APIVersion: "v1",
 				},
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      service.Name + appPropConfigMapSuffix,
-					Namespace: service.Namespace,
+					Name:        service.Name + appPropConfigMapSuffix,
+					Namespace:   service.Namespace,
+					Annotations: map[string]string{AppPropManagedKeysKey: "test1,test2,test3,test7"},
 				},
 				Data: map[string]string{
 					ConfigMapApplicationPropertyKey: "\ntest1=abc\ntest2=def\ntest3=ghi\ntest4=012\ntest5=345\ntest7=jkl",
@@ -230,6 +233,41 @@ func TestGetAppPropConfigMapContentHash(t *testing.T) {
 	}
 }
 
+func TestGetAppPropConfigMapContentHash_CustomConfigMap(t *testing.T) {
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{PropertiesConfigMap: "custom-props"},
+		},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "custom-props",
+			Namespace:   service.Namespace,
+			Annotations: map[string]string{AppPropManagedKeysKey: "infra1,infra2"},
+		},
+		Data: map[string]string{
+			ConfigMapApplicationPropertyKey: "\ninfra1=old\ninfra2=removed\nuser1=mine\nuser2=same",
+		},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(cm).Build()
+
+	_, configMap, err := getAppPropConfigMapContentHash(service, map[string]string{
+		"infra1": "new",
+		"infra3": "added",
+		"user1":  "operator",
+		"user2":  "same",
+	}, cli)
+	assert.NoError(t, err)
+	assert.Equal(t, "\ninfra1=new\ninfra3=added\nuser1=mine\nuser2=same", configMap.Data[ConfigMapApplicationPropertyKey])
+	assert.Equal(t, "infra1,infra3", configMap.Annotations[AppPropManagedKeysKey])
+
+	_, configMap, err = getAppPropConfigMapContentHash(service, map[string]string{}, test.NewFakeClientBuilder().AddK8sObjects(configMap).Build())
+	assert.NoError(t, err)
+	assert.Equal(t, "\nuser1=mine\nuser2=same", configMap.Data[ConfigMapApplicationPropertyKey])
+	assert.NotContains(t, configMap.Annotations, AppPropManagedKeysKey)
+}
+
 func Test_getAppPropsFromConfigMap(t *testing.T) {
 	type args struct {
 		configMap *corev1.ConfigMap
@@ -284,6 +322,21 @@ func Test_getAppPropsFromConfigMap(t *testing.T) {
 				"test3": "test3",
 			},
 		},
+		{
+			"With Property value containing equals",
+			args{
+				&corev1.ConfigMap{
+					Data: map[string]string{
+						ConfigMapApplicationPropertyKey: "\ntest1=test1\ntest2=key=\"value\";",
+					},
+				},
+				true,
+			},
+			map[string]string{
+				"test1": "test1",
+				"test2": "key=\"value\";",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
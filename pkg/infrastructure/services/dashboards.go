@@ -19,15 +19,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"strings"
 	"time"
 
+	"github.com/RHsyseng/operator-utils/pkg/resource"
 	grafanav1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -36,6 +39,10 @@ import (
 type GrafanaDashboard struct {
 	Name             string
 	RawJSONDashboard string
+	// ConfigMapRef references the ConfigMap key holding the dashboard JSON, used instead of RawJSONDashboard
+	ConfigMapRef *corev1.ConfigMapKeySelector
+	// Missing marks a dashboard listed by the service that couldn't be fetched, its deployed GrafanaDashboard is kept as is
+	Missing bool
 }
 
 const (
@@ -43,23 +50,32 @@ const (
 	dashboardsPath = "/monitoring/dashboards/"
 )
 
-func fetchGrafanaDashboards(cli *client.Client, instance v1alpha1.KogitoService) ([]GrafanaDashboard, error) {
+// fetchGrafanaDashboards fetches the dashboards exposed by the service and the ones provided by the user in ConfigMaps.
+// resolved is false while the service deployment is not available, meaning that the deployed dashboards must be left untouched.
+func fetchGrafanaDashboards(cli *client.Client, instance v1alpha1.KogitoService) (dashboards []GrafanaDashboard, resolved bool, err error) {
 	available, err := IsDeploymentAvailable(cli, instance)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if !available {
 		log.Debugf("Deployment not available yet for KogitoService %s ", instance.GetName())
-		return nil, nil
+		return nil, false, nil
 	}
 
 	svcURL := infrastructure.GetKogitoServiceEndpoint(instance)
 	dashboardNames, err := fetchGrafanaDashboardNamesForURL(svcURL)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if dashboards, err = fetchDashboards(svcURL, dashboardNames); err != nil {
+		return nil, false, err
 	}
 
-	return fetchDashboards(svcURL, dashboardNames)
+	configMapDashboards, err := fetchConfigMapDashboards(cli, instance)
+	if err != nil {
+		return nil, false, err
+	}
+	return append(dashboards, configMapDashboards...), true, nil
 }
 
 func fetchGrafanaDashboardNamesForURL(serverURL string) ([]string, error) {
@@ -87,62 +103,125 @@ func fetchGrafanaDashboardNamesForURL(serverURL string) ([]string, error) {
 func fetchDashboards(serverURL string, dashboardNames []string) ([]GrafanaDashboard, error) {
 	var dashboards []GrafanaDashboard
 	for _, name := range dashboardNames {
-		dashboardURL := fmt.Sprintf("%s%s%s", serverURL, dashboardsPath, name)
-		resp, err := http.Get(dashboardURL)
+		dashboard, err := fetchDashboard(serverURL, name)
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode == http.StatusNotFound {
-			log.Debugf("Dashboard %s not found, ignoring the resource.", name)
-			return nil, nil
-		}
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("Received NOT expected status code %d while making GET request to %s ", resp.StatusCode, dashboardURL)
+		dashboards = append(dashboards, *dashboard)
+	}
+	return dashboards, nil
+}
+
+// fetchDashboard fetches the given dashboard from the service, marking it as missing if the service doesn't expose it
+func fetchDashboard(serverURL string, name string) (*GrafanaDashboard, error) {
+	dashboardURL := fmt.Sprintf("%s%s%s", serverURL, dashboardsPath, name)
+	resp, err := http.Get(dashboardURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		log.Warnf("Dashboard %s listed by the service not found, keeping the deployed one.", name)
+		return &GrafanaDashboard{Name: name, Missing: true}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Received NOT expected status code %d while making GET request to %s ", resp.StatusCode, dashboardURL)
+	}
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read dashboard %s from %s: %v ", name, dashboardURL, err)
+	}
+	return &GrafanaDashboard{Name: name, RawJSONDashboard: string(bodyBytes)}, nil
+}
+
+// fetchConfigMapDashboards gets the dashboards provided by the user in ConfigMaps of the service namespace.
+// Dashboards referencing a missing ConfigMap or key are ignored until they're created.
+func fetchConfigMapDashboards(cli *client.Client, instance v1alpha1.KogitoService) ([]GrafanaDashboard, error) {
+	var dashboards []GrafanaDashboard
+	for _, dashboard := range instance.GetSpec().GetMonitoring().Dashboards {
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: dashboard.ConfigMapName, Namespace: instance.GetNamespace()}}
+		if exists, err := kubernetes.ResourceC(cli).Fetch(configMap); err != nil {
+			return nil, err
+		} else if !exists {
+			log.Warnf("ConfigMap %s holding dashboard %s not found, ignoring the dashboard", dashboard.ConfigMapName, dashboard.Key)
+			continue
 		}
-		bodyBytes, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			log.Fatal(err)
+		if _, ok := configMap.Data[dashboard.Key]; !ok {
+			log.Warnf("Key %s not found in ConfigMap %s, ignoring the dashboard", dashboard.Key, dashboard.ConfigMapName)
+			continue
 		}
-		dashboards = append(dashboards, GrafanaDashboard{Name: name, RawJSONDashboard: string(bodyBytes)})
+		dashboards = append(dashboards, GrafanaDashboard{
+			Name: dashboard.Key,
+			ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: dashboard.ConfigMapName},
+				Key:                  dashboard.Key,
+			},
+		})
 	}
 	return dashboards, nil
 }
 
 func configureGrafanaDashboards(client *client.Client, kogitoService v1alpha1.KogitoService, scheme *runtime.Scheme, namespace string) (time.Duration, error) {
-	dashboards, err := fetchGrafanaDashboards(client, kogitoService)
+	if !infrastructure.IsGrafanaAvailable(client) {
+		log.Debugf("Grafana Operator is not available in the cluster, not going to deploy dashboards for %s", kogitoService.GetName())
+		return 0, nil
+	}
+	dashboards, resolved, err := fetchGrafanaDashboards(client, kogitoService)
 	if err != nil {
 		return reconciliationPeriodAfterDashboardsError, err
 	}
+	if !resolved {
+		return 0, nil
+	}
 
 	reconcileAfter, err := deployGrafanaDashboards(dashboards, client, kogitoService, scheme, namespace)
 
 	return reconcileAfter, err
 }
 
+// deployGrafanaDashboards creates, updates or deletes the GrafanaDashboards owned by the service to match the given dashboards
 func deployGrafanaDashboards(dashboards []GrafanaDashboard, cli *client.Client, kogitoService v1alpha1.KogitoService, scheme *runtime.Scheme, namespace string) (time.Duration, error) {
+	var requested []resource.KubernetesResource
 	for _, dashboard := range dashboards {
-		resourceName := strings.ReplaceAll(strings.ToLower(dashboard.Name), ".json", "")
-		dashboardDefinition := &grafanav1.GrafanaDashboard{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      resourceName,
-				Namespace: namespace,
-				Labels: map[string]string{
-					framework.LabelAppKey: kogitoService.GetName(),
-				},
-			},
-			Spec: grafanav1.GrafanaDashboardSpec{
-				Json: dashboard.RawJSONDashboard,
-				Name: dashboard.Name,
-			},
-		}
-		isCreated, err := kubernetes.ResourceC(cli).CreateIfNotExistsForOwner(dashboardDefinition, kogitoService, scheme)
-		if err != nil {
-			log.Warnf("Error occurs while creating dashboard %s, not going to reconcile the resource: %v", dashboard.Name, err)
+		dashboardDefinition := createGrafanaDashboard(dashboard, kogitoService, namespace)
+		if dashboard.Missing {
+			if exists, err := kubernetes.ResourceC(cli).Fetch(dashboardDefinition); err != nil {
+				return reconciliationPeriodAfterDashboardsError, err
+			} else if exists && metav1.IsControlledBy(dashboardDefinition, kogitoService) {
+				requested = append(requested, dashboardDefinition)
+			}
+			continue
 		}
-		if isCreated {
-			log.Infof("Successfully created grafana dashboard %s", dashboard.Name)
+		if err := framework.SetOwner(kogitoService, scheme, dashboardDefinition); err != nil {
+			return reconciliationPeriodAfterDashboardsError, err
 		}
+		requested = append(requested, dashboardDefinition)
+	}
+
+	if err := syncOwnedResources(cli, kogitoService, namespace, &grafanav1.GrafanaDashboardList{}, reflect.TypeOf(grafanav1.GrafanaDashboard{}),
+		requested, framework.CreateGrafanaDashboardComparator()); err != nil {
+		return reconciliationPeriodAfterDashboardsError, err
 	}
 	return 0, nil
 }
+
+func createGrafanaDashboard(dashboard GrafanaDashboard, kogitoService v1alpha1.KogitoService, namespace string) *grafanav1.GrafanaDashboard {
+	resourceName := getServiceResourceName(kogitoService, strings.ReplaceAll(strings.ToLower(dashboard.Name), ".json", ""))
+	if dashboard.ConfigMapRef != nil {
+		resourceName = getServiceResourceName(kogitoService, dashboard.ConfigMapRef.Name+"-"+strings.TrimSuffix(dashboard.ConfigMapRef.Key, ".json"))
+	}
+	dashboardLabels := infrastructure.GetMonitoringLabels()
+	dashboardLabels[framework.LabelAppKey] = kogitoService.GetName()
+	return &grafanav1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName,
+			Namespace: namespace,
+			Labels:    dashboardLabels,
+		},
+		Spec: grafanav1.GrafanaDashboardSpec{
+			Json:         dashboard.RawJSONDashboard,
+			Name:         dashboard.Name,
+			ConfigMapRef: dashboard.ConfigMapRef,
+		},
+	}
+}
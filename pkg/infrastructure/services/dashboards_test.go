@@ -23,6 +23,7 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -73,6 +74,48 @@ func Test_fetchDashboards(t *testing.T) {
 	assert.Equal(t, fetchedDashboardNames[0], dashboards[0].Name)
 }
 
+func Test_fetchDashboards_MissingDashboard(t *testing.T) {
+	server := mockKogitoSvcReplies(t, serverHandler{Path: dashboardsPath + "dashboard2.json", JSONResponse: "mydashboard2"})
+	defer server.Close()
+
+	dashboards, err := fetchDashboards(server.URL, []string{"dashboard1.json", "dashboard2.json"})
+	assert.NoError(t, err)
+	assert.Len(t, dashboards, 2)
+	assert.Equal(t, "dashboard1.json", dashboards[0].Name)
+	assert.True(t, dashboards[0].Missing)
+	assert.Equal(t, "dashboard2.json", dashboards[1].Name)
+	assert.False(t, dashboards[1].Missing)
+}
+
+func Test_fetchConfigMapDashboards(t *testing.T) {
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "my-kogito-runtime", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Monitoring: v1alpha1.Monitoring{
+					Dashboards: []v1alpha1.MonitoringDashboard{
+						{ConfigMapName: "my-dashboards", Key: "orders.json"},
+						{ConfigMapName: "my-dashboards", Key: "missing.json"},
+						{ConfigMapName: "missing-dashboards", Key: "orders.json"},
+					},
+				},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "my-dashboards", Namespace: t.Name()},
+		Data:       map[string]string{"orders.json": "{}"},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(service, configMap).Build()
+
+	dashboards, err := fetchConfigMapDashboards(cli, service)
+	assert.NoError(t, err)
+	assert.Len(t, dashboards, 1)
+	assert.Equal(t, "orders.json", dashboards[0].Name)
+	assert.Equal(t, "my-dashboards", dashboards[0].ConfigMapRef.Name)
+	assert.Equal(t, "orders.json", dashboards[0].ConfigMapRef.Key)
+}
+
 func Test_serviceDeployer_DeployGrafanaDashboards(t *testing.T) {
 	replicas := int32(1)
 	service := &v1alpha1.KogitoRuntime{
@@ -103,7 +146,7 @@ func Test_serviceDeployer_DeployGrafanaDashboards(t *testing.T) {
 
 	dashboard := &grafanav1.GrafanaDashboard{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "mydashboard",
+			Name:      "my-kogito-runtime-mydashboard",
 			Namespace: t.Name(),
 		},
 	}
@@ -111,9 +154,88 @@ func Test_serviceDeployer_DeployGrafanaDashboards(t *testing.T) {
 
 	dashboard = &grafanav1.GrafanaDashboard{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "myseconddashboard",
+			Name:      "my-kogito-runtime-myseconddashboard",
 			Namespace: t.Name(),
 		},
 	}
 	test.AssertFetchMustExist(t, cli, dashboard)
 }
+
+func Test_serviceDeployer_DeployGrafanaDashboards_UpdateAndDelete(t *testing.T) {
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "my-kogito-runtime", Namespace: t.Name(), UID: test.GenerateUID()},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(service).Build()
+
+	dashboards := []GrafanaDashboard{
+		{Name: "mydashboard.json", RawJSONDashboard: "[]"},
+		{Name: "myseconddashboard.json", RawJSONDashboard: "[]"},
+	}
+	_, err := deployGrafanaDashboards(dashboards, cli, service, meta.GetRegisteredSchema(), t.Name())
+	assert.NoError(t, err)
+
+	// new image version updating the first dashboard, removing the second one and a dashboard provided in a ConfigMap
+	dashboards = []GrafanaDashboard{
+		{Name: "mydashboard.json", RawJSONDashboard: `[{"title": "new"}]`},
+		{
+			Name: "orders.json",
+			ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "my-dashboards"},
+				Key:                  "orders.json",
+			},
+		},
+	}
+	reconcileAfter, err := deployGrafanaDashboards(dashboards, cli, service, meta.GetRegisteredSchema(), t.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), reconcileAfter)
+
+	dashboard := &grafanav1.GrafanaDashboard{ObjectMeta: metav1.ObjectMeta{Name: "my-kogito-runtime-mydashboard", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, cli, dashboard)
+	assert.Equal(t, `[{"title": "new"}]`, dashboard.Spec.Json)
+	test.AssertFetchMustNotExist(t, cli, &grafanav1.GrafanaDashboard{ObjectMeta: metav1.ObjectMeta{Name: "my-kogito-runtime-myseconddashboard", Namespace: t.Name()}})
+	dashboard = &grafanav1.GrafanaDashboard{ObjectMeta: metav1.ObjectMeta{Name: "my-kogito-runtime-my-dashboards-orders", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, cli, dashboard)
+	assert.Equal(t, "my-dashboards", dashboard.Spec.ConfigMapRef.Name)
+}
+
+func Test_serviceDeployer_DeployGrafanaDashboards_MissingDashboard(t *testing.T) {
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "my-kogito-runtime", Namespace: t.Name(), UID: test.GenerateUID()},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(service).Build()
+
+	dashboards := []GrafanaDashboard{
+		{Name: "mydashboard.json", RawJSONDashboard: "[]"},
+		{Name: "myseconddashboard.json", RawJSONDashboard: "[]"},
+	}
+	_, err := deployGrafanaDashboards(dashboards, cli, service, meta.GetRegisteredSchema(), t.Name())
+	assert.NoError(t, err)
+
+	// the service fails to serve the first dashboard, still listed, and doesn't list the second one anymore
+	dashboards = []GrafanaDashboard{{Name: "mydashboard.json", Missing: true}}
+	_, err = deployGrafanaDashboards(dashboards, cli, service, meta.GetRegisteredSchema(), t.Name())
+	assert.NoError(t, err)
+
+	dashboard := &grafanav1.GrafanaDashboard{ObjectMeta: metav1.ObjectMeta{Name: "my-kogito-runtime-mydashboard", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, cli, dashboard)
+	assert.Equal(t, "[]", dashboard.Spec.Json)
+	test.AssertFetchMustNotExist(t, cli, &grafanav1.GrafanaDashboard{ObjectMeta: metav1.ObjectMeta{Name: "my-kogito-runtime-myseconddashboard", Namespace: t.Name()}})
+}
+
+func Test_serviceDeployer_DeployGrafanaDashboards_SameDashboardOfTwoServices(t *testing.T) {
+	travels := &v1alpha1.KogitoRuntime{ObjectMeta: v1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()}}
+	visas := &v1alpha1.KogitoRuntime{ObjectMeta: v1.ObjectMeta{Name: "visas", Namespace: t.Name(), UID: test.GenerateUID()}}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(travels, visas).Build()
+
+	dashboards := []GrafanaDashboard{{Name: "domain-dashboard.json", RawJSONDashboard: "[]"}}
+	_, err := deployGrafanaDashboards(dashboards, cli, travels, meta.GetRegisteredSchema(), t.Name())
+	assert.NoError(t, err)
+	_, err = deployGrafanaDashboards(dashboards, cli, visas, meta.GetRegisteredSchema(), t.Name())
+	assert.NoError(t, err)
+
+	for _, service := range []v1alpha1.KogitoService{travels, visas} {
+		dashboard := &grafanav1.GrafanaDashboard{ObjectMeta: metav1.ObjectMeta{Name: service.GetName() + "-domain-dashboard", Namespace: t.Name()}}
+		test.AssertFetchMustExist(t, cli, dashboard)
+		assert.True(t, metav1.IsControlledBy(dashboard, service))
+	}
+}
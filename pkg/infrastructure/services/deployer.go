@@ -39,10 +39,9 @@ import (
 var log = logger.GetLogger("services_definition")
 
 const (
-	reconciliationPeriodAfterInfraError                = time.Minute
-	reconciliationPeriodAfterMessagingError            = time.Second * 30
-	reconciliationPeriodMonitoringEndpointNotAvailable = time.Second * 10
-	reconciliationPeriodAfterDashboardsError           = time.Second * 30
+	reconciliationPeriodAfterInfraError      = time.Minute
+	reconciliationPeriodAfterMessagingError  = time.Second * 30
+	reconciliationPeriodAfterDashboardsError = time.Second * 30
 )
 
 // ServiceDefinition defines the structure for a Kogito Service
@@ -67,8 +66,10 @@ type ServiceDefinition struct {
 	OnGetComparators func(comparator compare.ResourceComparator)
 	// SingleReplica if set to true, avoids that the service has more than one pod replica
 	SingleReplica bool
-	// KafkaTopics is a collection of Kafka Topics to be created within the service
+	// KafkaTopics is a collection of Kafka Topics consumed by the service, to be created within the service
 	KafkaTopics []string
+	// ProducedKafkaTopics is a collection of Kafka Topics the service produces to, to be created within the service
+	ProducedKafkaTopics []string
 	// HealthCheckProbe is the probe that needs to be configured in the service. Defaults to TCPHealthCheckProbe
 	HealthCheckProbe HealthCheckProbeType
 	// CustomService indicates that the service can be built within the cluster
@@ -116,6 +117,7 @@ type serviceDeployer struct {
 	client     *client.Client
 	scheme     *runtime.Scheme
 	recorder   record.EventRecorder
+	messaging  *messagingDeployer
 }
 
 func (s *serviceDeployer) getNamespace() string { return s.definition.Request.Namespace }
@@ -128,9 +130,20 @@ func (s *serviceDeployer) Deploy() (reconcileAfter time.Duration, err error) {
 		s.definition.DefaultImageName = s.definition.Request.Name
 	}
 
+	// the service is being deleted, release the resources not garbage collected by Kubernetes
+	if s.instance.GetDeletionTimestamp() != nil {
+		err = releaseMessagingResources(s.client, s.scheme, s.definition, s.instance)
+		return
+	}
+
 	// always update its status
 	defer s.updateStatus(s.instance, &err)
 
+	// the messaging resources tracking the service must be released once it is deleted
+	if err = addMessagingFinalizer(s.getMessagingDeployer(), s.instance); err != nil {
+		return
+	}
+
 	// we need to take ownership of the custom configmap provided
 	if len(s.instance.GetSpec().GetPropertiesConfigMap()) > 0 {
 		reconcileAfter, err = s.takeCustomConfigMapOwnership()
@@ -151,6 +164,13 @@ func (s *serviceDeployer) Deploy() (reconcileAfter time.Duration, err error) {
 		return
 	}
 
+	// topics and triggers should be available before the service starts consuming them,
+	// but failing to provision them must not block its rollout, they are provisioned again afterwards
+	messagingErr := s.configureMessaging()
+	if messagingErr != nil {
+		log.Warnf("Messaging resources of service %s not provisioned, rolling it out anyway: %v", s.instance.GetName(), messagingErr)
+	}
+
 	// create our resources
 	requestedResources, err := s.createRequiredResources()
 	if err != nil {
@@ -188,13 +208,13 @@ func (s *serviceDeployer) Deploy() (reconcileAfter time.Duration, err error) {
 		s.generateEventForDeltaResources("Removed", resourceType, delta.Removed)
 	}
 
-	reconcileAfter, err = s.configureMonitoring()
-	if err != nil || reconcileAfter > 0 {
+	if reconcileAfter, err = s.configureMonitoring(); err != nil {
 		return
 	}
 
-	reconcileAfter, err = s.configureMessaging()
-
+	if messagingErr != nil {
+		return reconciliationPeriodAfterMessagingError, messagingErr
+	}
 	return
 }
 
@@ -234,7 +254,8 @@ func (s *serviceDeployer) takeKogitoInfraOwnership() (err error) {
 		if err != nil {
 			return err
 		}
-		if framework.IsOwner(kogitoInfra, s.instance) {
+		// owner references can't cross namespaces
+		if kogitoInfra.Namespace != s.getNamespace() || framework.IsOwner(kogitoInfra, s.instance) {
 			continue
 		}
 		if err = framework.AddOwnerReference(s.instance, s.scheme, kogitoInfra); err != nil {
@@ -271,38 +292,68 @@ func (s *serviceDeployer) update() error {
 	return nil
 }
 
-// checkInfraDependencies verifies if every KogitoInfra resource have an ok status.
+// checkInfraDependencies verifies if every KogitoInfra resource the service depends on is configured and usable from the service namespace.
+// A KogitoInfra whose backing resource is temporarily unavailable keeps the properties resolved before, so the service is not held back.
 func (s *serviceDeployer) checkInfraDependencies() (time.Duration, error) {
-	kogitoInfraReferences := s.instance.GetSpec().GetInfra()
+	kogitoInfraReferences := infrastructure.GetKogitoInfraReferences(s.instance)
 	log.Debugf("Going to fetch kogito infra properties for given references : %s", kogitoInfraReferences)
 	for _, infraName := range kogitoInfraReferences {
 		infra, err := infrastructure.MustFetchKogitoInfraInstance(s.client, infraName, s.instance.GetNamespace())
 		if err != nil {
 			return 0, err
 		}
-		if infra.Status.Condition.Type == v1alpha1.FailureInfraConditionType {
+		if configured, reason := isKogitoInfraConfigured(infra); !configured {
 			s.instance.GetStatus().SetFailed(
 				v1alpha1.KogitoInfraNotReadyReason,
 				fmt.Errorf("KogitoService '%s' is waiting for infra dependency; skipping deployment; KogitoInfra not ready: %s; Status: %s",
-					s.instance.GetName(), infra.Name, infra.Status.Condition.Reason))
+					s.instance.GetName(), infra.Name, reason))
 			return reconciliationPeriodAfterInfraError, nil
 		}
+		// Knative Triggers only subscribe to Brokers of their own namespace, so the Broker must live in the service namespace
+		if infrastructure.IsKnativeEventingResource(infra) {
+			if brokerName, brokerNamespace := infrastructure.GetKnativeEventingBrokerReference(infra); brokerNamespace != s.instance.GetNamespace() {
+				s.instance.GetStatus().SetFailed(
+					v1alpha1.KogitoInfraNotSupportedReason,
+					fmt.Errorf("KogitoService '%s' can't consume events from Knative Broker %s of namespace %s referenced by KogitoInfra %s; skipping deployment; "+
+						"Knative Triggers only bind to Brokers of their own namespace, reference a KogitoInfra for a Broker of namespace %s",
+						s.instance.GetName(), brokerName, brokerNamespace, infraName, s.instance.GetNamespace()))
+				return reconciliationPeriodAfterInfraError, nil
+			}
+		}
 	}
 	return 0, nil
 }
 
-func (s *serviceDeployer) configureMessaging() (time.Duration, error) {
-	if err := handleMessagingResources(s.client, s.scheme, s.definition, s.instance); err != nil {
-		return reconciliationPeriodAfterMessagingError, err
+// isKogitoInfraConfigured checks the Configured condition of the given KogitoInfra, falling back to the deprecated
+// single condition for instances not reconciled with conditions yet. Returns the reason when not configured.
+func isKogitoInfraConfigured(infra *v1alpha1.KogitoInfra) (bool, v1alpha1.KogitoInfraConditionReason) {
+	if len(infra.Status.Conditions) == 0 {
+		return infra.Status.Condition.Type != v1alpha1.FailureInfraConditionType, infra.Status.Condition.Reason
 	}
-	return 0, nil
+	if condition := infra.Status.GetCondition(v1alpha1.ConfiguredInfraConditionType); condition == nil {
+		return false, ""
+	} else if condition.Status != v1.ConditionTrue {
+		return false, condition.Reason
+	}
+	return true, ""
+}
+
+func (s *serviceDeployer) configureMessaging() error {
+	return handleMessagingResources(s.getMessagingDeployer(), s.instance)
+}
+
+// getMessagingDeployer gets the messaging deployer shared by the reconciliation, resolving the topics declared by the service once
+func (s *serviceDeployer) getMessagingDeployer() messagingDeployer {
+	if s.messaging == nil {
+		messaging := newMessagingDeployer(s.client, s.scheme, s.definition)
+		s.messaging = &messaging
+	}
+	return *s.messaging
 }
 
 func (s *serviceDeployer) configureMonitoring() (time.Duration, error) {
-	if failedVerifyAddon, err := configurePrometheus(s.client, s.instance, s.scheme); err != nil {
+	if err := configurePrometheus(s.client, s.instance, s.scheme); err != nil {
 		return 0, err
-	} else if failedVerifyAddon {
-		return reconciliationPeriodMonitoringEndpointNotAvailable, nil
 	}
 
 	reconcileAfter, err := configureGrafanaDashboards(s.client, s.instance, s.scheme, s.getNamespace())
@@ -30,6 +30,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"reflect"
+	"strings"
 )
 
 // createRequiredResources creates the required resources given the KogitoService instance
@@ -56,15 +57,17 @@ func (s *serviceDeployer) createRequiredResources() (resources map[reflect.Type]
 
 		appProps := map[string]string{}
 		var envProperties []corev1.EnvVar
+		var infraVolumes []v1alpha1.KogitoInfraVolume
 
-		if len(s.instance.GetSpec().GetInfra()) > 0 {
+		if len(s.instance.GetSpec().GetInfra()) > 0 || len(s.instance.GetSpec().GetMessaging().Channels) > 0 {
 			log.Debugf("Infra references are provided")
-			infraAppProps, infraEnvProp, err := s.fetchKogitoInfraProperties()
+			infraAppProps, infraEnvProp, infraVolumeProp, err := s.fetchKogitoInfraProperties()
 			if err != nil {
 				return resources, err
 			}
 			util.AppendToStringMap(infraAppProps, appProps)
 			envProperties = append(envProperties, infraEnvProp...)
+			infraVolumes = append(infraVolumes, infraVolumeProp...)
 		}
 
 		if len(s.instance.GetSpec().GetConfig()) > 0 {
@@ -73,12 +76,19 @@ func (s *serviceDeployer) createRequiredResources() (resources map[reflect.Type]
 		}
 
 		s.applyEnvironmentPropertiesConfiguration(envProperties, deployment)
+		s.applyInfraVolumesConfiguration(infraVolumes, deployment)
 
 		contentHash, configMap, err := getAppPropConfigMapContentHash(s.instance, appProps, s.client)
 		if err != nil {
 			return resources, err
 		}
 		s.applyApplicationPropertiesConfigurations(contentHash, deployment, s.instance)
+
+		referencedContentHash, err := getReferencedObjectsContentHash(s.instance, deployment, s.client)
+		if err != nil {
+			return resources, err
+		}
+		s.applyReferencedObjectsConfigurations(referencedContentHash, deployment)
 		if configMap != nil {
 			resources[reflect.TypeOf(corev1.ConfigMap{})] = []resource.KubernetesResource{configMap}
 		}
@@ -244,16 +254,53 @@ func (s *serviceDeployer) getComparator() compare.MapComparator {
 	return compare.MapComparator{Comparator: resourceComparator}
 }
 
-func (s *serviceDeployer) fetchKogitoInfraProperties() (map[string]string, []corev1.EnvVar, error) {
+// syncOwnedResources creates, updates or deletes the resources of the given type owned by the service in the given namespace
+// to match the requested ones, using the custom comparator to find the resources to update
+func syncOwnedResources(cli *client.Client, kogitoService v1alpha1.KogitoService, namespace string, list runtime.Object, resourceType reflect.Type,
+	requested []resource.KubernetesResource, customComparator func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool) error {
+	deployed, err := kubernetes.ResourceC(cli).ListAll([]runtime.Object{list}, namespace, kogitoService)
+	if err != nil {
+		return err
+	}
+
+	resourceComparator := compare.DefaultComparator()
+	resourceComparator.SetComparator(
+		framework.NewComparatorBuilder().
+			WithType(resourceType).
+			UseDefaultComparator().
+			WithCustomComparator(customComparator).
+			Build())
+	comparator := compare.MapComparator{Comparator: resourceComparator}
+	deltas := comparator.Compare(deployed, map[reflect.Type][]resource.KubernetesResource{resourceType: requested})
+	for deltaType, delta := range deltas {
+		if !delta.HasChanges() {
+			continue
+		}
+		log.Infof("Will create %d, update %d, and delete %d instances of %v for %s", len(delta.Added), len(delta.Updated), len(delta.Removed), deltaType, kogitoService.GetName())
+		if _, err = kubernetes.ResourceC(cli).CreateResources(delta.Added); err != nil {
+			return err
+		}
+		if _, err = kubernetes.ResourceC(cli).UpdateResources(deployed[deltaType], delta.Updated); err != nil {
+			return err
+		}
+		if _, err = kubernetes.ResourceC(cli).DeleteResources(delta.Removed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *serviceDeployer) fetchKogitoInfraProperties() (map[string]string, []corev1.EnvVar, []v1alpha1.KogitoInfraVolume, error) {
 	kogitoInfraReferences := s.instance.GetSpec().GetInfra()
 	log.Debugf("Going to fetch kogito infra properties for given references : %s", kogitoInfraReferences)
 	consolidateAppProperties := map[string]string{}
 	var consolidateEnvProperties []corev1.EnvVar
+	var consolidateVolumes []v1alpha1.KogitoInfraVolume
 	for _, kogitoInfraName := range kogitoInfraReferences {
 		// load infra resource
 		kogitoInfraInstance, err := infrastructure.MustFetchKogitoInfraInstance(s.client, kogitoInfraName, s.instance.GetNamespace())
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 
 		// fetch app properties from Kogito infra instance
@@ -261,13 +308,93 @@ func (s *serviceDeployer) fetchKogitoInfraProperties() (map[string]string, []cor
 		util.AppendToStringMap(appProp, consolidateAppProperties)
 
 		// fetch env properties from Kogito infra instance
-		envProp := kogitoInfraInstance.Status.Env
+		envProp := infrastructure.GetKogitoInfraEnvs(kogitoInfraInstance, s.instance.GetNamespace())
 		consolidateEnvProperties = append(consolidateEnvProperties, envProp...)
+
+		// fetch volumes from Kogito infra instance
+		consolidateVolumes = append(consolidateVolumes, infrastructure.GetKogitoInfraVolumes(kogitoInfraInstance, s.instance.GetNamespace())...)
+	}
+
+	// channels bound to other KogitoInfra instances
+	kafkaHandler := kafkaMessagingDeployer{messagingDeployer: s.getMessagingDeployer()}
+	channelAppProps, channelEnvs, channelVolumes, err := kafkaHandler.getChannelsProperties(s.instance)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	util.AppendToStringMap(channelAppProps, consolidateAppProperties)
+	consolidateEnvProperties = append(consolidateEnvProperties, channelEnvs...)
+	consolidateVolumes = append(consolidateVolumes, channelVolumes...)
+	amqpHandler := amqpMessagingDeployer{messagingDeployer: s.getMessagingDeployer()}
+	if channelAppProps, channelEnvs, channelVolumes, err = amqpHandler.getChannelsProperties(s.instance); err != nil {
+		return nil, nil, nil, err
+	}
+	util.AppendToStringMap(channelAppProps, consolidateAppProperties)
+	consolidateEnvProperties = append(consolidateEnvProperties, channelEnvs...)
+	consolidateVolumes = append(consolidateVolumes, channelVolumes...)
+	resolveKogitoServicePlaceholders(s.instance, consolidateAppProperties, consolidateEnvProperties)
+	return consolidateAppProperties, consolidateEnvProperties, consolidateVolumes, nil
+}
+
+// resolveKogitoServicePlaceholders replaces the service name and namespace placeholders in the properties published by the KogitoInfra instances
+func resolveKogitoServicePlaceholders(service v1alpha1.KogitoService, appProps map[string]string, envs []corev1.EnvVar) {
+	replacer := strings.NewReplacer(
+		infrastructure.KogitoServiceNamePlaceholder, service.GetName(),
+		infrastructure.KogitoServiceNamespacePlaceholder, service.GetNamespace())
+	for key, value := range appProps {
+		appProps[key] = replacer.Replace(value)
+	}
+	for i := range envs {
+		envs[i].Value = replacer.Replace(envs[i].Value)
+		if envs[i].ValueFrom != nil && envs[i].ValueFrom.SecretKeyRef != nil {
+			envs[i].ValueFrom = envs[i].ValueFrom.DeepCopy()
+			envs[i].ValueFrom.SecretKeyRef.Key = replacer.Replace(envs[i].ValueFrom.SecretKeyRef.Key)
+		}
 	}
-	return consolidateAppProperties, consolidateEnvProperties, nil
 }
 
 func (s *serviceDeployer) applyEnvironmentPropertiesConfiguration(envProps []corev1.EnvVar, deployment *appsv1.Deployment) {
 	container := &deployment.Spec.Template.Spec.Containers[0]
 	container.Env = append(container.Env, envProps...)
 }
+
+// applyInfraVolumesConfiguration mounts the Secrets required by the referenced KogitoInfra instances in the service container
+func (s *serviceDeployer) applyInfraVolumesConfiguration(infraVolumes []v1alpha1.KogitoInfraVolume, deployment *appsv1.Deployment) {
+	for _, infraVolume := range infraVolumes {
+		// the same KogitoInfra volume might be provided by more than one reference
+		if hasVolume(deployment.Spec.Template.Spec.Volumes, infraVolume.Name) {
+			continue
+		}
+		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, createInfraVolume(infraVolume))
+		deployment.Spec.Template.Spec.Containers[0].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[0].VolumeMounts, createInfraVolumeMount(infraVolume))
+	}
+}
+
+// createInfraVolume creates a volume for the Secret required by a KogitoInfra instance
+func createInfraVolume(infraVolume v1alpha1.KogitoInfraVolume) corev1.Volume {
+	return corev1.Volume{
+		Name: infraVolume.Name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: infraVolume.SecretName,
+			},
+		},
+	}
+}
+
+// createInfraVolumeMount creates a container volume mount for the Secret required by a KogitoInfra instance
+func createInfraVolumeMount(infraVolume v1alpha1.KogitoInfraVolume) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      infraVolume.Name,
+		MountPath: infraVolume.MountPath,
+		ReadOnly:  true,
+	}
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
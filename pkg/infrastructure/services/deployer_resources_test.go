@@ -17,6 +17,7 @@ package services
 import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
 	imgv1 "github.com/openshift/api/image/v1"
@@ -201,3 +202,136 @@ func Test_serviceDeployer_createRequiredResources_CreateWithAppPropConfigMap(t *
 	_, ok = deployment.Spec.Template.Annotations[AppPropContentHashKey]
 	assert.True(t, ok)
 }
+
+func Test_serviceDeployer_createRequiredResources_MountInfraVolumes(t *testing.T) {
+	replicas := int32(1)
+	kogitoKafka := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "managed-kafka", Namespace: t.Name()},
+		Status: v1alpha1.KogitoInfraStatus{
+			Volumes: []v1alpha1.KogitoInfraVolume{
+				{Name: "managed-kafka-ca", SecretName: "kafka-ca", MountPath: "/home/kogito/certs/managed-kafka"},
+			},
+		},
+	}
+	instance := &v1alpha1.KogitoSupportingService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      infrastructure.DefaultDataIndexName,
+			Namespace: t.Name(),
+		},
+		Spec: v1alpha1.KogitoSupportingServiceSpec{
+			ServiceType: v1alpha1.DataIndex,
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Replicas: &replicas,
+				Infra:    []string{kogitoKafka.Name},
+			},
+		},
+	}
+	is, tag := test.GetImageStreams(infrastructure.DefaultDataIndexImageName, instance.Namespace, instance.Name, infrastructure.GetKogitoImageVersion())
+	cli := test.CreateFakeClientOnOpenShift([]runtime.Object{is, kogitoKafka}, []runtime.Object{tag}, nil)
+	deployer := serviceDeployer{
+		client:   cli,
+		scheme:   meta.GetRegisteredSchema(),
+		instance: instance,
+		definition: ServiceDefinition{
+			DefaultImageName: infrastructure.DefaultDataIndexImageName,
+			Request: reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: infrastructure.DefaultDataIndexName, Namespace: t.Name()},
+			},
+		},
+	}
+	resources, err := deployer.createRequiredResources()
+	assert.NoError(t, err)
+
+	deployment, ok := resources[reflect.TypeOf(appsv1.Deployment{})][0].(*appsv1.Deployment)
+	assert.True(t, ok)
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "managed-kafka-ca",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "kafka-ca"}},
+	})
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "managed-kafka-ca",
+		MountPath: "/home/kogito/certs/managed-kafka",
+		ReadOnly:  true,
+	})
+}
+
+func Test_serviceDeployer_createRequiredResources_InfraOtherNamespace(t *testing.T) {
+	replicas := int32(1)
+	kogitoKafka := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-kafka", Namespace: "kogito-infra"},
+		Spec:       v1alpha1.KogitoInfraSpec{AllowedNamespaces: []string{t.Name()}},
+		Status: v1alpha1.KogitoInfraStatus{
+			Env: []corev1.EnvVar{framework.CreateSecretEnvVar("KAFKA_PASSWORD", "shared-kafka-credential", "password")},
+			Volumes: []v1alpha1.KogitoInfraVolume{
+				{Name: "shared-kafka-ca", SecretName: "shared-kafka-credential", MountPath: "/home/kogito/certs/shared-kafka"},
+			},
+		},
+	}
+	instance := &v1alpha1.KogitoSupportingService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      infrastructure.DefaultDataIndexName,
+			Namespace: t.Name(),
+		},
+		Spec: v1alpha1.KogitoSupportingServiceSpec{
+			ServiceType: v1alpha1.DataIndex,
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Replicas: &replicas,
+				Infra:    []string{"kogito-infra/shared-kafka"},
+			},
+		},
+	}
+	is, tag := test.GetImageStreams(infrastructure.DefaultDataIndexImageName, instance.Namespace, instance.Name, infrastructure.GetKogitoImageVersion())
+	cli := test.CreateFakeClientOnOpenShift([]runtime.Object{is, kogitoKafka}, []runtime.Object{tag}, nil)
+	deployer := serviceDeployer{
+		client:   cli,
+		scheme:   meta.GetRegisteredSchema(),
+		instance: instance,
+		definition: ServiceDefinition{
+			DefaultImageName: infrastructure.DefaultDataIndexImageName,
+			Request: reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: infrastructure.DefaultDataIndexName, Namespace: t.Name()},
+			},
+		},
+	}
+	resources, err := deployer.createRequiredResources()
+	assert.NoError(t, err)
+
+	// Secrets of the KogitoInfra are copied into the service namespace
+	deployment, ok := resources[reflect.TypeOf(appsv1.Deployment{})][0].(*appsv1.Deployment)
+	assert.True(t, ok)
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "shared-kafka-ca",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "kogito-infra-shared-kafka-credential"}},
+	})
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env,
+		framework.CreateSecretEnvVar("KAFKA_PASSWORD", "kogito-infra-shared-kafka-credential", "password"))
+}
+
+func Test_resolveKogitoServicePlaceholders(t *testing.T) {
+	appProps := map[string]string{
+		"quarkus.oidc.client-id":                     infrastructure.KogitoServiceNamePlaceholder,
+		"quarkus.oidc.auth-server-url":               "https://sso.example.com/auth/realms/kogito",
+		"kogito.persistence.infinispan.cache-prefix": infrastructure.KogitoServiceNamespacePlaceholder + "-",
+	}
+	secretEnv := corev1.EnvVar{
+		Name: "QUARKUS_OIDC_CREDENTIALS_SECRET",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "keycloak-credential"},
+				Key:                  infrastructure.KogitoServiceNamePlaceholder,
+			},
+		},
+	}
+	envs := []corev1.EnvVar{secretEnv, {Name: "CLIENT_ID", Value: infrastructure.KogitoServiceNamePlaceholder}}
+
+	service := &v1alpha1.KogitoRuntime{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: "team-a"}}
+	resolveKogitoServicePlaceholders(service, appProps, envs)
+
+	assert.Equal(t, "travels", appProps["quarkus.oidc.client-id"])
+	assert.Equal(t, "team-a-", appProps["kogito.persistence.infinispan.cache-prefix"])
+	assert.Equal(t, "https://sso.example.com/auth/realms/kogito", appProps["quarkus.oidc.auth-server-url"])
+	assert.Equal(t, "travels", envs[0].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, "travels", envs[1].Value)
+	// the env vars published by the KogitoInfra instance are left untouched
+	assert.Equal(t, infrastructure.KogitoServiceNamePlaceholder, secretEnv.ValueFrom.SecretKeyRef.Key)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -104,6 +105,56 @@ func Test_serviceDeployer_Deploy(t *testing.T) {
 	assert.Equal(t, v1alpha1.ProvisioningConditionType, service.Status.Conditions[0].Type)
 }
 
+func Test_serviceDeployer_checkInfraDependencies(t *testing.T) {
+	infra := createSuccessfulInfinispanInfra(t.Name())
+	infra.Status.Conditions = []v1alpha1.KogitoInfraCondition{
+		{Type: v1alpha1.ReadyInfraConditionType, Status: corev1.ConditionFalse, Reason: v1alpha1.ResourceNotReady},
+		{Type: v1alpha1.ResourceAvailableInfraConditionType, Status: corev1.ConditionFalse, Reason: v1alpha1.ResourceNotReady},
+		{Type: v1alpha1.ConfiguredInfraConditionType, Status: corev1.ConditionTrue},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "travels", Namespace: t.Name()},
+		Spec:       v1alpha1.KogitoRuntimeSpec{KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{infra.Name}}},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(infra, service).Build()
+	deployer := &serviceDeployer{client: cli, instance: service}
+
+	// the backing resource is temporarily unavailable, but the infra is still configured
+	reconcileAfter, err := deployer.checkInfraDependencies()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), reconcileAfter)
+
+	infra.Status.Conditions[2] = v1alpha1.KogitoInfraCondition{Type: v1alpha1.ConfiguredInfraConditionType, Status: corev1.ConditionFalse, Reason: v1alpha1.InvalidResourceConfiguration}
+	assert.NoError(t, kubernetes.ResourceC(cli).UpdateStatus(infra))
+	reconcileAfter, err = deployer.checkInfraDependencies()
+	assert.NoError(t, err)
+	assert.Equal(t, reconciliationPeriodAfterInfraError, reconcileAfter)
+}
+
+func Test_serviceDeployer_checkInfraDependencies_KnativeBrokerOtherNamespace(t *testing.T) {
+	infra := &v1alpha1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "shared-knative", Namespace: "kogito-infra"},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource:          v1alpha1.Resource{APIVersion: infrastructure.KnativeEventingAPIVersion, Kind: infrastructure.KnativeEventingBrokerKind},
+			AllowedNamespaces: []string{t.Name()},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: v1.ObjectMeta{Name: "travels", Namespace: t.Name()},
+		Spec:       v1alpha1.KogitoRuntimeSpec{KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{"kogito-infra/shared-knative"}}},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(infra, service).Build()
+	deployer := &serviceDeployer{client: cli, instance: service}
+
+	reconcileAfter, err := deployer.checkInfraDependencies()
+	assert.NoError(t, err)
+	assert.Equal(t, reconciliationPeriodAfterInfraError, reconcileAfter)
+	conditions := service.Status.Conditions
+	assert.Equal(t, v1alpha1.FailedConditionType, conditions[len(conditions)-1].Type)
+	assert.Equal(t, v1alpha1.KogitoInfraNotSupportedReason, conditions[len(conditions)-1].Reason)
+	assert.Contains(t, conditions[len(conditions)-1].Message, "Knative Broker default of namespace kogito-infra")
+}
+
 func createSuccessfulKafkaInfra(namespace string) *v1alpha1.KogitoInfra {
 	return &v1alpha1.KogitoInfra{
 		ObjectMeta: v1.ObjectMeta{Name: "kafka-infra", Namespace: namespace},
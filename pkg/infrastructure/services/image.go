@@ -23,6 +23,7 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/openshift"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	dockerv10 "github.com/openshift/api/image/docker10"
 	imgv1 "github.com/openshift/api/image/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -110,6 +111,15 @@ func (i *imageHandler) resolveImage() (string, error) {
 	return i.resolveRegistryImage(), nil
 }
 
+// fetchDockerImage fetches the metadata of the image from its ImageStreamTag.
+// Returns nil on non OpenShift clusters or if the ImageStreamTag is not ready.
+func (i *imageHandler) fetchDockerImage() (*dockerv10.DockerImage, error) {
+	if !i.client.IsOpenshift() {
+		return nil, nil
+	}
+	return openshift.ImageStreamC(i.client).FetchDockerImage(types.NamespacedName{Name: i.imageStreamName, Namespace: i.namespace}, i.resolveTag())
+}
+
 // resolveRegistryImage resolves images like "quay.io/kiegroup/kogito-jobs-service:latest", as informed by user.
 func (i *imageHandler) resolveRegistryImage() string {
 	domain := i.image.Domain
@@ -187,6 +197,23 @@ func NewImageHandlerForBuiltServices(image *v1alpha1.Image, namespace string, cl
 
 func newImageHandler(instance v1alpha1.KogitoService, definition ServiceDefinition, cli *client.Client) (*imageHandler, error) {
 	addDockerImageReference := len(instance.GetSpec().GetImage()) != 0 || !definition.CustomService
+	handler := newImageHandlerForInstance(instance, definition, cli)
+	if cli.IsOpenshift() {
+		sharedImageStream, err := GetSharedDeployedImageStream(handler.imageStreamName, instance.GetNamespace(), cli)
+		if err != nil {
+			return nil, err
+		}
+		if sharedImageStream != nil {
+			handler.imageStream = sharedImageStream
+		} else {
+			handler.createImageStream(instance.GetNamespace(), addDockerImageReference, instance.GetSpec().IsInsecureImageRegistry())
+		}
+	}
+	return handler, nil
+}
+
+// newImageHandlerForInstance creates the imageHandler for the given instance without creating its ImageStream
+func newImageHandlerForInstance(instance v1alpha1.KogitoService, definition ServiceDefinition, cli *client.Client) *imageHandler {
 	var image v1alpha1.Image
 	if len(instance.GetSpec().GetImage()) == 0 {
 		image = v1alpha1.Image{
@@ -197,7 +224,7 @@ func newImageHandler(instance v1alpha1.KogitoService, definition ServiceDefiniti
 		image = framework.ConvertImageTagToImage(instance.GetSpec().GetImage())
 	}
 
-	handler := &imageHandler{
+	return &imageHandler{
 		image:            &image,
 		imageStream:      nil,
 		defaultImageName: definition.DefaultImageName,
@@ -205,18 +232,6 @@ func newImageHandler(instance v1alpha1.KogitoService, definition ServiceDefiniti
 		namespace:        instance.GetNamespace(),
 		client:           cli,
 	}
-	if cli.IsOpenshift() {
-		sharedImageStream, err := GetSharedDeployedImageStream(handler.imageStreamName, instance.GetNamespace(), cli)
-		if err != nil {
-			return nil, err
-		}
-		if sharedImageStream != nil {
-			handler.imageStream = sharedImageStream
-		} else {
-			handler.createImageStream(instance.GetNamespace(), addDockerImageReference, instance.GetSpec().IsInsecureImageRegistry())
-		}
-	}
-	return handler, nil
 }
 
 // GetSharedDeployedImageStream gets the deployed ImageStream shared among Kogito Custom Resources
@@ -21,8 +21,11 @@ import (
 
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 type messageTopicKind string
@@ -30,8 +33,12 @@ type messageTopicKind string
 const (
 	// consumed is the topics type that the application can consume
 	consumed messageTopicKind = "CONSUMED"
+	// produced is the topics type that the application can produce
+	produced messageTopicKind = "PRODUCED"
 	// topicInfoPath which the topics are fetched
 	topicInfoPath = "/messaging/topics"
+	// messagingFinalizer is added to the services using messaging resources in other namespaces, released when the service is deleted
+	messagingFinalizer = "kogito.kie.org/messaging"
 )
 
 type messageTopic struct {
@@ -43,41 +50,161 @@ type messagingDeployer struct {
 	scheme     *runtime.Scheme
 	cli        *client.Client
 	definition ServiceDefinition
+	// declaredTopics is shared by the handlers of a reconciliation, so that the service image is fetched once
+	declaredTopics *declaredTopicsCache
+}
+
+// declaredTopicsCache holds the topics declared by a service once resolved
+type declaredTopicsCache struct {
+	resolved bool
+	topics   []messageTopic
+}
+
+func newMessagingDeployer(cli *client.Client, scheme *runtime.Scheme, definition ServiceDefinition) messagingDeployer {
+	return messagingDeployer{scheme: scheme, cli: cli, definition: definition, declaredTopics: &declaredTopicsCache{}}
 }
 
 // handleMessagingResources handles messaging resources creation.
 // These resources can be required by the deployed service through a bound KogitoInfra.
-func handleMessagingResources(cli *client.Client, scheme *runtime.Scheme, definition ServiceDefinition, service v1alpha1.KogitoService) error {
-	m := messagingDeployer{
-		scheme:     scheme,
-		cli:        cli,
-		definition: definition,
-	}
+func handleMessagingResources(m messagingDeployer, service v1alpha1.KogitoService) error {
 	knativeHandler := knativeMessagingDeployer{messagingDeployer: m}
 	kafkaHandler := kafkaMessagingDeployer{messagingDeployer: m}
+	amqpHandler := amqpMessagingDeployer{messagingDeployer: m}
 	if err := knativeHandler.createRequiredResources(service); err != nil {
 		return err
 	}
 	if err := kafkaHandler.createRequiredResources(service); err != nil {
 		return err
 	}
+	if err := amqpHandler.createRequiredResources(service); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (m *messagingDeployer) fetchRequiredTopics(instance v1alpha1.KogitoService) ([]messageTopic, error) {
-	svcURL := infrastructure.GetKogitoServiceEndpoint(instance)
-	return m.fetchRequiredTopicsForURL(instance, svcURL)
+// releaseMessagingResources releases the messaging resources used by the service being deleted that can't be
+// garbage collected by Kubernetes, such as KafkaTopics or ActiveMQ Artemis addresses in other namespaces, and removes its messaging finalizer.
+func releaseMessagingResources(cli *client.Client, scheme *runtime.Scheme, definition ServiceDefinition, service v1alpha1.KogitoService) error {
+	if !controllerutil.ContainsFinalizer(service, messagingFinalizer) {
+		return nil
+	}
+	m := newMessagingDeployer(cli, scheme, definition)
+	kafkaHandler := kafkaMessagingDeployer{messagingDeployer: m}
+	if err := kafkaHandler.releaseResources(service); err != nil {
+		return err
+	}
+	amqpHandler := amqpMessagingDeployer{messagingDeployer: m}
+	if err := amqpHandler.releaseResources(service); err != nil {
+		return err
+	}
+	controllerutil.RemoveFinalizer(service, messagingFinalizer)
+	return kubernetes.ResourceC(cli).Update(service)
 }
 
-func (m *messagingDeployer) fetchRequiredTopicsForURL(instance v1alpha1.KogitoService, serverURL string) ([]messageTopic, error) {
+// addMessagingFinalizer adds the messaging finalizer to the service when it's bound to Kafka or ActiveMQ Artemis instances,
+// since the topics tracking the services using them, and the addresses created in other namespaces, must be released once it's deleted
+func addMessagingFinalizer(m messagingDeployer, service v1alpha1.KogitoService) error {
+	if controllerutil.ContainsFinalizer(service, messagingFinalizer) {
+		return nil
+	}
+	if tracked, err := m.usesTrackedMessagingResources(service); err != nil || !tracked {
+		return err
+	}
+	log.Debugf("Adding messaging finalizer to service %s", service.GetName())
+	controllerutil.AddFinalizer(service, messagingFinalizer)
+	return kubernetes.ResourceC(m.cli).Update(service)
+}
+
+// usesTrackedMessagingResources checks if the KogitoInfra instances referenced by the service provide Kafka or
+// ActiveMQ Artemis instances, whatever their namespace, whose topics or addresses are released by the messaging finalizer
+func (m *messagingDeployer) usesTrackedMessagingResources(service v1alpha1.KogitoService) (bool, error) {
+	for _, infraName := range infrastructure.GetKogitoInfraReferences(service) {
+		infra := &v1alpha1.KogitoInfra{}
+		if exists, err := kubernetes.ResourceC(m.cli).FetchWithKey(infrastructure.GetKogitoInfraKey(infraName, service.GetNamespace()), infra); err != nil {
+			return false, err
+		} else if exists && (infrastructure.IsStrimziKafkaResource(infra) || infrastructure.IsArtemisResource(infra)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fetchRequiredTopics gets the topics declared in the instance spec and in the labels of its image.
+// If no topic is declared, they are discovered querying the running service.
+// resolved is false when the topics can't be known yet, since the service is not available to be queried.
+func (m *messagingDeployer) fetchRequiredTopics(instance v1alpha1.KogitoService) (topics []messageTopic, resolved bool, err error) {
+	if topics, err = m.fetchDeclaredTopics(instance); err != nil {
+		return nil, false, err
+	} else if len(topics) > 0 {
+		return topics, true, nil
+	}
 	available, err := IsDeploymentAvailable(m.cli, instance)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if !available {
 		log.Debugf("Deployment not available yet for KogitoService %s ", instance.GetName())
-		return nil, nil
+		return nil, false, nil
+	}
+	svcURL := infrastructure.GetKogitoServiceEndpoint(instance)
+	if topics, err = m.fetchRequiredTopicsForURL(instance, svcURL); err != nil {
+		return nil, false, err
+	}
+	return topics, true, nil
+}
+
+// fetchDeclaredTopics gets the topics declared in the instance spec and in the labels of its image
+func (m *messagingDeployer) fetchDeclaredTopics(instance v1alpha1.KogitoService) ([]messageTopic, error) {
+	if m.declaredTopics != nil && m.declaredTopics.resolved {
+		return m.declaredTopics.topics, nil
+	}
+	messaging := instance.GetSpec().GetMessaging()
+	consumedChannels, producedChannels := messaging.Consumed, messaging.Produced
+	dockerImage, err := newImageHandlerForInstance(instance, m.definition, m.cli).fetchDockerImage()
+	if err != nil {
+		return nil, err
+	}
+	imageConsumedChannels, imageProducedChannels := framework.ExtractMessagingChannelsFromImage(dockerImage)
+	consumedChannels = append(append([]string{}, consumedChannels...), imageConsumedChannels...)
+	producedChannels = append(append([]string{}, producedChannels...), imageProducedChannels...)
+
+	var topics []messageTopic
+	for _, channel := range consumedChannels {
+		topics = appendMessageTopic(topics, messageTopic{Name: channel, Kind: consumed})
+	}
+	for _, channel := range producedChannels {
+		topics = appendMessageTopic(topics, messageTopic{Name: channel, Kind: produced})
 	}
+	if m.declaredTopics != nil {
+		m.declaredTopics.resolved = true
+		m.declaredTopics.topics = topics
+	}
+	return topics, nil
+}
+
+// getDefinitionTopics gets the topics required by the service definition
+func (m *messagingDeployer) getDefinitionTopics() []messageTopic {
+	var topics []messageTopic
+	for _, topicName := range m.definition.KafkaTopics {
+		topics = appendMessageTopic(topics, messageTopic{Name: topicName, Kind: consumed})
+	}
+	for _, topicName := range m.definition.ProducedKafkaTopics {
+		topics = appendMessageTopic(topics, messageTopic{Name: topicName, Kind: produced})
+	}
+	return topics
+}
+
+// appendMessageTopic appends the given topic if it's not declared yet
+func appendMessageTopic(topics []messageTopic, topic messageTopic) []messageTopic {
+	for _, existing := range topics {
+		if existing == topic {
+			return topics
+		}
+	}
+	return append(topics, topic)
+}
+
+func (m *messagingDeployer) fetchRequiredTopicsForURL(instance v1alpha1.KogitoService, serverURL string) ([]messageTopic, error) {
 	topicsURL := fmt.Sprintf("%s%s", serverURL, topicInfoPath)
 	resp, err := http.Get(topicsURL)
 	if err != nil {
@@ -109,3 +236,47 @@ func (m *messagingDeployer) fetchInfraDependency(service v1alpha1.KogitoService,
 	}
 	return nil, nil
 }
+
+// fetchChannelInfras fetches the KogitoInfra instances bound to the channels of the given service, by channel name
+func (m *messagingDeployer) fetchChannelInfras(service v1alpha1.KogitoService) (map[string]*v1alpha1.KogitoInfra, error) {
+	channelInfras := map[string]*v1alpha1.KogitoInfra{}
+	infras := map[string]*v1alpha1.KogitoInfra{}
+	for _, channel := range service.GetSpec().GetMessaging().Channels {
+		infra, fetched := infras[channel.Infra]
+		if !fetched {
+			var err error
+			if infra, err = infrastructure.MustFetchKogitoInfraInstance(m.cli, channel.Infra, service.GetNamespace()); err != nil {
+				return nil, err
+			}
+			infras[channel.Infra] = infra
+		}
+		if !infrastructure.IsKafkaResource(infra) && !infrastructure.IsKnativeEventingResource(infra) && !infrastructure.IsAMQPResource(infra) {
+			log.Warnf("KogitoInfra %s bound to channel %s doesn't provide a messaging resource, ignoring it", infra.Name, channel.Name)
+			continue
+		}
+		channelInfras[channel.Name] = infra
+	}
+	return channelInfras, nil
+}
+
+// resolveChannelInfra gets the KogitoInfra providing the resources for the given channel, accepted by the given checker.
+// Channels not bound to any KogitoInfra use the default one. Returns nil if the channel is bound to a KogitoInfra not accepted by the checker.
+func resolveChannelInfra(channel string, channelInfras map[string]*v1alpha1.KogitoInfra, defaultInfra *v1alpha1.KogitoInfra, checker func(*v1alpha1.KogitoInfra) bool) *v1alpha1.KogitoInfra {
+	if infra, bound := channelInfras[channel]; bound {
+		if checker(infra) {
+			return infra
+		}
+		return nil
+	}
+	return defaultInfra
+}
+
+// hasInfra checks if any of the given KogitoInfra instances is accepted by the given checker
+func hasInfra(infras map[string]*v1alpha1.KogitoInfra, checker func(*v1alpha1.KogitoInfra) bool) bool {
+	for _, infra := range infras {
+		if checker(infra) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,265 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	artemisv1beta1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/artemis/v1beta1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// quarkusAMQPAppPropPrefix prefix of the quarkus application properties configuring the AMQP connector
+	quarkusAMQPAppPropPrefix = "amqp-"
+	// quarkusChannelConnectorAppProp quarkus channel attribute for setting the connector
+	quarkusChannelConnectorAppProp = "connector"
+	// quarkusChannelAddressAppProp quarkus channel attribute for setting the AMQP address
+	quarkusChannelAddressAppProp = "address"
+	// smallRyeAMQPConnector name of the SmallRye AMQP connector
+	smallRyeAMQPConnector = "smallrye-amqp"
+)
+
+// amqpMessagingDeployer implementation of messagingHandler
+type amqpMessagingDeployer struct {
+	messagingDeployer
+}
+
+// createRequiredResources creates the ActiveMQ Artemis addresses of the topics used by the service, along with a queue
+// for each consumed topic. Addresses of external AMQP brokers are left to the broker configuration.
+func (a *amqpMessagingDeployer) createRequiredResources(service v1alpha1.KogitoService) error {
+	infra, err := a.fetchInfraDependency(service, infrastructure.IsArtemisResource)
+	if err != nil {
+		return err
+	}
+	channelInfras, err := a.fetchChannelInfras(service)
+	if err != nil {
+		return err
+	}
+	if infra == nil && !hasInfra(channelInfras, infrastructure.IsArtemisResource) {
+		return nil
+	}
+	log.Debugf("Going to apply ActiveMQ Artemis addresses required by the deployed service '%s'", service.GetName())
+
+	topics := a.getDefinitionTopics()
+	requiredTopics, resolved, err := a.fetchRequiredTopics(service)
+	if err != nil {
+		return err
+	}
+	for _, topic := range requiredTopics {
+		topics = appendMessageTopic(topics, topic)
+	}
+
+	// group the topics by the KogitoInfra providing their broker, tracking the consumed ones
+	infras := map[string]*v1alpha1.KogitoInfra{}
+	if infra != nil {
+		infras[getInfraKey(infra)] = infra
+	}
+	for _, channelInfra := range channelInfras {
+		if infrastructure.IsArtemisResource(channelInfra) {
+			infras[getInfraKey(channelInfra)] = channelInfra
+		}
+	}
+	infraTopicNames := map[string][]string{}
+	var consumedTopicNames []string
+	for _, topic := range topics {
+		topicInfra := resolveChannelInfra(topic.Name, channelInfras, infra, infrastructure.IsArtemisResource)
+		if topicInfra == nil {
+			continue
+		}
+		if topicInfraKey := getInfraKey(topicInfra); !util.Contains(topic.Name, infraTopicNames[topicInfraKey]) {
+			infraTopicNames[topicInfraKey] = append(infraTopicNames[topicInfraKey], topic.Name)
+		}
+		if topic.Kind == consumed {
+			consumedTopicNames = append(consumedTopicNames, topic.Name)
+		}
+	}
+
+	for _, infraKey := range getSortedInfraKeys(infras) {
+		topicInfra := infras[infraKey]
+		artemisKey := a.getArtemisInstanceNamespaceName(topicInfra)
+		var names []string
+		for _, topicName := range infraTopicNames[infraKey] {
+			addressName := infrastructure.GetInfraResourceName(topicInfra, topicName, service.GetNamespace())
+			queueName := ""
+			if util.Contains(topicName, consumedTopicNames) {
+				queueName = infrastructure.GetArtemisQueueName(addressName, service.GetName(), service.GetNamespace())
+			}
+			address := infrastructure.GetArtemisAddress(getArtemisAddressResourceName(service, addressName), artemisKey.Namespace, artemisKey.Name,
+				addressName, queueName, service.GetName(), service.GetNamespace())
+			if err := a.reconcileArtemisAddress(address, service); err != nil {
+				return err
+			}
+			names = append(names, address.Name)
+		}
+		// we can only know which addresses are not used anymore once the service topics are resolved
+		if !resolved {
+			continue
+		}
+		if err := a.deleteUnusedArtemisAddresses(artemisKey.Namespace, service, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileArtemisAddress creates the given address or replaces the deployed one when its address or queue name changes,
+// since ActiveMQ Artemis operator doesn't apply the changes of the deployed addresses to the broker.
+// Replacing an address loses the messages pending in its queue, so other changes are not applied.
+// Addresses in the service namespace are owned by the service, otherwise the messaging finalizer of the service releases them once deleted.
+func (a *amqpMessagingDeployer) reconcileArtemisAddress(expected *artemisv1beta1.ActiveMQArtemisAddress, service v1alpha1.KogitoService) error {
+	deployed := &artemisv1beta1.ActiveMQArtemisAddress{}
+	exists, err := kubernetes.ResourceC(a.cli).FetchWithKey(types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, deployed)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if deployed.Spec.AddressName == expected.Spec.AddressName && deployed.Spec.QueueName == expected.Spec.QueueName {
+			if !reflect.DeepEqual(deployed.Spec, expected.Spec) {
+				log.Warnf("ActiveMQ Artemis address %s settings changed, they are applied only when its address or queue changes", deployed.Name)
+			}
+			return nil
+		}
+		// the broker applies addresses on creation only, the messages pending in the replaced queue are lost
+		log.Infof("ActiveMQ Artemis address %s changed from %s to %s, replacing it", deployed.Name, deployed.Spec.QueueName, expected.Spec.QueueName)
+		if err := kubernetes.ResourceC(a.cli).Delete(deployed); err != nil {
+			return err
+		}
+	}
+	log.Debugf("Going to create ActiveMQ Artemis address %s", expected.Name)
+	if expected.Namespace == service.GetNamespace() {
+		return kubernetes.ResourceC(a.cli).CreateForOwner(expected, service, a.scheme)
+	}
+	// the address of another namespace is released by the messaging finalizer of the service
+	return kubernetes.ResourceC(a.cli).Create(expected)
+}
+
+// deleteUnusedArtemisAddresses deletes the addresses created for the given service in the given namespace not in the given list
+func (a *amqpMessagingDeployer) deleteUnusedArtemisAddresses(namespace string, service v1alpha1.KogitoService, names []string) error {
+	addresses := &artemisv1beta1.ActiveMQArtemisAddressList{}
+	if err := kubernetes.ResourceC(a.cli).ListWithNamespaceAndLabel(namespace, addresses, infrastructure.GetArtemisAddressLabels(service.GetName(), service.GetNamespace())); err != nil {
+		return err
+	}
+	for i := range addresses.Items {
+		if util.Contains(addresses.Items[i].Name, names) {
+			continue
+		}
+		log.Debugf("ActiveMQ Artemis address %s not used anymore by service %s, deleting it", addresses.Items[i].Name, service.GetName())
+		if err := kubernetes.ResourceC(a.cli).Delete(&addresses.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseResources deletes the addresses created for the given service in the namespaces of the brokers it uses
+func (a *amqpMessagingDeployer) releaseResources(service v1alpha1.KogitoService) error {
+	for _, infraName := range infrastructure.GetKogitoInfraReferences(service) {
+		infra := &v1alpha1.KogitoInfra{}
+		if exists, err := kubernetes.ResourceC(a.cli).FetchWithKey(infrastructure.GetKogitoInfraKey(infraName, service.GetNamespace()), infra); err != nil {
+			return err
+		} else if !exists || !infrastructure.IsArtemisResource(infra) {
+			continue
+		}
+		if err := a.deleteUnusedArtemisAddresses(a.getArtemisInstanceNamespaceName(infra).Namespace, service, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getChannelsProperties gets the application properties connecting the channels provided by an AMQP KogitoInfra to its broker
+// through the SmallRye AMQP connector, along with the environment variables and volumes of the bound KogitoInfra instances
+// not referenced in the service infra.
+// Consumed channels of an ActiveMQ Artemis broker read from the queue created for the service.
+// Channels must be declared as consumed or produced to know which properties to set.
+func (a *amqpMessagingDeployer) getChannelsProperties(service v1alpha1.KogitoService) (map[string]string, []corev1.EnvVar, []v1alpha1.KogitoInfraVolume, error) {
+	appProps := map[string]string{}
+	var envs []corev1.EnvVar
+	var volumes []v1alpha1.KogitoInfraVolume
+	defaultInfra, err := a.fetchInfraDependency(service, infrastructure.IsAMQPResource)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	channelInfras, err := a.fetchChannelInfras(service)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if defaultInfra == nil && !hasInfra(channelInfras, infrastructure.IsAMQPResource) {
+		return appProps, envs, volumes, nil
+	}
+	declaredTopics, err := a.fetchDeclaredTopics(service)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	topics := a.getDefinitionTopics()
+	for _, topic := range declaredTopics {
+		topics = appendMessageTopic(topics, topic)
+	}
+
+	var infraKeys []types.NamespacedName
+	for _, infraName := range service.GetSpec().GetInfra() {
+		infraKeys = append(infraKeys, infrastructure.GetKogitoInfraKey(infraName, service.GetNamespace()))
+	}
+	for _, topic := range topics {
+		infra := resolveChannelInfra(topic.Name, channelInfras, defaultInfra, infrastructure.IsAMQPResource)
+		if infra == nil {
+			continue
+		}
+		prefix := getChannelAppPropPrefix(topic)
+		address := infrastructure.GetInfraResourceName(infra, topic.Name, service.GetNamespace())
+		if topic.Kind == consumed && infrastructure.IsArtemisResource(infra) {
+			address = infrastructure.GetArtemisFQQN(address, infrastructure.GetArtemisQueueName(address, service.GetName(), service.GetNamespace()))
+		}
+		appProps[prefix+quarkusChannelConnectorAppProp] = smallRyeAMQPConnector
+		appProps[prefix+quarkusChannelAddressAppProp] = address
+		if _, bound := channelInfras[topic.Name]; !bound {
+			continue
+		}
+		for key, value := range infra.Status.AppProps {
+			if strings.HasPrefix(key, quarkusAMQPAppPropPrefix) {
+				appProps[prefix+strings.TrimPrefix(key, quarkusAMQPAppPropPrefix)] = value
+			}
+		}
+		if infraKey := (types.NamespacedName{Namespace: infra.Namespace, Name: infra.Name}); !containsInfraKey(infraKey, infraKeys) {
+			infraKeys = append(infraKeys, infraKey)
+			envs = append(envs, infrastructure.GetKogitoInfraEnvs(infra, service.GetNamespace())...)
+			volumes = append(volumes, infrastructure.GetKogitoInfraVolumes(infra, service.GetNamespace())...)
+		}
+	}
+	return appProps, envs, volumes, nil
+}
+
+func (a *amqpMessagingDeployer) getArtemisInstanceNamespaceName(instance *v1alpha1.KogitoInfra) *types.NamespacedName {
+	name, namespace := instance.Spec.Resource.Name, instance.Spec.Resource.Namespace
+	if len(name) == 0 {
+		name = infrastructure.ArtemisInstanceName
+	}
+	if len(namespace) == 0 {
+		namespace = instance.Namespace
+	}
+	return &types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+// getArtemisAddressResourceName gets the name of the ActiveMQArtemisAddress resource created for the given service and address
+func getArtemisAddressResourceName(service v1alpha1.KogitoService, addressName string) string {
+	return getServiceResourceName(service, addressName)
+}
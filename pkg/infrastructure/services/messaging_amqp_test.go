@@ -0,0 +1,192 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	artemisv1beta1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/artemis/v1beta1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_createArtemisAddresses(t *testing.T) {
+	kogitoInfraInstance := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-artemis", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.ArtemisAPIVersion,
+				Kind:       infrastructure.ArtemisKind,
+			},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra: []string{kogitoInfraInstance.Name},
+				Messaging: v1alpha1.Messaging{
+					Consumed: []string{"travellers"},
+					Produced: []string{"processed_travellers"},
+				},
+			},
+		},
+	}
+
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfraInstance, service).Build()
+	a := amqpMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, a.createRequiredResources(service))
+
+	travellersAddress := &artemisv1beta1.ActiveMQArtemisAddress{ObjectMeta: metav1.ObjectMeta{Name: "travels-travellers", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, travellersAddress)
+	assert.Equal(t, "travellers", travellersAddress.Spec.AddressName)
+	assert.Equal(t, "travellers."+t.Name()+".travels", travellersAddress.Spec.QueueName)
+	assert.Equal(t, []string{infrastructure.ArtemisInstanceName}, travellersAddress.Spec.ApplyToCrNames)
+	assert.True(t, framework.IsOwner(travellersAddress, service))
+	processedAddress := &artemisv1beta1.ActiveMQArtemisAddress{ObjectMeta: metav1.ObjectMeta{Name: "travels-processed-travellers", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, processedAddress)
+	assert.Equal(t, "processed_travellers", processedAddress.Spec.AddressName)
+	assert.Empty(t, processedAddress.Spec.QueueName)
+	// the addresses are released once the service is deleted, whatever their namespace
+	assert.NoError(t, addMessagingFinalizer(a.messagingDeployer, service))
+	assert.Contains(t, service.Finalizers, messagingFinalizer)
+
+	appProps, _, _, err := a.getChannelsProperties(service)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"mp.messaging.incoming.travellers.connector":           "smallrye-amqp",
+		"mp.messaging.incoming.travellers.address":             "travellers::travellers." + t.Name() + ".travels",
+		"mp.messaging.outgoing.processed_travellers.connector": "smallrye-amqp",
+		"mp.messaging.outgoing.processed_travellers.address":   "processed_travellers",
+	}, appProps)
+
+	// channel not consumed anymore
+	service.Spec.Messaging.Consumed = nil
+	assert.NoError(t, a.createRequiredResources(service))
+	test.AssertFetchMustNotExist(t, client, travellersAddress)
+	test.AssertFetchMustExist(t, client, processedAddress)
+}
+
+func Test_reconcileArtemisAddress_ReplacedOnQueueChange(t *testing.T) {
+	service := &v1alpha1.KogitoRuntime{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()}}
+	deployed := infrastructure.GetArtemisAddress("travels-travellers", t.Name(), "my-broker", "travellers", "travellers.queue", service.Name, service.Namespace)
+	deployed.UID = test.GenerateUID()
+	client := test.NewFakeClientBuilder().AddK8sObjects(service, deployed).Build()
+	a := amqpMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+
+	// other settings changes are not applied to keep the pending messages
+	expected := infrastructure.GetArtemisAddress("travels-travellers", t.Name(), "my-broker", "travellers", "travellers.queue", service.Name, service.Namespace)
+	expected.Spec.RoutingType = "anycast"
+	assert.NoError(t, a.reconcileArtemisAddress(expected, service))
+	address := &artemisv1beta1.ActiveMQArtemisAddress{ObjectMeta: metav1.ObjectMeta{Name: "travels-travellers", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, address)
+	assert.Equal(t, deployed.UID, address.UID)
+	assert.Equal(t, artemisv1beta1.MulticastRoutingType, address.Spec.RoutingType)
+
+	expected = infrastructure.GetArtemisAddress("travels-travellers", t.Name(), "my-broker", "travellers", "travellers.new-queue", service.Name, service.Namespace)
+	assert.NoError(t, a.reconcileArtemisAddress(expected, service))
+	address = &artemisv1beta1.ActiveMQArtemisAddress{ObjectMeta: metav1.ObjectMeta{Name: "travels-travellers", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, address)
+	assert.Equal(t, "travellers.new-queue", address.Spec.QueueName)
+}
+
+func Test_releaseMessagingResources_CrossNamespaceAddresses(t *testing.T) {
+	kogitoInfraInstance := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-artemis", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.ArtemisAPIVersion,
+				Kind:       infrastructure.ArtemisKind,
+				Name:       "my-broker",
+				Namespace:  "brokers",
+			},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra:     []string{kogitoInfraInstance.Name},
+				Messaging: v1alpha1.Messaging{Consumed: []string{"travellers"}},
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfraInstance, service).Build()
+	a := amqpMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, a.createRequiredResources(service))
+
+	travellersAddress := &artemisv1beta1.ActiveMQArtemisAddress{ObjectMeta: metav1.ObjectMeta{Name: "travels-travellers", Namespace: "brokers"}}
+	test.AssertFetchMustExist(t, client, travellersAddress)
+	assert.Equal(t, []string{"my-broker"}, travellersAddress.Spec.ApplyToCrNames)
+	assert.Empty(t, travellersAddress.OwnerReferences)
+	assert.NoError(t, addMessagingFinalizer(a.messagingDeployer, service))
+	test.AssertFetchMustExist(t, client, service)
+	assert.Contains(t, service.Finalizers, messagingFinalizer)
+
+	assert.NoError(t, releaseMessagingResources(client, meta.GetRegisteredSchema(), ServiceDefinition{}, service))
+	test.AssertFetchMustNotExist(t, client, travellersAddress)
+	service = &v1alpha1.KogitoRuntime{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, service)
+	assert.NotContains(t, service.Finalizers, messagingFinalizer)
+}
+
+func Test_getAMQPChannelsProperties_ExternalBroker(t *testing.T) {
+	externalInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "partner-amqp", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			External: &v1alpha1.ExternalInfra{AMQP: &v1alpha1.ExternalAMQP{Host: "partner-broker"}},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{"amqp-host": "partner-broker", "amqp-port": "5672"},
+			Env:      []corev1.EnvVar{framework.CreateEnvVar("ENABLE_EVENTS", "true")},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Messaging: v1alpha1.Messaging{
+					Consumed: []string{"bookings"},
+					Channels: []v1alpha1.MessagingChannel{{Name: "bookings", Infra: externalInfra.Name}},
+				},
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(externalInfra, service).Build()
+	a := amqpMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+
+	// addresses of external brokers are not managed
+	assert.NoError(t, a.createRequiredResources(service))
+	addresses := &artemisv1beta1.ActiveMQArtemisAddressList{}
+	assert.NoError(t, client.ControlCli.List(context.TODO(), addresses))
+	assert.Empty(t, addresses.Items)
+
+	appProps, envs, _, err := a.getChannelsProperties(service)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"mp.messaging.incoming.bookings.connector": "smallrye-amqp",
+		"mp.messaging.incoming.bookings.address":   "bookings",
+		"mp.messaging.incoming.bookings.host":      "partner-broker",
+		"mp.messaging.incoming.bookings.port":      "5672",
+	}, appProps)
+	assert.Equal(t, externalInfra.Status.Env, envs)
+}
@@ -18,13 +18,32 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	kafkav1beta1 "github.com/kiegroup/kogito-cloud-operator/pkg/apis/kafka/v1beta1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sort"
+	"strings"
 )
 
 const (
 	// QuarkusKafkaBootstrapAppProp quarkus application property for setting kafka server
 	QuarkusKafkaBootstrapAppProp = "kafka.bootstrap.servers"
+
+	// quarkusKafkaAppPropPrefix prefix of the quarkus application properties configuring the kafka clients
+	quarkusKafkaAppPropPrefix = "kafka."
+	// quarkusIncomingChannelAppPropPrefix prefix of the quarkus application properties configuring a consumed channel
+	quarkusIncomingChannelAppPropPrefix = "mp.messaging.incoming."
+	// quarkusOutgoingChannelAppPropPrefix prefix of the quarkus application properties configuring a produced channel
+	quarkusOutgoingChannelAppPropPrefix = "mp.messaging.outgoing."
+	// quarkusChannelTopicAppProp quarkus channel attribute for setting the kafka topic
+	quarkusChannelTopicAppProp = "topic"
+	// springIncomingTopicAppPropPrefix prefix of the spring boot application properties setting the kafka topic of a consumed channel
+	springIncomingTopicAppPropPrefix = "kogito.addon.cloudevents.kafka.kogito_incoming_stream."
+	// springOutgoingTopicAppPropPrefix prefix of the spring boot application properties setting the kafka topic of a produced channel
+	springOutgoingTopicAppPropPrefix = "kogito.addon.cloudevents.kafka.kogito_outgoing_stream."
 )
 
 // kafkaMessagingDeployer implementation of messagingHandler
@@ -34,48 +53,293 @@ type kafkaMessagingDeployer struct {
 
 func (k *kafkaMessagingDeployer) createRequiredResources(service v1alpha1.KogitoService) error {
 	infra, err := k.fetchInfraDependency(service, infrastructure.IsKafkaResource)
-	if err != nil || infra == nil {
+	if err != nil {
 		return err
 	}
-	if err := k.createRequiredKafkaTopics(infra, service); err != nil {
+	channelInfras, err := k.fetchChannelInfras(service)
+	if err != nil {
+		return err
+	}
+	if infra == nil && len(channelInfras) == 0 {
+		return nil
+	}
+	if err := k.createRequiredKafkaTopics(infra, channelInfras, service); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (k *kafkaMessagingDeployer) createRequiredKafkaTopics(infra *v1alpha1.KogitoInfra, service v1alpha1.KogitoService) error {
+func (k *kafkaMessagingDeployer) createRequiredKafkaTopics(defaultInfra *v1alpha1.KogitoInfra, channelInfras map[string]*v1alpha1.KogitoInfra, service v1alpha1.KogitoService) error {
 	log.Debugf("Going to apply kafka topic configurations required by the deployed service '%s'", service.GetName())
-	kafkaURI := infra.Status.AppProps[QuarkusKafkaBootstrapAppProp]
-	if len(kafkaURI) == 0 {
-		log.Debugf("Ignoring Kafka Topics creation, Kafka URI is empty from the given KogitoInfra: %s", infra.Name)
-		return nil
-	}
 	// topics required by definition
-	for _, kafkaTopic := range k.definition.KafkaTopics {
-		err := k.createKafkaTopicIfNotExists(kafkaTopic, infra)
-		if err != nil {
-			return err
+	var topicNames []string
+	for _, topic := range k.getDefinitionTopics() {
+		if !util.Contains(topic.Name, topicNames) {
+			topicNames = append(topicNames, topic.Name)
 		}
 	}
 	// topics required by the deployed service
-	topics, err := k.fetchRequiredTopics(service)
+	topics, resolved, err := k.fetchRequiredTopics(service)
 	if err != nil {
 		return err
 	}
 	for _, topic := range topics {
-		err := k.createKafkaTopicIfNotExists(topic.Name, infra)
-		if err != nil {
+		if !util.Contains(topic.Name, topicNames) {
+			topicNames = append(topicNames, topic.Name)
+		}
+	}
+
+	// group the topics by the KogitoInfra providing their Kafka instance
+	infras := map[string]*v1alpha1.KogitoInfra{}
+	if defaultInfra != nil {
+		infras[getInfraKey(defaultInfra)] = defaultInfra
+	}
+	for _, infra := range channelInfras {
+		if infrastructure.IsKafkaResource(infra) {
+			infras[getInfraKey(infra)] = infra
+		}
+	}
+	infraTopicNames := map[string][]string{}
+	for _, topicName := range topicNames {
+		if infra := resolveChannelInfra(topicName, channelInfras, defaultInfra, infrastructure.IsKafkaResource); infra != nil {
+			infraTopicNames[getInfraKey(infra)] = append(infraTopicNames[getInfraKey(infra)], topicName)
+		}
+	}
+
+	messaging := service.GetSpec().GetMessaging()
+	for _, infraKey := range getSortedInfraKeys(infras) {
+		infra := infras[infraKey]
+		if !infrastructure.IsStrimziKafkaResource(infra) {
+			log.Debugf("Ignoring Kafka Topics creation, the topics of the external Kafka cluster of KogitoInfra %s are not managed by the operator", infra.Name)
+			continue
+		}
+		if len(infra.Status.AppProps[QuarkusKafkaBootstrapAppProp]) == 0 {
+			log.Debugf("Ignoring Kafka Topics creation, Kafka URI is empty from the given KogitoInfra: %s", infra.Name)
+			continue
+		}
+		// the KafkaTopics are named after the KogitoInfra naming policy, while their settings are declared by channel
+		var kafkaTopicNames []string
+		for _, topicName := range infraTopicNames[infraKey] {
+			kafkaTopicName := infrastructure.GetInfraResourceName(infra, topicName, service.GetNamespace())
+			if err := k.reconcileKafkaTopic(kafkaTopicName, messaging.GetTopic(topicName), infra, service); err != nil {
+				return err
+			}
+			kafkaTopicNames = append(kafkaTopicNames, kafkaTopicName)
+		}
+		if err := k.grantKafkaTopicsAccess(infra, kafkaTopicNames); err != nil {
+			return err
+		}
+		// we can only know which topics are not used anymore once the service topics are resolved
+		if !resolved {
+			continue
+		}
+		if err := k.releaseUnusedKafkaTopics(infra, service, kafkaTopicNames); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (k *kafkaMessagingDeployer) createKafkaTopicIfNotExists(topicName string, instance *v1alpha1.KogitoInfra) error {
-	log.Debugf("Going to create kafka topic it is not exists %k", topicName)
+// getChannelsProperties gets the application properties connecting the channels bound to a Kafka KogitoInfra
+// to its Kafka instance, along with the environment variables and volumes of the bound KogitoInfra instances
+// not referenced in the service infra.
+// The topic of the channels provided by a KogitoInfra with a naming policy is set as well, since it differs from the channel name.
+// Channels must be declared as consumed or produced to know which properties to set.
+func (k *kafkaMessagingDeployer) getChannelsProperties(service v1alpha1.KogitoService) (map[string]string, []corev1.EnvVar, []v1alpha1.KogitoInfraVolume, error) {
+	appProps := map[string]string{}
+	var envs []corev1.EnvVar
+	var volumes []v1alpha1.KogitoInfraVolume
+	defaultInfra, err := k.fetchInfraDependency(service, infrastructure.IsKafkaResource)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	channelInfras, err := k.fetchChannelInfras(service)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	namedDefaultInfra := defaultInfra != nil && defaultInfra.Spec.ResourceNaming != nil
+	if !namedDefaultInfra && len(channelInfras) == 0 {
+		return appProps, envs, volumes, nil
+	}
+	declaredTopics, err := k.fetchDeclaredTopics(service)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	topics := k.getDefinitionTopics()
+	for _, topic := range declaredTopics {
+		topics = appendMessageTopic(topics, topic)
+	}
+	if namedDefaultInfra {
+		for _, topic := range topics {
+			if _, bound := channelInfras[topic.Name]; !bound {
+				util.AppendToStringMap(getChannelTopicAppProps(topic, infrastructure.GetInfraResourceName(defaultInfra, topic.Name, service.GetNamespace())), appProps)
+			}
+		}
+	}
+	var infraKeys []types.NamespacedName
+	for _, infraName := range service.GetSpec().GetInfra() {
+		infraKeys = append(infraKeys, infrastructure.GetKogitoInfraKey(infraName, service.GetNamespace()))
+	}
+	for _, channel := range service.GetSpec().GetMessaging().Channels {
+		infra, bound := channelInfras[channel.Name]
+		if !bound || !infrastructure.IsKafkaResource(infra) {
+			continue
+		}
+		declared := false
+		for _, topic := range topics {
+			if topic.Name != channel.Name {
+				continue
+			}
+			declared = true
+			prefix := getChannelAppPropPrefix(topic)
+			for key, value := range infra.Status.AppProps {
+				if strings.HasPrefix(key, quarkusKafkaAppPropPrefix) {
+					appProps[prefix+strings.TrimPrefix(key, quarkusKafkaAppPropPrefix)] = value
+				}
+			}
+			util.AppendToStringMap(getChannelTopicAppProps(topic, infrastructure.GetInfraResourceName(infra, topic.Name, service.GetNamespace())), appProps)
+		}
+		if !declared {
+			log.Warnf("Channel %s bound to KogitoInfra %s is not declared as consumed or produced by service %s, skipping its properties", channel.Name, infra.Name, service.GetName())
+			continue
+		}
+		if infraKey := (types.NamespacedName{Namespace: infra.Namespace, Name: infra.Name}); !containsInfraKey(infraKey, infraKeys) {
+			infraKeys = append(infraKeys, infraKey)
+			envs = append(envs, infrastructure.GetKogitoInfraEnvs(infra, service.GetNamespace())...)
+			volumes = append(volumes, infrastructure.GetKogitoInfraVolumes(infra, service.GetNamespace())...)
+		}
+	}
+	return appProps, envs, volumes, nil
+}
+
+func containsInfraKey(key types.NamespacedName, keys []types.NamespacedName) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// getChannelAppPropPrefix gets the prefix of the quarkus application properties configuring the channel of the given topic
+func getChannelAppPropPrefix(topic messageTopic) string {
+	if topic.Kind == produced {
+		return quarkusOutgoingChannelAppPropPrefix + topic.Name + "."
+	}
+	return quarkusIncomingChannelAppPropPrefix + topic.Name + "."
+}
+
+// getChannelTopicAppProps gets the quarkus and spring boot application properties setting the kafka topic of the channel of the given topic
+func getChannelTopicAppProps(topic messageTopic, topicName string) map[string]string {
+	springPrefix := springIncomingTopicAppPropPrefix
+	if topic.Kind == produced {
+		springPrefix = springOutgoingTopicAppPropPrefix
+	}
+	return map[string]string{
+		getChannelAppPropPrefix(topic) + quarkusChannelTopicAppProp: topicName,
+		springPrefix + topic.Name:                                   topicName,
+	}
+}
 
+// releaseResources stops tracking the given service in the KafkaTopics it uses, deleting the ones not used anymore
+func (k *kafkaMessagingDeployer) releaseResources(service v1alpha1.KogitoService) error {
+	for _, infraName := range infrastructure.GetKogitoInfraReferences(service) {
+		infra := &v1alpha1.KogitoInfra{}
+		if exists, err := kubernetes.ResourceC(k.cli).FetchWithKey(infrastructure.GetKogitoInfraKey(infraName, service.GetNamespace()), infra); err != nil {
+			return err
+		} else if !exists || !infrastructure.IsStrimziKafkaResource(infra) {
+			continue
+		}
+		if err := k.releaseUnusedKafkaTopics(infra, service, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseUnusedKafkaTopics stops tracking the given service in the KafkaTopics not in the given list.
+// KafkaTopics not used by any service are deleted.
+func (k *kafkaMessagingDeployer) releaseUnusedKafkaTopics(infra *v1alpha1.KogitoInfra, service v1alpha1.KogitoService, topicNames []string) error {
+	kafkaNamespaceName := k.getKafkaInstanceNamespaceName(infra)
+	kafkaTopics := &kafkav1beta1.KafkaTopicList{}
+	if err := kubernetes.ResourceC(k.cli).ListWithNamespaceAndLabel(kafkaNamespaceName.Namespace, kafkaTopics, infrastructure.GetKafkaTopicLabels(kafkaNamespaceName.Name)); err != nil {
+		return err
+	}
+	serviceKey := infrastructure.GetKafkaTopicServiceKey(service.GetNamespace(), service.GetName())
+	for i := range kafkaTopics.Items {
+		kafkaTopic := &kafkaTopics.Items[i]
+		services := infrastructure.GetKafkaTopicServices(kafkaTopic)
+		if !util.Contains(serviceKey, services) || util.Contains(kafkaTopic.Name, topicNames) {
+			continue
+		}
+		framework.RemoveOwnerReference(service, kafkaTopic)
+		services = util.RemoveFromSlice(services, serviceKey)
+		if len(services) == 0 {
+			log.Debugf("Kafka topic %s not used anymore, deleting it", kafkaTopic.Name)
+			if err := kubernetes.ResourceC(k.cli).Delete(kafkaTopic); err != nil {
+				return err
+			}
+			continue
+		}
+		log.Debugf("Kafka topic %s not used anymore by service %s", kafkaTopic.Name, serviceKey)
+		infrastructure.SetKafkaTopicServices(kafkaTopic, services)
+		if err := kubernetes.ResourceC(k.cli).Update(kafkaTopic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackKafkaTopicService tracks the given service as user of the KafkaTopic created by the operator.
+// When the KafkaTopic is in the service namespace, the service is also added as owner of the KafkaTopic.
+// The messaging finalizer of the service untracks it from the KafkaTopic once deleted, deleting the KafkaTopic if not used anymore.
+// Returns true if the KafkaTopic has been changed.
+func (k *kafkaMessagingDeployer) trackKafkaTopicService(kafkaTopic *kafkav1beta1.KafkaTopic, service v1alpha1.KogitoService) (bool, error) {
+	if !infrastructure.IsManagedKafkaTopic(kafkaTopic) {
+		return false, nil
+	}
+	changed := false
+	if services := infrastructure.GetKafkaTopicServices(kafkaTopic); !util.Contains(infrastructure.GetKafkaTopicServiceKey(service.GetNamespace(), service.GetName()), services) {
+		infrastructure.SetKafkaTopicServices(kafkaTopic, append(services, infrastructure.GetKafkaTopicServiceKey(service.GetNamespace(), service.GetName())))
+		changed = true
+	}
+	if kafkaTopic.Namespace == service.GetNamespace() {
+		if !framework.IsOwner(kafkaTopic, service) {
+			if err := framework.AddOwnerReference(service, k.scheme, kafkaTopic); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// grantKafkaTopicsAccess adds the ACLs for the given topics to the KafkaUser created by the KogitoInfra, if any
+func (k *kafkaMessagingDeployer) grantKafkaTopicsAccess(instance *v1alpha1.KogitoInfra, topicNames []string) error {
+	if instance.Spec.External != nil {
+		return nil
+	}
 	kafkaNamespaceName := k.getKafkaInstanceNamespaceName(instance)
-	log.Debugf("Resolved kafka instance name %k and namespace %k", kafkaNamespaceName.Name, kafkaNamespaceName.Namespace)
+	kafkaUser := &kafkav1beta1.KafkaUser{}
+	if exists, err := kubernetes.ResourceC(k.cli).FetchWithKey(types.NamespacedName{Name: instance.Name, Namespace: kafkaNamespaceName.Namespace}, kafkaUser); err != nil {
+		return err
+	} else if !exists {
+		log.Debugf("Kafka user %s not found, skipping topics authorization", instance.Name)
+		return nil
+	}
+	if infrastructure.AddKafkaUserTopicACLs(kafkaUser, topicNames...) {
+		log.Debugf("Granting kafka user %s access to topics %s", kafkaUser.Name, topicNames)
+		return kubernetes.ResourceC(k.cli).Update(kafkaUser)
+	}
+	return nil
+}
+
+// reconcileKafkaTopic creates the kafka topic with the given settings or updates the existing one to match them
+func (k *kafkaMessagingDeployer) reconcileKafkaTopic(topicName string, settings *v1alpha1.KafkaTopicSettings, instance *v1alpha1.KogitoInfra, service v1alpha1.KogitoService) error {
+	log.Debugf("Going to reconcile kafka topic %s", topicName)
+
+	kafkaNamespaceName := k.getKafkaInstanceNamespaceName(instance)
+	log.Debugf("Resolved kafka instance name %s and namespace %s", kafkaNamespaceName.Name, kafkaNamespaceName.Namespace)
 
 	kafkaTopic, err := k.loadDeployedKafkaTopic(topicName, kafkaNamespaceName.Namespace)
 	if err != nil {
@@ -83,12 +347,43 @@ func (k *kafkaMessagingDeployer) createKafkaTopicIfNotExists(topicName string, i
 	}
 
 	if kafkaTopic == nil {
-		_, err := k.createNewKafkaTopic(topicName, kafkaNamespaceName.Name, kafkaNamespaceName.Namespace)
-		if err != nil {
-			return err
-		}
+		_, err := k.createNewKafkaTopic(topicName, kafkaNamespaceName.Name, kafkaNamespaceName.Namespace, settings, service)
+		return err
 	}
-	return nil
+	return k.updateKafkaTopic(kafkaTopic, kafkaNamespaceName.Name, settings, service)
+}
+
+// updateKafkaTopic reconciles the settings of a KafkaTopic created by the operator, topics created by users are left untouched
+func (k *kafkaMessagingDeployer) updateKafkaTopic(kafkaTopic *kafkav1beta1.KafkaTopic, kafkaName string, settings *v1alpha1.KafkaTopicSettings, service v1alpha1.KogitoService) error {
+	if !infrastructure.IsManagedKafkaTopic(kafkaTopic) {
+		log.Debugf("Kafka topic %s not created by the operator, skipping its reconciliation", kafkaTopic.Name)
+		return nil
+	}
+	expected := infrastructure.GetKafkaTopic(kafkaTopic.Name, kafkaTopic.Namespace, kafkaName, settings)
+	if expected.Spec.Partitions < kafkaTopic.Spec.Partitions {
+		log.Warnf("Kafka topic %s has %d partitions, they can't be decreased to %d", kafkaTopic.Name, kafkaTopic.Spec.Partitions, expected.Spec.Partitions)
+		expected.Spec.Partitions = kafkaTopic.Spec.Partitions
+	}
+	// Strimzi doesn't reassign the partitions of existing topics, so their replicas are kept
+	if expected.Spec.Replicas != kafkaTopic.Spec.Replicas {
+		log.Warnf("Kafka topic %s has %d replicas, they can't be changed to %d once created", kafkaTopic.Name, kafkaTopic.Spec.Replicas, expected.Spec.Replicas)
+		expected.Spec.Replicas = kafkaTopic.Spec.Replicas
+	}
+	// the topic name can't be changed once the topic is created
+	expected.Spec.TopicName = kafkaTopic.Spec.TopicName
+	changed, err := k.trackKafkaTopicService(kafkaTopic, service)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(expected.Spec, kafkaTopic.Spec) {
+		log.Debugf("Kafka topic %s settings changed, updating it", kafkaTopic.Name)
+		kafkaTopic.Spec = expected.Spec
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return kubernetes.ResourceC(k.cli).Update(kafkaTopic)
 }
 
 func (k *kafkaMessagingDeployer) getKafkaInstanceNamespaceName(instance *v1alpha1.KogitoInfra) *types.NamespacedName {
@@ -121,9 +416,13 @@ func (k *kafkaMessagingDeployer) loadDeployedKafkaTopic(topicName, kafkaNamespac
 	return nil, nil
 }
 
-func (k *kafkaMessagingDeployer) createNewKafkaTopic(topicName, kafkaName, kafkaNamespace string) (*kafkav1beta1.KafkaTopic, error) {
+func (k *kafkaMessagingDeployer) createNewKafkaTopic(topicName, kafkaName, kafkaNamespace string, settings *v1alpha1.KafkaTopicSettings, service v1alpha1.KogitoService) (*kafkav1beta1.KafkaTopic, error) {
 	log.Debugf("Going to create kafka topic %s", topicName)
-	kafkaTopic := infrastructure.GetKafkaTopic(topicName, kafkaNamespace, kafkaName)
+	kafkaTopic := infrastructure.GetKafkaTopic(topicName, kafkaNamespace, kafkaName, settings)
+	infrastructure.SetKafkaTopicServices(kafkaTopic, nil)
+	if _, err := k.trackKafkaTopicService(kafkaTopic, service); err != nil {
+		return nil, err
+	}
 	if err := kubernetes.ResourceC(k.cli).Create(kafkaTopic); err != nil {
 		log.Error("Error occurs while creating kogito Kafka topic")
 		return nil, err
@@ -131,3 +430,18 @@ func (k *kafkaMessagingDeployer) createNewKafkaTopic(topicName, kafkaName, kafka
 	log.Debugf("Kogito Kafka topic(%s) created successfully", topicName)
 	return kafkaTopic, nil
 }
+
+// getInfraKey gets the namespace/name key of the given KogitoInfra, since KogitoInfra instances of different namespaces may share the name
+func getInfraKey(infra *v1alpha1.KogitoInfra) string {
+	return types.NamespacedName{Namespace: infra.Namespace, Name: infra.Name}.String()
+}
+
+// getSortedInfraKeys gets the keys of the given KogitoInfra instances, sorted to reconcile them in a stable order
+func getSortedInfraKeys(infras map[string]*v1alpha1.KogitoInfra) []string {
+	keys := make([]string, 0, len(infras))
+	for key := range infras {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
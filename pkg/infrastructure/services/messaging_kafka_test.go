@@ -21,6 +21,7 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/kafka/v1beta1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
 	"github.com/stretchr/testify/assert"
@@ -79,3 +80,447 @@ func Test_createKafkaTopics(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, exists)
 }
+
+func Test_createKafkaTopics_GrantsKafkaUserAccess(t *testing.T) {
+	kogitoInfraInstance := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KafkaAPIVersion,
+				Kind:       infrastructure.KafkaKind,
+			},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "kogito-kafka:9093"},
+		},
+	}
+	kafka := &v1beta1.Kafka{ObjectMeta: metav1.ObjectMeta{Name: infrastructure.KafkaInstanceName, Namespace: t.Name()}}
+	kafka.Spec.Kafka.Authorization = &v1beta1.KafkaAuthorization{Type: v1beta1.KafkaSimpleAuthorization}
+	kafkaUser := infrastructure.GetKafkaUserDefaultResource(kogitoInfraInstance.Name, kafka, v1beta1.KafkaScramSha512Authentication)
+	service := &v1alpha1.KogitoSupportingService{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-index", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoSupportingServiceSpec{
+			ServiceType:       v1alpha1.DataIndex,
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{Infra: []string{kogitoInfraInstance.Name}},
+		},
+	}
+
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfraInstance, kafkaUser, service).Build()
+	k := kafkaMessagingDeployer{
+		messagingDeployer{
+			scheme:     meta.GetRegisteredSchema(),
+			cli:        client,
+			definition: ServiceDefinition{KafkaTopics: []string{"kogito-processinstances-events"}},
+		}}
+	assert.NoError(t, k.createRequiredResources(service))
+
+	test.AssertFetchMustExist(t, client, kafkaUser)
+	assert.Contains(t, kafkaUser.Spec.Authorization.ACLs, v1beta1.KafkaUserACL{
+		Resource:  v1beta1.KafkaUserACLResource{Type: v1beta1.KafkaACLTopicResource, Name: "kogito-processinstances-events", PatternType: "literal"},
+		Operation: v1beta1.KafkaACLReadOperation,
+	})
+}
+
+func Test_createKafkaTopics_WithSettings(t *testing.T) {
+	kogitoInfraInstance := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KafkaAPIVersion,
+				Kind:       infrastructure.KafkaKind,
+			},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "kogito-kafka:9092"},
+		},
+	}
+	retention := int64(86400000)
+	service := &v1alpha1.KogitoSupportingService{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-index", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoSupportingServiceSpec{
+			ServiceType: v1alpha1.DataIndex,
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra: []string{kogitoInfraInstance.Name},
+				Messaging: v1alpha1.Messaging{
+					Topics: []v1alpha1.KafkaTopicSettings{
+						{
+							Name:              "kogito-processinstances-events",
+							Partitions:        3,
+							ReplicationFactor: 2,
+							RetentionMs:       &retention,
+							CleanupPolicy:     v1alpha1.KafkaTopicCompactPolicy,
+						},
+						{Name: "kogito-users-events", Partitions: 3},
+					},
+				},
+			},
+		},
+	}
+	// existing topics created by the operator are reconciled to match the settings
+	existingTopic := infrastructure.GetKafkaTopic("kogito-processinstances-events", t.Name(), infrastructure.KafkaInstanceName, nil)
+	infrastructure.SetKafkaTopicServices(existingTopic, nil)
+	// partitions are never decreased
+	existingUserTopic := infrastructure.GetKafkaTopic("kogito-usertaskinstances-events", t.Name(), infrastructure.KafkaInstanceName, nil)
+	infrastructure.SetKafkaTopicServices(existingUserTopic, nil)
+	existingUserTopic.Spec.Partitions = 5
+	// topics created by users are left untouched
+	unmanagedTopic := infrastructure.GetKafkaTopic("kogito-users-events", t.Name(), infrastructure.KafkaInstanceName, nil)
+
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfraInstance, service, existingTopic, existingUserTopic, unmanagedTopic).Build()
+	k := kafkaMessagingDeployer{
+		messagingDeployer{
+			scheme: meta.GetRegisteredSchema(),
+			cli:    client,
+			definition: ServiceDefinition{
+				KafkaTopics: []string{"kogito-processinstances-events", "kogito-usertaskinstances-events", "kogito-jobs-events", "kogito-users-events"},
+			}}}
+	assert.NoError(t, k.createRequiredResources(service))
+
+	test.AssertFetchMustExist(t, client, existingTopic)
+	assert.Equal(t, int32(3), existingTopic.Spec.Partitions)
+	// replicas of existing topics are never changed
+	assert.Equal(t, int32(1), existingTopic.Spec.Replicas)
+	assert.Equal(t, "86400000", existingTopic.Spec.Config["retention.ms"])
+	assert.Equal(t, "compact", existingTopic.Spec.Config["cleanup.policy"])
+
+	test.AssertFetchMustExist(t, client, existingUserTopic)
+	assert.Equal(t, int32(5), existingUserTopic.Spec.Partitions)
+
+	test.AssertFetchMustExist(t, client, unmanagedTopic)
+	assert.Equal(t, int32(1), unmanagedTopic.Spec.Partitions)
+	assert.False(t, infrastructure.IsManagedKafkaTopic(unmanagedTopic))
+
+	// topics without settings are created with the defaults
+	jobsTopic := &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "kogito-jobs-events", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, jobsTopic)
+	assert.Equal(t, int32(1), jobsTopic.Spec.Partitions)
+	assert.Empty(t, jobsTopic.Spec.Config)
+}
+
+func Test_createKafkaTopics_ReleaseUnusedTopics(t *testing.T) {
+	kogitoInfraInstance := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KafkaAPIVersion,
+				Kind:       infrastructure.KafkaKind,
+			},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "kogito-kafka:9092"},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra:     []string{kogitoInfraInstance.Name},
+				Messaging: v1alpha1.Messaging{Consumed: []string{"travellers", "visas"}},
+			},
+		},
+	}
+	// topic shared with another service
+	sharedTopic := infrastructure.GetKafkaTopic("visas", t.Name(), infrastructure.KafkaInstanceName, nil)
+	infrastructure.SetKafkaTopicServices(sharedTopic, []string{t.Name() + "/visas"})
+
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfraInstance, service, sharedTopic).Build()
+	k := kafkaMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, k.createRequiredResources(service))
+
+	travellersTopic := &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "travellers", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, travellersTopic)
+	assert.Equal(t, t.Name()+"/travels", travellersTopic.Annotations[infrastructure.KafkaTopicServicesAnnotation])
+	assert.True(t, framework.IsOwner(travellersTopic, service))
+	test.AssertFetchMustExist(t, client, sharedTopic)
+	assert.Equal(t, t.Name()+"/travels,"+t.Name()+"/visas", sharedTopic.Annotations[infrastructure.KafkaTopicServicesAnnotation])
+	// the service must be untracked from the KafkaTopics once deleted, even in the same namespace
+	assert.NoError(t, addMessagingFinalizer(k.messagingDeployer, service))
+	assert.Contains(t, service.Finalizers, messagingFinalizer)
+
+	// channels are not consumed anymore
+	service.Spec.Messaging.Consumed = []string{"processedtravellers"}
+	assert.NoError(t, k.createRequiredResources(service))
+	test.AssertFetchMustNotExist(t, client, travellersTopic)
+	sharedTopic = &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "visas", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, sharedTopic)
+	assert.Equal(t, t.Name()+"/visas", sharedTopic.Annotations[infrastructure.KafkaTopicServicesAnnotation])
+	assert.False(t, framework.IsOwner(sharedTopic, service))
+}
+
+func Test_releaseMessagingResources_CrossNamespaceTopics(t *testing.T) {
+	kogitoInfraInstance := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KafkaAPIVersion,
+				Kind:       infrastructure.KafkaKind,
+				Name:       "my-kafka",
+				Namespace:  "kafka",
+			},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "my-kafka:9092"},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra:     []string{kogitoInfraInstance.Name},
+				Messaging: v1alpha1.Messaging{Consumed: []string{"travellers"}},
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfraInstance, service).Build()
+	k := kafkaMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, k.createRequiredResources(service))
+
+	travellersTopic := &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "travellers", Namespace: "kafka"}}
+	test.AssertFetchMustExist(t, client, travellersTopic)
+	assert.Empty(t, travellersTopic.OwnerReferences)
+	assert.NoError(t, addMessagingFinalizer(k.messagingDeployer, service))
+	test.AssertFetchMustExist(t, client, service)
+	assert.Contains(t, service.Finalizers, messagingFinalizer)
+
+	assert.NoError(t, releaseMessagingResources(client, meta.GetRegisteredSchema(), ServiceDefinition{}, service))
+	test.AssertFetchMustNotExist(t, client, travellersTopic)
+	service = &v1alpha1.KogitoRuntime{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, service)
+	assert.NotContains(t, service.Finalizers, messagingFinalizer)
+}
+
+func Test_releaseMessagingResources_SameNamespaceTopics(t *testing.T) {
+	kogitoInfraInstance := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{APIVersion: infrastructure.KafkaAPIVersion, Kind: infrastructure.KafkaKind},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "kogito-kafka:9092"},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra:     []string{kogitoInfraInstance.Name},
+				Messaging: v1alpha1.Messaging{Consumed: []string{"visas"}},
+			},
+		},
+	}
+	// topic shared with another service
+	sharedTopic := infrastructure.GetKafkaTopic("visas", t.Name(), infrastructure.KafkaInstanceName, nil)
+	infrastructure.SetKafkaTopicServices(sharedTopic, []string{t.Name() + "/visas"})
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfraInstance, service, sharedTopic).Build()
+	k := kafkaMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, addMessagingFinalizer(k.messagingDeployer, service))
+	assert.NoError(t, k.createRequiredResources(service))
+	test.AssertFetchMustExist(t, client, sharedTopic)
+	assert.Equal(t, t.Name()+"/travels,"+t.Name()+"/visas", sharedTopic.Annotations[infrastructure.KafkaTopicServicesAnnotation])
+
+	// the service is deleted
+	assert.NoError(t, releaseMessagingResources(client, meta.GetRegisteredSchema(), ServiceDefinition{}, service))
+	sharedTopic = &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "visas", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, sharedTopic)
+	assert.Equal(t, t.Name()+"/visas", sharedTopic.Annotations[infrastructure.KafkaTopicServicesAnnotation])
+	assert.False(t, framework.IsOwner(sharedTopic, service))
+	service = &v1alpha1.KogitoRuntime{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, service)
+	assert.NotContains(t, service.Finalizers, messagingFinalizer)
+}
+
+func Test_createKafkaTopics_BoundChannels(t *testing.T) {
+	internalInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KafkaAPIVersion,
+				Kind:       infrastructure.KafkaKind,
+			},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "internal-kafka:9092"},
+		},
+	}
+	partnerInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "partner-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KafkaAPIVersion,
+				Kind:       infrastructure.KafkaKind,
+				Name:       "partner",
+				Namespace:  "partners",
+			},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{
+				QuarkusKafkaBootstrapAppProp: "partner-kafka.partners:9093",
+				"kafka.security.protocol":    "SSL",
+			},
+			Volumes: []v1alpha1.KogitoInfraVolume{{Name: "partner-kafka-kafka-credential", SecretName: "partner-kafka-kafka-credential"}},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra: []string{internalInfra.Name},
+				Messaging: v1alpha1.Messaging{
+					Consumed: []string{"travellers"},
+					Produced: []string{"bookings"},
+					Channels: []v1alpha1.MessagingChannel{{Name: "bookings", Infra: partnerInfra.Name}},
+				},
+			},
+		},
+	}
+
+	client := test.NewFakeClientBuilder().AddK8sObjects(internalInfra, partnerInfra, service).Build()
+	k := kafkaMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, k.createRequiredResources(service))
+
+	travellersTopic := &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "travellers", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, client, travellersTopic)
+	assert.Equal(t, infrastructure.GetKafkaTopicLabels(infrastructure.KafkaInstanceName), travellersTopic.Labels)
+	bookingsTopic := &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "bookings", Namespace: "partners"}}
+	test.AssertFetchMustExist(t, client, bookingsTopic)
+	assert.Equal(t, infrastructure.GetKafkaTopicLabels("partner"), bookingsTopic.Labels)
+	test.AssertFetchMustNotExist(t, client, &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "bookings", Namespace: t.Name()}})
+
+	appProps, _, volumes, err := k.getChannelsProperties(service)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"mp.messaging.outgoing.bookings.bootstrap.servers":               "partner-kafka.partners:9093",
+		"mp.messaging.outgoing.bookings.security.protocol":               "SSL",
+		"mp.messaging.outgoing.bookings.topic":                           "bookings",
+		"kogito.addon.cloudevents.kafka.kogito_outgoing_stream.bookings": "bookings",
+	}, appProps)
+	assert.Equal(t, partnerInfra.Status.Volumes, volumes)
+}
+
+func Test_createKafkaTopics_SameNameInfrasOfOtherNamespaces(t *testing.T) {
+	localInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{APIVersion: infrastructure.KafkaAPIVersion, Kind: infrastructure.KafkaKind},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "kafka:9092"},
+		},
+	}
+	partnerInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "partners"},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource:          v1alpha1.Resource{APIVersion: infrastructure.KafkaAPIVersion, Kind: infrastructure.KafkaKind},
+			AllowedNamespaces: []string{t.Name()},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "kafka.partners:9092"},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra: []string{localInfra.Name},
+				Messaging: v1alpha1.Messaging{
+					Consumed: []string{"travellers"},
+					Produced: []string{"bookings"},
+					Channels: []v1alpha1.MessagingChannel{{Name: "bookings", Infra: "partners/kafka"}},
+				},
+			},
+		},
+	}
+
+	client := test.NewFakeClientBuilder().AddK8sObjects(localInfra, partnerInfra, service).Build()
+	k := kafkaMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, k.createRequiredResources(service))
+
+	test.AssertFetchMustExist(t, client, &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "travellers", Namespace: t.Name()}})
+	test.AssertFetchMustExist(t, client, &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "bookings", Namespace: "partners"}})
+	test.AssertFetchMustNotExist(t, client, &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "bookings", Namespace: t.Name()}})
+}
+
+func Test_createKafkaTopics_ExternalKafkaChannel(t *testing.T) {
+	externalInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "partner-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			External: &v1alpha1.ExternalInfra{Kafka: &v1alpha1.ExternalKafka{BootstrapServers: "partner-kafka:9092"}},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "partner-kafka:9092"},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Messaging: v1alpha1.Messaging{
+					Produced: []string{"bookings"},
+					Channels: []v1alpha1.MessagingChannel{{Name: "bookings", Infra: externalInfra.Name}},
+				},
+			},
+		},
+	}
+
+	client := test.NewFakeClientBuilder().AddK8sObjects(externalInfra, service).Build()
+	k := kafkaMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, k.createRequiredResources(service))
+	// the topics of external clusters are not managed by the operator
+	test.AssertFetchMustNotExist(t, client, &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "bookings", Namespace: t.Name()}})
+
+	appProps, _, _, err := k.getChannelsProperties(service)
+	assert.NoError(t, err)
+	assert.Equal(t, "partner-kafka:9092", appProps["mp.messaging.outgoing.bookings.bootstrap.servers"])
+}
+
+func Test_createKafkaTopics_ResourceNaming(t *testing.T) {
+	sharedInfra := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-kafka", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KafkaAPIVersion,
+				Kind:       infrastructure.KafkaKind,
+				Name:       "shared",
+				Namespace:  "platform",
+			},
+			ResourceNaming: &v1alpha1.ResourceNamingPolicy{Prefix: "$(NAMESPACE)-", Suffix: "-v1"},
+		},
+		Status: v1alpha1.KogitoInfraStatus{
+			AppProps: map[string]string{QuarkusKafkaBootstrapAppProp: "shared-kafka.platform:9092"},
+		},
+	}
+	service := &v1alpha1.KogitoSupportingService{
+		ObjectMeta: metav1.ObjectMeta{Name: "jobs-service", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoSupportingServiceSpec{
+			ServiceType: v1alpha1.JobsService,
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra: []string{sharedInfra.Name},
+				Messaging: v1alpha1.Messaging{
+					Topics: []v1alpha1.KafkaTopicSettings{{Name: "kogito-job-service-job-status-events", Partitions: 3}},
+				},
+			},
+		},
+	}
+
+	client := test.NewFakeClientBuilder().AddK8sObjects(sharedInfra, service).Build()
+	k := kafkaMessagingDeployer{messagingDeployer{
+		scheme:     meta.GetRegisteredSchema(),
+		cli:        client,
+		definition: ServiceDefinition{ProducedKafkaTopics: []string{"kogito-job-service-job-status-events"}},
+	}}
+	assert.NoError(t, k.createRequiredResources(service))
+
+	topicName := t.Name() + "-kogito-job-service-job-status-events-v1"
+	kafkaTopic := &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: topicName, Namespace: "platform"}}
+	test.AssertFetchMustExist(t, client, kafkaTopic)
+	assert.Equal(t, topicName, kafkaTopic.Spec.TopicName)
+	assert.Equal(t, int32(3), kafkaTopic.Spec.Partitions)
+	test.AssertFetchMustNotExist(t, client, &v1beta1.KafkaTopic{ObjectMeta: metav1.ObjectMeta{Name: "kogito-job-service-job-status-events", Namespace: "platform"}})
+
+	appProps, _, _, err := k.getChannelsProperties(service)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"mp.messaging.outgoing.kogito-job-service-job-status-events.topic":                           topicName,
+		"kogito.addon.cloudevents.kafka.kogito_outgoing_stream.kogito-job-service-job-status-events": topicName,
+	}, appProps)
+}
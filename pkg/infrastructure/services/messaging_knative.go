@@ -15,8 +15,11 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"github.com/RHsyseng/operator-utils/pkg/resource"
+	"reflect"
+
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
@@ -24,11 +27,17 @@ import (
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	sourcesv1alpha1 "knative.dev/eventing/pkg/apis/sources/v1alpha1"
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
 	"knative.dev/pkg/tracker"
+	controllercli "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const topicIdentifier = "kogito.kie.org/messageEventId"
@@ -40,42 +49,201 @@ type knativeMessagingDeployer struct {
 
 func (k *knativeMessagingDeployer) createRequiredResources(service v1alpha1.KogitoService) error {
 	infra, err := k.fetchInfraDependency(service, infrastructure.IsKnativeEventingResource)
-	if err != nil || infra == nil {
+	if err != nil {
+		return err
+	}
+	channelInfras, err := k.fetchChannelInfras(service)
+	if err != nil {
 		return err
 	}
+	if infra == nil && !hasInfra(channelInfras, infrastructure.IsKnativeEventingResource) {
+		return nil
+	}
 
 	// since we depend on Knative, let's bind a SinkBinding object to our deployment
-	sinkBinding := k.newSinkBinding(service, infra)
-	if _, err := kubernetes.ResourceC(k.cli).CreateIfNotExistsForOwner(sinkBinding, service, k.scheme); err != nil {
-		return err
+	if infra != nil {
+		sinkBinding := k.newSinkBinding(service, infra)
+		if _, err := kubernetes.ResourceC(k.cli).CreateIfNotExistsForOwner(sinkBinding, service, k.scheme); err != nil {
+			return err
+		}
 	}
 
 	// fetch for consumed topics to create our triggers
-	topics, err := k.fetchRequiredTopics(service)
+	topics, resolved, err := k.fetchRequiredTopics(service)
 	if err != nil {
 		return err
 	}
 
-	var knativeRes resource.KubernetesResource
-	exists := false
+	var consumedTopics []string
 	for _, topic := range topics {
 		if topic.Kind == consumed {
-			if exists, err = k.triggerExists(topic, service); err != nil {
+			topicInfra := resolveChannelInfra(topic.Name, channelInfras, infra, infrastructure.IsKnativeEventingResource)
+			if topicInfra == nil {
+				continue
+			}
+			consumedTopics = append(consumedTopics, topic.Name)
+			if err := k.reconcileTrigger(topic, service, topicInfra); err != nil {
 				return err
-			} else if !exists {
-				knativeRes = k.newTrigger(topic, service, infra)
-				if err := kubernetes.ResourceC(k.cli).CreateForOwner(knativeRes, service, k.scheme); err != nil {
-					return err
-				}
 			}
 		}
 	}
 
+	// we can only know which triggers are not used anymore once the service topics are resolved
+	if !resolved {
+		return nil
+	}
+	return k.deleteUnusedTriggers(service, consumedTopics)
+}
+
+// reconcileTrigger creates the Trigger subscribing the service to the given topic, or updates its filter and delivery
+// options if they don't match the service messaging settings
+func (k *knativeMessagingDeployer) reconcileTrigger(t messageTopic, service v1alpha1.KogitoService, infra *v1alpha1.KogitoInfra) error {
+	settings := k.getTriggerSettings(t, service)
+	delivery, err := getTriggerDeliverySpec(settings, service)
+	if err != nil {
+		return err
+	}
+	expected := k.newTrigger(t, service, infra)
+	trigger, err := k.fetchTrigger(t, service)
+	if err != nil {
+		return err
+	}
+	if trigger != nil && trigger.Spec.Broker != expected.Spec.Broker {
+		// the broker can't be changed once the trigger is created
+		log.Debugf("Channel %s bound to broker %s, replacing trigger %s", t.Name, expected.Spec.Broker, trigger.Name)
+		if err := kubernetes.ResourceC(k.cli).Delete(trigger); err != nil {
+			return err
+		}
+		trigger = nil
+	}
+	if trigger == nil {
+		if err := kubernetes.ResourceC(k.cli).CreateForOwner(expected, service, k.scheme); err != nil {
+			return err
+		}
+		trigger = expected
+	} else if !reflect.DeepEqual(trigger.Spec.Filter, expected.Spec.Filter) {
+		log.Debugf("Filter of trigger %s changed, updating", trigger.Name)
+		trigger.Spec.Filter = expected.Spec.Filter
+		if err := kubernetes.ResourceC(k.cli).Update(trigger); err != nil {
+			return err
+		}
+	}
+	return k.reconcileTriggerDelivery(trigger, delivery)
+}
+
+// reconcileTriggerDelivery sets the given delivery options in the deployed Trigger.
+// The Trigger API of the Knative Eventing version we depend on doesn't define them, so the deployed object is patched.
+func (k *knativeMessagingDeployer) reconcileTriggerDelivery(trigger *eventingv1.Trigger, delivery *eventingduckv1.DeliverySpec) error {
+	deployed := &unstructured.Unstructured{}
+	deployed.SetGroupVersionKind(eventingv1.SchemeGroupVersion.WithKind(infrastructure.KnativeEventingTriggerKind))
+	if exists, err := kubernetes.ResourceC(k.cli).FetchWithKey(types.NamespacedName{Name: trigger.Name, Namespace: trigger.Namespace}, deployed); err != nil || !exists {
+		return err
+	}
+	deployedDelivery, _, err := unstructured.NestedMap(deployed.Object, "spec", "delivery")
+	if err != nil {
+		return err
+	}
+	var expectedDelivery map[string]interface{}
+	if delivery != nil {
+		if expectedDelivery, err = runtime.DefaultUnstructuredConverter.ToUnstructured(delivery); err != nil {
+			return err
+		}
+	}
+	if len(deployedDelivery) == 0 && len(expectedDelivery) == 0 || reflect.DeepEqual(deployedDelivery, expectedDelivery) {
+		return nil
+	}
+	log.Debugf("Delivery options of trigger %s changed, updating", trigger.Name)
+	// a null delivery removes the options from the Trigger
+	patch, err := json.Marshal(map[string]interface{}{"spec": map[string]interface{}{"delivery": expectedDelivery}})
+	if err != nil {
+		return err
+	}
+	return k.cli.ControlCli.Patch(context.TODO(), deployed, controllercli.RawPatch(types.MergePatchType, patch))
+}
+
+// getTriggerSettings gets the Trigger settings defined in the service for the given topic, the default ones if not defined
+func (k *knativeMessagingDeployer) getTriggerSettings(t messageTopic, service v1alpha1.KogitoService) *v1alpha1.KnativeTriggerSettings {
+	messaging := service.GetSpec().GetMessaging()
+	if settings := messaging.GetTrigger(t.Name); settings != nil {
+		return settings
+	}
+	return &v1alpha1.KnativeTriggerSettings{Name: t.Name}
+}
+
+// getTriggerDeliverySpec converts the delivery settings of the Trigger to the Knative Eventing delivery options, nil if not defined
+func getTriggerDeliverySpec(settings *v1alpha1.KnativeTriggerSettings, service v1alpha1.KogitoService) (*eventingduckv1.DeliverySpec, error) {
+	if settings.Delivery == nil {
+		return nil, nil
+	}
+	delivery := &eventingduckv1.DeliverySpec{Retry: settings.Delivery.Retry}
+	if len(settings.Delivery.BackoffPolicy) > 0 {
+		policy := eventingduckv1.BackoffPolicyType(settings.Delivery.BackoffPolicy)
+		delivery.BackoffPolicy = &policy
+	}
+	if len(settings.Delivery.BackoffDelay) > 0 {
+		delay := settings.Delivery.BackoffDelay
+		delivery.BackoffDelay = &delay
+	}
+	if sink := settings.Delivery.DeadLetterSink; sink != nil {
+		delivery.DeadLetterSink = &duckv1.Destination{}
+		if len(sink.Name) > 0 {
+			delivery.DeadLetterSink.Ref = &duckv1.KReference{
+				Name:       sink.Name,
+				Namespace:  service.GetNamespace(),
+				Kind:       sink.Kind,
+				APIVersion: sink.APIVersion,
+			}
+			if len(delivery.DeadLetterSink.Ref.Kind) == 0 {
+				delivery.DeadLetterSink.Ref.Kind = meta.KindService.Name
+			}
+			if len(delivery.DeadLetterSink.Ref.APIVersion) == 0 {
+				delivery.DeadLetterSink.Ref.APIVersion = meta.KindService.GroupVersion.Version
+			}
+		}
+		if len(sink.URI) > 0 {
+			uri, err := apis.ParseURL(sink.URI)
+			if err != nil {
+				return nil, fmt.Errorf("invalid dead-letter sink URI %s for channel %s: %v", sink.URI, settings.Name, err)
+			}
+			delivery.DeadLetterSink.URI = uri
+		}
+	}
+	if err := delivery.Validate(context.TODO()); err != nil {
+		return nil, fmt.Errorf("invalid delivery options for channel %s: %v", settings.Name, err)
+	}
+	return delivery, nil
+}
+
+// deleteUnusedTriggers deletes the Triggers owned by the given service for topics not consumed anymore
+func (k *knativeMessagingDeployer) deleteUnusedTriggers(service v1alpha1.KogitoService, consumedTopics []string) error {
+	triggers := &eventingv1.TriggerList{}
+	if err := kubernetes.ResourceC(k.cli).ListWithNamespaceAndLabel(service.GetNamespace(), triggers, map[string]string{framework.LabelAppKey: service.GetName()}); err != nil {
+		return err
+	}
+	for i := range triggers.Items {
+		trigger := &triggers.Items[i]
+		if !framework.IsOwner(trigger, service) || util.Contains(trigger.Labels[topicIdentifier], consumedTopics) {
+			continue
+		}
+		log.Debugf("Topic %s not consumed anymore by service %s, deleting trigger %s", trigger.Labels[topicIdentifier], service.GetName(), trigger.Name)
+		if err := kubernetes.ResourceC(k.cli).Delete(trigger); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // newTrigger creates a new Knative Eventing Trigger reference for the given Topic
 func (k *knativeMessagingDeployer) newTrigger(t messageTopic, service v1alpha1.KogitoService, infra *v1alpha1.KogitoInfra) *eventingv1.Trigger {
+	brokerName, _ := infrastructure.GetKnativeEventingBrokerReference(infra)
+	settings := k.getTriggerSettings(t, service)
+	filter := eventingv1.TriggerFilterAttributes{"type": t.Name}
+	if len(settings.Type) > 0 {
+		filter["type"] = settings.Type
+	}
+	if len(settings.Source) > 0 {
+		filter["source"] = settings.Source
+	}
 	return &eventingv1.Trigger{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-listener-%s", service.GetName(), util.RandomSuffix()),
@@ -86,8 +254,8 @@ func (k *knativeMessagingDeployer) newTrigger(t messageTopic, service v1alpha1.K
 			},
 		},
 		Spec: eventingv1.TriggerSpec{
-			Broker: infra.Spec.Resource.Name,
-			Filter: &eventingv1.TriggerFilter{Attributes: eventingv1.TriggerFilterAttributes{"type": t.Name}},
+			Broker: brokerName,
+			Filter: &eventingv1.TriggerFilter{Attributes: filter},
 			Subscriber: duckv1.Destination{
 				Ref: &duckv1.KReference{
 					Name:       service.GetName(),
@@ -103,14 +271,7 @@ func (k *knativeMessagingDeployer) newTrigger(t messageTopic, service v1alpha1.K
 // newSinkBinding creates a new SinkBinding object targeting the given KogitoInfra resource and binding the
 // deployment resource owned by the given KogitoService
 func (k *knativeMessagingDeployer) newSinkBinding(service v1alpha1.KogitoService, infra *v1alpha1.KogitoInfra) *sourcesv1alpha1.SinkBinding {
-	ns := infra.Spec.Resource.Namespace
-	name := infra.Spec.Resource.Name
-	if len(ns) == 0 {
-		ns = service.GetNamespace()
-	}
-	if len(name) == 0 {
-		name = service.GetName()
-	}
+	name, ns := infrastructure.GetKnativeEventingBrokerReference(infra)
 	return &sourcesv1alpha1.SinkBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-publisher", service.GetName()),
@@ -142,19 +303,20 @@ func (k *knativeMessagingDeployer) newSinkBinding(service v1alpha1.KogitoService
 	}
 }
 
-func (k *knativeMessagingDeployer) triggerExists(t messageTopic, service v1alpha1.KogitoService) (bool, error) {
+// fetchTrigger fetches the Trigger owned by the given service for the given topic, nil if not found
+func (k *knativeMessagingDeployer) fetchTrigger(t messageTopic, service v1alpha1.KogitoService) (*eventingv1.Trigger, error) {
 	triggers := &eventingv1.TriggerList{}
 	labels := map[string]string{
 		framework.LabelAppKey: service.GetName(),
 		topicIdentifier:       t.Name,
 	}
 	if err := kubernetes.ResourceC(k.cli).ListWithNamespaceAndLabel(service.GetNamespace(), triggers, labels); err != nil {
-		return false, err
+		return nil, err
 	}
-	for _, trigger := range triggers.Items {
-		if framework.IsOwner(&trigger, service) {
-			return true, nil
+	for i := range triggers.Items {
+		if framework.IsOwner(&triggers.Items[i], service) {
+			return &triggers.Items[i], nil
 		}
 	}
-	return false, nil
+	return nil, nil
 }
@@ -0,0 +1,184 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func Test_knativeMessagingDeployer_DeleteUnusedTriggers(t *testing.T) {
+	kogitoInfraInstance := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-knative", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KnativeEventingAPIVersion,
+				Kind:       infrastructure.KnativeEventingBrokerKind,
+			},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra:     []string{kogitoInfraInstance.Name},
+				Messaging: v1alpha1.Messaging{Consumed: []string{"travellers", "visas"}},
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfraInstance, service).Build()
+	k := knativeMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, k.createRequiredResources(service))
+
+	triggers := &eventingv1.TriggerList{}
+	assert.NoError(t, kubernetes.ResourceC(client).ListWithNamespace(t.Name(), triggers))
+	assert.Len(t, triggers.Items, 2)
+
+	// visas are not consumed anymore
+	service.Spec.Messaging.Consumed = []string{"travellers"}
+	assert.NoError(t, k.createRequiredResources(service))
+	triggers = &eventingv1.TriggerList{}
+	assert.NoError(t, kubernetes.ResourceC(client).ListWithNamespace(t.Name(), triggers))
+	assert.Len(t, triggers.Items, 1)
+	assert.Equal(t, "travellers", triggers.Items[0].Labels[topicIdentifier])
+}
+
+func Test_knativeMessagingDeployer_UpdateTriggerFilter(t *testing.T) {
+	kogitoInfraInstance := &v1alpha1.KogitoInfra{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-knative", Namespace: t.Name()},
+		Spec: v1alpha1.KogitoInfraSpec{
+			Resource: v1alpha1.Resource{
+				APIVersion: infrastructure.KnativeEventingAPIVersion,
+				Kind:       infrastructure.KnativeEventingBrokerKind,
+			},
+		},
+	}
+	service := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name(), UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Infra:     []string{kogitoInfraInstance.Name},
+				Messaging: v1alpha1.Messaging{Consumed: []string{"travellers"}},
+			},
+		},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfraInstance, service).Build()
+	k := knativeMessagingDeployer{messagingDeployer{scheme: meta.GetRegisteredSchema(), cli: client}}
+	assert.NoError(t, k.createRequiredResources(service))
+
+	triggers := &eventingv1.TriggerList{}
+	assert.NoError(t, kubernetes.ResourceC(client).ListWithNamespace(t.Name(), triggers))
+	assert.Len(t, triggers.Items, 1)
+	assert.Equal(t, eventingv1.TriggerFilterAttributes{"type": "travellers"}, triggers.Items[0].Spec.Filter.Attributes)
+
+	service.Spec.Messaging.Triggers = []v1alpha1.KnativeTriggerSettings{
+		{
+			Name:   "travellers",
+			Type:   "org.acme.travels.traveller",
+			Source: "/travellers",
+			Delivery: &v1alpha1.KnativeDeliverySettings{
+				Retry:          &[]int32{3}[0],
+				DeadLetterSink: &v1alpha1.KnativeDestination{Name: "travellers-dlq"},
+			},
+		},
+	}
+	assert.NoError(t, k.createRequiredResources(service))
+	triggers = &eventingv1.TriggerList{}
+	assert.NoError(t, kubernetes.ResourceC(client).ListWithNamespace(t.Name(), triggers))
+	assert.Len(t, triggers.Items, 1)
+	assert.Equal(t, eventingv1.TriggerFilterAttributes{"type": "org.acme.travels.traveller", "source": "/travellers"}, triggers.Items[0].Spec.Filter.Attributes)
+}
+
+func Test_getTriggerDeliverySpec(t *testing.T) {
+	service := &v1alpha1.KogitoRuntime{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: t.Name()}}
+	retry := int32(5)
+	exponential := eventingduckv1.BackoffPolicyExponential
+	delay := "PT0.5S"
+	dlqURI, _ := apis.ParseURL("http://dlq.example.com/events")
+	tests := []struct {
+		name     string
+		settings *v1alpha1.KnativeTriggerSettings
+		want     *eventingduckv1.DeliverySpec
+		wantErr  bool
+	}{
+		{
+			"NoDelivery",
+			&v1alpha1.KnativeTriggerSettings{Name: "travellers"},
+			nil,
+			false,
+		},
+		{
+			"RetriesAndService",
+			&v1alpha1.KnativeTriggerSettings{
+				Name: "travellers",
+				Delivery: &v1alpha1.KnativeDeliverySettings{
+					Retry:          &retry,
+					BackoffPolicy:  v1alpha1.KnativeExponentialBackoffPolicy,
+					BackoffDelay:   delay,
+					DeadLetterSink: &v1alpha1.KnativeDestination{Name: "travellers-dlq"},
+				},
+			},
+			&eventingduckv1.DeliverySpec{
+				Retry:         &retry,
+				BackoffPolicy: &exponential,
+				BackoffDelay:  &delay,
+				DeadLetterSink: &duckv1.Destination{
+					Ref: &duckv1.KReference{Kind: "Service", APIVersion: "v1", Name: "travellers-dlq", Namespace: t.Name()},
+				},
+			},
+			false,
+		},
+		{
+			"URI",
+			&v1alpha1.KnativeTriggerSettings{
+				Name:     "travellers",
+				Delivery: &v1alpha1.KnativeDeliverySettings{DeadLetterSink: &v1alpha1.KnativeDestination{URI: dlqURI.String()}},
+			},
+			&eventingduckv1.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: dlqURI}},
+			false,
+		},
+		{
+			"InvalidDelay",
+			&v1alpha1.KnativeTriggerSettings{
+				Name:     "travellers",
+				Delivery: &v1alpha1.KnativeDeliverySettings{BackoffDelay: "500ms"},
+			},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getTriggerDeliverySpec(tt.settings, service)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
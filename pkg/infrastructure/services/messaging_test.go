@@ -52,6 +52,39 @@ func Test_fetchRequiredTopicsWithEmptyReply(t *testing.T) {
 	assert.Empty(t, topics)
 }
 
+func Test_fetchRequiredTopicsDeclaredInSpec(t *testing.T) {
+	instance := createServiceInstance(t)
+	instance.GetSpec().(*v1alpha1.KogitoRuntimeSpec).Messaging = v1alpha1.Messaging{
+		Consumed: []string{"travellers"},
+		Produced: []string{"processedtravellers", "processedtravellers"},
+	}
+
+	// the deployment is not required, topics are provisioned before the first rollout
+	m := messagingDeployer{cli: test.CreateFakeClient(nil, nil, nil)}
+	topics, resolved, err := m.fetchRequiredTopics(instance)
+	assert.NoError(t, err)
+	assert.True(t, resolved)
+	assert.Equal(t, []messageTopic{{Name: "travellers", Kind: consumed}, {Name: "processedtravellers", Kind: produced}}, topics)
+}
+
+func Test_fetchDeclaredTopics_ResolvedOncePerReconciliation(t *testing.T) {
+	instance := createServiceInstance(t)
+	instance.GetSpec().(*v1alpha1.KogitoRuntimeSpec).Messaging = v1alpha1.Messaging{Consumed: []string{"travellers"}}
+
+	m := newMessagingDeployer(test.CreateFakeClient(nil, nil, nil), nil, ServiceDefinition{})
+	kafkaHandler := kafkaMessagingDeployer{messagingDeployer: m}
+	amqpHandler := amqpMessagingDeployer{messagingDeployer: m}
+	topics, err := kafkaHandler.fetchDeclaredTopics(instance)
+	assert.NoError(t, err)
+	assert.Equal(t, []messageTopic{{Name: "travellers", Kind: consumed}}, topics)
+
+	// the handlers of the same reconciliation share the resolved topics
+	instance.GetSpec().(*v1alpha1.KogitoRuntimeSpec).Messaging.Consumed = []string{"visas"}
+	topics, err = amqpHandler.fetchDeclaredTopics(instance)
+	assert.NoError(t, err)
+	assert.Equal(t, []messageTopic{{Name: "travellers", Kind: consumed}}, topics)
+}
+
 func createAvailableDeployment(instance v1alpha1.KogitoService) *v1.Deployment {
 	return &v1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{Name: instance.GetName(), Namespace: instance.GetNamespace()},
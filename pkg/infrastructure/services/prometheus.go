@@ -15,111 +15,58 @@
 package services
 
 import (
-	"net/http"
+	"reflect"
 
+	"github.com/RHsyseng/operator-utils/pkg/resource"
 	monv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
-	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
-	"github.com/kiegroup/kogito-cloud-operator/pkg/operator"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 )
 
-const prometheusServerGroup = "monitoring.coreos.com"
-
-func configurePrometheus(client *client.Client, kogitoService v1alpha1.KogitoService, scheme *runtime.Scheme) (failedVerifyAddon bool, err error) {
-	prometheusAvailable := isPrometheusAvailable(client)
-	if !prometheusAvailable {
+// configurePrometheus creates or updates the ServiceMonitor scraping the metrics of the service and the PrometheusRule holding its alerts
+func configurePrometheus(client *client.Client, kogitoService v1alpha1.KogitoService, scheme *runtime.Scheme) error {
+	if !infrastructure.IsPrometheusAvailable(client) {
 		log.Debugf("prometheus operator not available in namespace")
-		return
-	}
-
-	deploymentAvailable, err := IsDeploymentAvailable(client, kogitoService)
-	if err != nil {
-		return
-	}
-	if !deploymentAvailable {
-		log.Debugf("Deployment is currently not available, will try in next reconciliation loop")
-		return
-	}
-
-	prometheusAddOnAvailable, err := isPrometheusAddOnAvailable(kogitoService)
-	if err != nil {
-		return true, err
+		return nil
 	}
-	if prometheusAddOnAvailable {
-		if err = createPrometheusServiceMonitorIfNotExists(client, kogitoService, scheme); err != nil {
-			return
-		}
-	}
-	return
-}
-
-// isPrometheusAvailable checks if Prometheus CRD is available in the cluster
-func isPrometheusAvailable(client *client.Client) bool {
-	return client.HasServerGroup(prometheusServerGroup)
-}
 
-func isPrometheusAddOnAvailable(kogitoService v1alpha1.KogitoService) (bool, error) {
-	url := infrastructure.GetKogitoServiceEndpoint(kogitoService)
-	url = url + getMonitoringPath(kogitoService.GetSpec().GetMonitoring())
-	if resp, err := http.Head(url); err != nil {
-		return false, err
-	} else if resp.StatusCode == http.StatusOK {
-		return true, nil
-	}
-	log.Debugf("Non-OK Http Status received")
-	return false, nil
-}
-
-func createPrometheusServiceMonitorIfNotExists(client *client.Client, kogitoService v1alpha1.KogitoService, scheme *runtime.Scheme) error {
-	serviceMonitor, err := loadDeployedServiceMonitor(client, kogitoService.GetName(), kogitoService.GetNamespace())
-	if err != nil {
+	serviceMonitor := createRequiredServiceMonitor(kogitoService)
+	if err := framework.SetOwner(kogitoService, scheme, serviceMonitor); err != nil {
 		return err
 	}
-	if serviceMonitor == nil {
-		_, err := createServiceMonitor(client, kogitoService, scheme)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func loadDeployedServiceMonitor(client *client.Client, instanceName, namespace string) (*monv1.ServiceMonitor, error) {
-	log.Debug("fetching deployed Service monitor instance with name %s in namespace %s", instanceName, namespace)
-	serviceMonitor := &monv1.ServiceMonitor{}
-	if exits, err := kubernetes.ResourceC(client).FetchWithKey(types.NamespacedName{Name: instanceName, Namespace: namespace}, serviceMonitor); err != nil {
-		log.Error("Error occurs while fetching Service monitor instance")
-		return nil, err
-	} else if !exits {
-		log.Debug("Service monitor instance is not exists")
-		return nil, nil
-	} else {
-		log.Debug("Service monitor instance found")
-		return serviceMonitor, nil
+	if err := syncOwnedResources(client, kogitoService, kogitoService.GetNamespace(), &monv1.ServiceMonitorList{}, reflect.TypeOf(monv1.ServiceMonitor{}),
+		[]resource.KubernetesResource{serviceMonitor}, framework.CreateServiceMonitorComparator()); err != nil {
+		return err
 	}
+	return reconcilePrometheusRule(client, kogitoService, scheme)
 }
 
-// createServiceMonitor create ServiceMonitor used for scraping by prometheus for kogito service
-func createServiceMonitor(cli *client.Client, kogitoService v1alpha1.KogitoService, scheme *runtime.Scheme) (*monv1.ServiceMonitor, error) {
+// createRequiredServiceMonitor creates the ServiceMonitor used for scraping by prometheus for kogito service
+func createRequiredServiceMonitor(kogitoService v1alpha1.KogitoService) *monv1.ServiceMonitor {
 	monitoring := kogitoService.GetSpec().GetMonitoring()
-	endPoint := monv1.Endpoint{}
-	endPoint.Path = getMonitoringPath(monitoring)
-	endPoint.Scheme = getMonitoringScheme(monitoring)
+	endPoint := monv1.Endpoint{
+		Path:                 getMonitoringPath(monitoring),
+		Scheme:               getMonitoringScheme(monitoring),
+		Interval:             monitoring.Interval,
+		ScrapeTimeout:        monitoring.ScrapeTimeout,
+		TLSConfig:            getMonitoringTLSConfig(monitoring),
+		MetricRelabelConfigs: getMonitoringMetricRelabelConfigs(monitoring),
+	}
+	if monitoring.BearerTokenSecret != nil {
+		endPoint.BearerTokenSecret = *monitoring.BearerTokenSecret
+	}
 
 	serviceSelectorLabels := make(map[string]string)
 	serviceSelectorLabels[framework.LabelAppKey] = kogitoService.GetName()
 
-	serviceMonitorLabels := make(map[string]string)
-	serviceMonitorLabels["name"] = operator.Name
+	serviceMonitorLabels := infrastructure.GetMonitoringLabels()
 	serviceMonitorLabels[framework.LabelAppKey] = kogitoService.GetName()
 
-	sm := &monv1.ServiceMonitor{
+	return &monv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      kogitoService.GetName(),
 			Namespace: kogitoService.GetNamespace(),
@@ -139,15 +86,34 @@ func createServiceMonitor(cli *client.Client, kogitoService v1alpha1.KogitoServi
 			},
 		},
 	}
+}
 
-	if err := framework.SetOwner(kogitoService, scheme, sm); err != nil {
-		return nil, err
+func getMonitoringTLSConfig(monitoring v1alpha1.Monitoring) *monv1.TLSConfig {
+	if monitoring.TLSConfig == nil {
+		return nil
+	}
+	return &monv1.TLSConfig{
+		CA:                 monv1.SecretOrConfigMap{Secret: monitoring.TLSConfig.CASecret},
+		Cert:               monv1.SecretOrConfigMap{Secret: monitoring.TLSConfig.CertSecret},
+		KeySecret:          monitoring.TLSConfig.KeySecret,
+		ServerName:         monitoring.TLSConfig.ServerName,
+		InsecureSkipVerify: monitoring.TLSConfig.InsecureSkipVerify,
 	}
-	if err := kubernetes.ResourceC(cli).Create(sm); err != nil {
-		log.Error("Error occurs while creating Service Monitor instance")
-		return nil, err
+}
+
+func getMonitoringMetricRelabelConfigs(monitoring v1alpha1.Monitoring) []*monv1.RelabelConfig {
+	var relabelConfigs []*monv1.RelabelConfig
+	for _, relabeling := range monitoring.MetricRelabelings {
+		relabelConfigs = append(relabelConfigs, &monv1.RelabelConfig{
+			SourceLabels: relabeling.SourceLabels,
+			Separator:    relabeling.Separator,
+			TargetLabel:  relabeling.TargetLabel,
+			Regex:        relabeling.Regex,
+			Replacement:  relabeling.Replacement,
+			Action:       relabeling.Action,
+		})
 	}
-	return sm, nil
+	return relabelConfigs
 }
 
 func getMonitoringPath(monitoring v1alpha1.Monitoring) string {
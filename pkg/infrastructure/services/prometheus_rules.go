@@ -0,0 +1,214 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"fmt"
+	"reflect"
+
+	monv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// default alerting rules generated for the Kogito services, replaced by the custom rules with the same name
+	alertProcessInstanceErrorRate = "KogitoProcessInstanceErrorRate"
+	alertSLAViolations            = "KogitoSLAViolations"
+	alertKafkaConsumerErrors      = "KogitoKafkaConsumerErrors"
+	alertPodRestarts              = "KogitoPodRestarts"
+
+	defaultProcessInstanceErrorRateThreshold int32 = 5
+	defaultSLAViolationsThreshold            int32 = 0
+	defaultKafkaConsumerErrorsThreshold      int32 = 0
+	defaultPodRestartsThreshold              int32 = 2
+
+	alertSeverityLabel   = "severity"
+	alertDefaultSeverity = "warning"
+	alertSummaryKey      = "summary"
+	alertDescriptionKey  = "description"
+	alertDefaultFor      = "5m"
+
+	// default metrics evaluated by the alerting rules: the process metrics published by the Kogito Prometheus monitoring addon,
+	// where the status label of the completed process instances holds their state, and the process metrics of the JVM
+	defaultProcessInstanceStartedMetric   = "kie_process_instance_started_total"
+	defaultProcessInstanceCompletedMetric = "kie_process_instance_completed_total"
+	// defaultProcessInstanceErrorStatus is the state of the Kogito process instances ended in error
+	defaultProcessInstanceErrorStatus = "5"
+	defaultSLAViolatedMetric          = "kie_process_instance_sla_violated_total"
+	defaultKafkaConsumerErrorsMetric  = "kafka_consumer_.*(error|failed).*_total"
+	defaultProcessStartTimeMetric     = "process_start_time_seconds"
+)
+
+// reconcilePrometheusRule creates or updates the PrometheusRule holding the alerting rules of the service,
+// or deletes it if the alerts are not enabled anymore
+func reconcilePrometheusRule(cli *client.Client, kogitoService v1alpha1.KogitoService, scheme *runtime.Scheme) error {
+	deployed := &monv1.PrometheusRule{ObjectMeta: metav1.ObjectMeta{Name: kogitoService.GetName(), Namespace: kogitoService.GetNamespace()}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(deployed)
+	if err != nil {
+		return err
+	}
+	if kogitoService.GetSpec().GetMonitoring().Alerts == nil {
+		if exists && metav1.IsControlledBy(deployed, kogitoService) {
+			log.Debugf("Alerts disabled for service %s, deleting PrometheusRule", kogitoService.GetName())
+			return kubernetes.ResourceC(cli).Delete(deployed)
+		}
+		return nil
+	}
+
+	requested := createPrometheusRule(kogitoService)
+	if !exists {
+		if err := framework.SetOwner(kogitoService, scheme, requested); err != nil {
+			return err
+		}
+		log.Debugf("Creating PrometheusRule for service %s", kogitoService.GetName())
+		return kubernetes.ResourceC(cli).Create(requested)
+	}
+	if reflect.DeepEqual(deployed.Spec, requested.Spec) && reflect.DeepEqual(deployed.Labels, requested.Labels) {
+		return nil
+	}
+	log.Debugf("Alerts of service %s changed, updating PrometheusRule", kogitoService.GetName())
+	deployed.Labels = requested.Labels
+	deployed.Spec = requested.Spec
+	return kubernetes.ResourceC(cli).Update(deployed)
+}
+
+// createPrometheusRule creates the PrometheusRule with the default and custom alerting rules of the service,
+// labeled to be evaluated by the Prometheus instances managed by KogitoInfra
+func createPrometheusRule(kogitoService v1alpha1.KogitoService) *monv1.PrometheusRule {
+	labels := infrastructure.GetMonitoringLabels()
+	labels[framework.LabelAppKey] = kogitoService.GetName()
+	return &monv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kogitoService.GetName(),
+			Namespace: kogitoService.GetNamespace(),
+			Labels:    labels,
+		},
+		Spec: monv1.PrometheusRuleSpec{
+			Groups: []monv1.RuleGroup{
+				{
+					Name:  kogitoService.GetName(),
+					Rules: getAlertingRules(kogitoService),
+				},
+			},
+		},
+	}
+}
+
+// getAlertingRules gets the default alerting rules of the service with the configured thresholds, replacing the ones
+// overridden by custom rules, followed by the other custom rules
+func getAlertingRules(kogitoService v1alpha1.KogitoService) []monv1.Rule {
+	alerts := kogitoService.GetSpec().GetMonitoring().Alerts
+	customRules := map[string]monv1.Rule{}
+	for _, rule := range alerts.Rules {
+		customRules[rule.Alert] = createCustomAlertingRule(rule)
+	}
+
+	var rules []monv1.Rule
+	for _, rule := range getDefaultAlertingRules(kogitoService, alerts.Thresholds, alerts.Metrics) {
+		if customRule, overridden := customRules[rule.Alert]; overridden {
+			rule = customRule
+			delete(customRules, rule.Alert)
+		}
+		rules = append(rules, rule)
+	}
+	for _, rule := range alerts.Rules {
+		if customRule, notAdded := customRules[rule.Alert]; notAdded {
+			rules = append(rules, customRule)
+			delete(customRules, rule.Alert)
+		}
+	}
+	return rules
+}
+
+func getDefaultAlertingRules(kogitoService v1alpha1.KogitoService, thresholds v1alpha1.MonitoringAlertThresholds, metrics v1alpha1.MonitoringAlertMetrics) []monv1.Rule {
+	// the ServiceMonitor sets the job label with the name of the scraped Service, named after the Kogito service
+	selector := fmt.Sprintf(`job="%s",namespace="%s"`, kogitoService.GetName(), kogitoService.GetNamespace())
+	name := kogitoService.GetName()
+	errorRate := getAlertThreshold(thresholds.ProcessInstanceErrorRate, defaultProcessInstanceErrorRateThreshold)
+	slaViolations := getAlertThreshold(thresholds.SLAViolations, defaultSLAViolationsThreshold)
+	kafkaConsumerErrors := getAlertThreshold(thresholds.KafkaConsumerErrors, defaultKafkaConsumerErrorsThreshold)
+	podRestarts := getAlertThreshold(thresholds.PodRestarts, defaultPodRestartsThreshold)
+	return []monv1.Rule{
+		createDefaultAlertingRule(alertProcessInstanceErrorRate,
+			fmt.Sprintf(`sum(rate(%s{%s,status="%s"}[5m])) / sum(rate(%s{%s}[5m])) * 100 > %d`,
+				getAlertMetric(metrics.ProcessInstanceCompleted, defaultProcessInstanceCompletedMetric), selector,
+				getAlertMetric(metrics.ProcessInstanceErrorStatus, defaultProcessInstanceErrorStatus),
+				getAlertMetric(metrics.ProcessInstanceStarted, defaultProcessInstanceStartedMetric), selector, errorRate),
+			fmt.Sprintf("High process instance error rate in %s", name),
+			fmt.Sprintf("More than %d%% of the process instances of %s ended in error over the last 5 minutes.", errorRate, name)),
+		createDefaultAlertingRule(alertSLAViolations,
+			fmt.Sprintf(`sum(increase(%s{%s}[5m])) > %d`, getAlertMetric(metrics.SLAViolated, defaultSLAViolatedMetric), selector, slaViolations),
+			fmt.Sprintf("SLA violations in %s", name),
+			fmt.Sprintf("More than %d process instance SLA violations in %s over the last 5 minutes.", slaViolations, name)),
+		createDefaultAlertingRule(alertKafkaConsumerErrors,
+			fmt.Sprintf(`sum(increase({__name__=~"%s",%s}[5m])) > %d`, getAlertMetric(metrics.KafkaConsumerErrors, defaultKafkaConsumerErrorsMetric), selector, kafkaConsumerErrors),
+			fmt.Sprintf("Kafka consumer errors in %s", name),
+			fmt.Sprintf("More than %d Kafka consumer errors in %s over the last 5 minutes.", kafkaConsumerErrors, name)),
+		createDefaultAlertingRule(alertPodRestarts,
+			fmt.Sprintf(`changes(%s{%s}[1h]) > %d`, getAlertMetric(metrics.ProcessStartTime, defaultProcessStartTimeMetric), selector, podRestarts),
+			fmt.Sprintf("Pod of %s restarting", name),
+			fmt.Sprintf("Pod {{ $labels.pod }} of %s restarted more than %d times over the last hour.", name, podRestarts)),
+	}
+}
+
+func createDefaultAlertingRule(alert, expr, summary, description string) monv1.Rule {
+	return monv1.Rule{
+		Alert:       alert,
+		Expr:        intstr.FromString(expr),
+		For:         alertDefaultFor,
+		Labels:      map[string]string{alertSeverityLabel: alertDefaultSeverity},
+		Annotations: map[string]string{alertSummaryKey: summary, alertDescriptionKey: description},
+	}
+}
+
+func createCustomAlertingRule(rule v1alpha1.MonitoringAlertRule) monv1.Rule {
+	labels := map[string]string{}
+	for key, value := range rule.Labels {
+		labels[key] = value
+	}
+	if len(rule.Severity) > 0 {
+		labels[alertSeverityLabel] = rule.Severity
+	} else if _, ok := labels[alertSeverityLabel]; !ok {
+		labels[alertSeverityLabel] = alertDefaultSeverity
+	}
+	return monv1.Rule{
+		Alert:       rule.Alert,
+		Expr:        intstr.FromString(rule.Expr),
+		For:         rule.For,
+		Labels:      labels,
+		Annotations: rule.Annotations,
+	}
+}
+
+func getAlertThreshold(threshold *int32, defaultThreshold int32) int32 {
+	if threshold == nil {
+		return defaultThreshold
+	}
+	return *threshold
+}
+
+func getAlertMetric(metric string, defaultMetric string) string {
+	if len(metric) == 0 {
+		return defaultMetric
+	}
+	return metric
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"testing"
+
+	monv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/infrastructure"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_reconcilePrometheusRule(t *testing.T) {
+	ns := t.Name()
+	kogitoService := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: ns, UID: test.GenerateUID()},
+		Spec: v1alpha1.KogitoRuntimeSpec{
+			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
+				Monitoring: v1alpha1.Monitoring{Alerts: &v1alpha1.MonitoringAlerts{}},
+			},
+		},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoService).Build()
+
+	assert.NoError(t, reconcilePrometheusRule(cli, kogitoService, meta.GetRegisteredSchema()))
+	rule := &monv1.PrometheusRule{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: ns}}
+	test.AssertFetchMustExist(t, cli, rule)
+	for key, value := range infrastructure.GetMonitoringLabels() {
+		assert.Equal(t, value, rule.Labels[key])
+	}
+	rules := rule.Spec.Groups[0].Rules
+	assert.Len(t, rules, 4)
+	assert.Equal(t, alertProcessInstanceErrorRate, rules[0].Alert)
+	assert.Contains(t, rules[0].Expr.String(), `job="travels"`)
+	assert.Contains(t, rules[0].Expr.String(), "> 5")
+	assert.Equal(t, alertDefaultSeverity, rules[0].Labels[alertSeverityLabel])
+
+	// thresholds and custom rules
+	errorRate := int32(10)
+	kogitoService.Spec.Monitoring.Alerts = &v1alpha1.MonitoringAlerts{
+		Thresholds: v1alpha1.MonitoringAlertThresholds{ProcessInstanceErrorRate: &errorRate},
+		Rules: []v1alpha1.MonitoringAlertRule{
+			{Alert: alertPodRestarts, Expr: "kube_pod_container_status_restarts_total > 0", Severity: "critical"},
+			{Alert: "TravelsHighLatency", Expr: "api_execution_elapsed_seconds > 1", For: "10m"},
+		},
+	}
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(kogitoService))
+	assert.NoError(t, reconcilePrometheusRule(cli, kogitoService, meta.GetRegisteredSchema()))
+	test.AssertFetchMustExist(t, cli, rule)
+	rules = rule.Spec.Groups[0].Rules
+	assert.Len(t, rules, 5)
+	assert.Contains(t, rules[0].Expr.String(), "> 10")
+	assert.Equal(t, alertPodRestarts, rules[3].Alert)
+	assert.Equal(t, "kube_pod_container_status_restarts_total > 0", rules[3].Expr.String())
+	assert.Equal(t, "critical", rules[3].Labels[alertSeverityLabel])
+	assert.Equal(t, "TravelsHighLatency", rules[4].Alert)
+	assert.Equal(t, "10m", rules[4].For)
+	assert.Equal(t, alertDefaultSeverity, rules[4].Labels[alertSeverityLabel])
+
+	// alerts disabled
+	kogitoService.Spec.Monitoring.Alerts = nil
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(kogitoService))
+	assert.NoError(t, reconcilePrometheusRule(cli, kogitoService, meta.GetRegisteredSchema()))
+	test.AssertFetchMustNotExist(t, cli, &monv1.PrometheusRule{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: ns}})
+}
+
+func Test_getDefaultAlertingRules(t *testing.T) {
+	kogitoService := &v1alpha1.KogitoRuntime{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: "team-a"}}
+	selector := `job="travels",namespace="team-a"`
+
+	rules := getDefaultAlertingRules(kogitoService, v1alpha1.MonitoringAlertThresholds{}, v1alpha1.MonitoringAlertMetrics{})
+	assert.Len(t, rules, 4)
+	assert.Equal(t, `sum(rate(kie_process_instance_completed_total{`+selector+`,status="5"}[5m])) / sum(rate(kie_process_instance_started_total{`+selector+`}[5m])) * 100 > 5`,
+		rules[0].Expr.String())
+	assert.Equal(t, `sum(increase(kie_process_instance_sla_violated_total{`+selector+`}[5m])) > 0`, rules[1].Expr.String())
+	assert.Equal(t, `sum(increase({__name__=~"kafka_consumer_.*(error|failed).*_total",`+selector+`}[5m])) > 0`, rules[2].Expr.String())
+	assert.Equal(t, `changes(process_start_time_seconds{`+selector+`}[1h]) > 2`, rules[3].Expr.String())
+
+	// metrics exposed with other names
+	metrics := v1alpha1.MonitoringAlertMetrics{
+		ProcessInstanceStarted:     "process_started_total",
+		ProcessInstanceCompleted:   "process_completed_total",
+		ProcessInstanceErrorStatus: "ERROR",
+		SLAViolated:                "process_sla_violated_total",
+		KafkaConsumerErrors:        "kafka_consumer_fetch_manager_errors_total",
+		ProcessStartTime:           "jvm_start_time_seconds",
+	}
+	rules = getDefaultAlertingRules(kogitoService, v1alpha1.MonitoringAlertThresholds{}, metrics)
+	assert.Equal(t, `sum(rate(process_completed_total{`+selector+`,status="ERROR"}[5m])) / sum(rate(process_started_total{`+selector+`}[5m])) * 100 > 5`,
+		rules[0].Expr.String())
+	assert.Equal(t, `sum(increase(process_sla_violated_total{`+selector+`}[5m])) > 0`, rules[1].Expr.String())
+	assert.Equal(t, `sum(increase({__name__=~"kafka_consumer_fetch_manager_errors_total",`+selector+`}[5m])) > 0`, rules[2].Expr.String())
+	assert.Equal(t, `changes(jvm_start_time_seconds{`+selector+`}[1h]) > 2`, rules[3].Expr.String())
+}
@@ -15,17 +15,18 @@
 package services
 
 import (
+	monv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
 	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
 )
 
 func Test_createServiceMonitor_defaultConfiguration(t *testing.T) {
 	ns := t.Name()
-	cli := test.CreateFakeClient(nil, nil, nil)
 	kogitoService := &v1alpha1.KogitoRuntime{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "travels",
@@ -33,15 +34,13 @@ func Test_createServiceMonitor_defaultConfiguration(t *testing.T) {
 		},
 	}
 
-	serviceMonitor, err := createServiceMonitor(cli, kogitoService, meta.GetRegisteredSchema())
-	assert.NoError(t, err)
+	serviceMonitor := createRequiredServiceMonitor(kogitoService)
 	assert.Equal(t, v1alpha1.MonitoringDefaultPath, serviceMonitor.Spec.Endpoints[0].Path)
 	assert.Equal(t, v1alpha1.MonitoringDefaultScheme, serviceMonitor.Spec.Endpoints[0].Scheme)
 }
 
 func Test_createServiceMonitor_customConfiguration(t *testing.T) {
 	ns := t.Name()
-	cli := test.CreateFakeClient(nil, nil, nil)
 	kogitoService := &v1alpha1.KogitoRuntime{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "travels",
@@ -50,15 +49,54 @@ func Test_createServiceMonitor_customConfiguration(t *testing.T) {
 		Spec: v1alpha1.KogitoRuntimeSpec{
 			KogitoServiceSpec: v1alpha1.KogitoServiceSpec{
 				Monitoring: v1alpha1.Monitoring{
-					Path:   "/testPath",
-					Scheme: "https",
+					Path:          "/testPath",
+					Scheme:        "https",
+					Interval:      "30s",
+					ScrapeTimeout: "10s",
+					TLSConfig: &v1alpha1.MonitoringTLSConfig{
+						CASecret:   &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "travels-tls"}, Key: "ca.crt"},
+						ServerName: "travels",
+					},
+					BearerTokenSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "travels-token"}, Key: "token"},
+					MetricRelabelings: []v1alpha1.MonitoringRelabelConfig{
+						{SourceLabels: []string{"__name__"}, Regex: "jvm_.*", Action: "drop"},
+					},
 				},
 			},
 		},
 	}
 
-	serviceMonitor, err := createServiceMonitor(cli, kogitoService, meta.GetRegisteredSchema())
-	assert.NoError(t, err)
+	serviceMonitor := createRequiredServiceMonitor(kogitoService)
 	assert.Equal(t, "/testPath", serviceMonitor.Spec.Endpoints[0].Path)
 	assert.Equal(t, "https", serviceMonitor.Spec.Endpoints[0].Scheme)
+	assert.Equal(t, "30s", serviceMonitor.Spec.Endpoints[0].Interval)
+	assert.Equal(t, "10s", serviceMonitor.Spec.Endpoints[0].ScrapeTimeout)
+	assert.Equal(t, "travels-tls", serviceMonitor.Spec.Endpoints[0].TLSConfig.CA.Secret.Name)
+	assert.Equal(t, "travels", serviceMonitor.Spec.Endpoints[0].TLSConfig.ServerName)
+	assert.Equal(t, "travels-token", serviceMonitor.Spec.Endpoints[0].BearerTokenSecret.Name)
+	assert.Equal(t, []string{"__name__"}, serviceMonitor.Spec.Endpoints[0].MetricRelabelConfigs[0].SourceLabels)
+	assert.Equal(t, "drop", serviceMonitor.Spec.Endpoints[0].MetricRelabelConfigs[0].Action)
+}
+
+func Test_configurePrometheus_updatesServiceMonitor(t *testing.T) {
+	ns := t.Name()
+	kogitoService := &v1alpha1.KogitoRuntime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "travels",
+			Namespace: ns,
+			UID:       test.GenerateUID(),
+		},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoService).SupportPrometheus().Build()
+
+	// the deployment is not available, the ServiceMonitor is created anyway
+	assert.NoError(t, configurePrometheus(cli, kogitoService, meta.GetRegisteredSchema()))
+	serviceMonitor := &monv1.ServiceMonitor{ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: ns}}
+	test.AssertFetchMustExist(t, cli, serviceMonitor)
+	assert.Empty(t, serviceMonitor.Spec.Endpoints[0].Interval)
+
+	kogitoService.Spec.Monitoring.Interval = "1m"
+	assert.NoError(t, configurePrometheus(cli, kogitoService, meta.GetRegisteredSchema()))
+	test.AssertFetchMustExist(t, cli, serviceMonitor)
+	assert.Equal(t, "1m", serviceMonitor.Spec.Endpoints[0].Interval)
 }
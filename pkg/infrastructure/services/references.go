@@ -0,0 +1,151 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"crypto/md5"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/kubernetes"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/client/meta"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ReferencedObjectsContentHashKey is the annotation key for the content hash of the Secrets and ConfigMaps referenced by the service pods
+	ReferencedObjectsContentHashKey = "referencedObjectsContentHash"
+)
+
+// getReferencedObjectsContentHash calculates the hash of the contents of every Secret and ConfigMap referenced by the Deployment Pod template.
+// The application.properties ConfigMap and the objects owned by the service are ignored since their changes are already handled during the reconciliation.
+func getReferencedObjectsContentHash(service v1alpha1.KogitoService, deployment *appsv1.Deployment, cli *client.Client) (string, error) {
+	content := ""
+	for _, reference := range framework.GetPodReferencedObjects(&deployment.Spec.Template) {
+		if reference.Kind == framework.ConfigMapReferenceKind && reference.Name == getAppPropConfigMapName(service) {
+			continue
+		}
+		var object meta.ResourceObject
+		objectMeta := metav1.ObjectMeta{Name: reference.Name, Namespace: service.GetNamespace()}
+		switch reference.Kind {
+		case framework.SecretReferenceKind:
+			object = &corev1.Secret{ObjectMeta: objectMeta}
+		case framework.ConfigMapReferenceKind:
+			object = &corev1.ConfigMap{ObjectMeta: objectMeta}
+		}
+		exists, err := kubernetes.ResourceC(cli).Fetch(object)
+		if err != nil {
+			return "", err
+		}
+		if exists && framework.IsOwner(object, service) {
+			continue
+		}
+		content = fmt.Sprintf("%s\n%s/%s", content, reference.Kind, reference.Name)
+		if !exists {
+			continue
+		}
+		var data map[string][]byte
+		switch o := object.(type) {
+		case *corev1.Secret:
+			data = o.Data
+		case *corev1.ConfigMap:
+			data = map[string][]byte{}
+			for key, value := range o.Data {
+				data[key] = []byte(value)
+			}
+			for key, value := range o.BinaryData {
+				data[key] = value
+			}
+		}
+		var keys []string
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			content = fmt.Sprintf("%s\n%s=%x", content, key, md5.Sum(data[key]))
+		}
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(content))), nil
+}
+
+// applyReferencedObjectsConfigurations sets the content hash of the referenced objects in the Pod template, triggering a new rollout when they change
+func (s *serviceDeployer) applyReferencedObjectsConfigurations(contentHash string, deployment *appsv1.Deployment) {
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[ReferencedObjectsContentHashKey] = contentHash
+}
+
+// NewReferencedObjectsEventHandler creates a new EventHandler for Secrets and ConfigMaps that enqueues the Kogito Services of the given kind
+// whose Deployment references the changed object
+func NewReferencedObjectsEventHandler(cli *client.Client, ownerKind string) handler.EventHandler {
+	return &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			var kind framework.ReferencedObjectKind
+			switch object.Object.(type) {
+			case *corev1.Secret:
+				kind = framework.SecretReferenceKind
+			case *corev1.ConfigMap:
+				kind = framework.ConfigMapReferenceKind
+			default:
+				return nil
+			}
+			deployments := &appsv1.DeploymentList{}
+			if err := kubernetes.ResourceC(cli).ListWithNamespace(object.Meta.GetNamespace(), deployments); err != nil {
+				log.Errorf("Impossible to list Deployments referencing %s %s: %v", kind, object.Meta.GetName(), err)
+				return nil
+			}
+			var requests []reconcile.Request
+			for i := range deployments.Items {
+				owner := metav1.GetControllerOf(&deployments.Items[i])
+				if owner == nil || owner.Kind != ownerKind {
+					continue
+				}
+				if framework.IsReferencedByPod(&deployments.Items[i].Spec.Template, kind, object.Meta.GetName()) {
+					log.Debugf("%s %s referenced by %s %s changed", kind, object.Meta.GetName(), ownerKind, owner.Name)
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: owner.Name, Namespace: object.Meta.GetNamespace()}})
+				}
+			}
+			return requests
+		}),
+	}
+}
+
+// ReferencedObjectsPredicate filters out the Secrets and ConfigMaps updates that don't change their contents
+var ReferencedObjectsPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		switch oldObject := e.ObjectOld.(type) {
+		case *corev1.Secret:
+			newObject := e.ObjectNew.(*corev1.Secret)
+			return !reflect.DeepEqual(oldObject.Data, newObject.Data) || !reflect.DeepEqual(oldObject.StringData, newObject.StringData)
+		case *corev1.ConfigMap:
+			newObject := e.ObjectNew.(*corev1.ConfigMap)
+			return !reflect.DeepEqual(oldObject.Data, newObject.Data) || !reflect.DeepEqual(oldObject.BinaryData, newObject.BinaryData)
+		}
+		return true
+	},
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/framework"
+	"github.com/kiegroup/kogito-cloud-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getReferencedObjectsContentHash(t *testing.T) {
+	service := &v1alpha1.KogitoRuntime{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: t.Name()}}
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Env: []corev1.EnvVar{framework.CreateSecretEnvVar("PASSWORD", "credentials", "password")}},
+					},
+					Volumes: []corev1.Volume{createAppPropVolume(service)},
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: t.Name()},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	appPropsCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: getAppPropConfigMapName(service), Namespace: t.Name()},
+		Data:       map[string]string{ConfigMapApplicationPropertyKey: "a=b"},
+	}
+
+	hash, err := getReferencedObjectsContentHash(service, deployment, test.NewFakeClientBuilder().AddK8sObjects(secret, appPropsCM).Build())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hash)
+
+	appPropsCM.Data[ConfigMapApplicationPropertyKey] = "a=c"
+	sameHash, err := getReferencedObjectsContentHash(service, deployment, test.NewFakeClientBuilder().AddK8sObjects(secret, appPropsCM).Build())
+	assert.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+
+	secret.Data["password"] = []byte("rotated")
+	rotatedHash, err := getReferencedObjectsContentHash(service, deployment, test.NewFakeClientBuilder().AddK8sObjects(secret, appPropsCM).Build())
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, rotatedHash)
+}
@@ -0,0 +1,31 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/kiegroup/kogito-cloud-operator/pkg/apis/app/v1alpha1"
+)
+
+// invalidResourceNameChars matches the characters not allowed in the names of the Kubernetes resources
+var invalidResourceNameChars = regexp.MustCompile("[^a-z0-9.-]")
+
+// getServiceResourceName gets the name of a resource created for the given service, prefixed by the service name
+// to avoid clashes between services of the same namespace
+func getServiceResourceName(service v1alpha1.KogitoService, name string) string {
+	return invalidResourceNameChars.ReplaceAllString(strings.ToLower(service.GetName()+"-"+name), "-")
+}
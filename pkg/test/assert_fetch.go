@@ -32,6 +32,13 @@ func AssertFetchMustExist(t *testing.T, client *client.Client, resource meta.Res
 	assert.True(t, exists)
 }
 
+// AssertFetchMustNotExist fetches the given object and verify if it does not exist in the context without errors
+func AssertFetchMustNotExist(t *testing.T, client *client.Client, resource meta.ResourceObject) {
+	exists, err := kubernetes.ResourceC(client).Fetch(resource)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
 // AssertFetchWithKeyMustExist fetches the given object with the defined key and verify if it exists in the context without errors
 func AssertFetchWithKeyMustExist(t *testing.T, client *client.Client, resource meta.ResourceObject, instance metav1.Object) {
 	exists, err := kubernetes.ResourceC(client).FetchWithKey(types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}, resource)
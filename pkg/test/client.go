@@ -110,7 +110,7 @@ func CreateFakeClientOnOpenShift(objects []runtime.Object, imageObjs []runtime.O
 	return NewFakeClientBuilder().AddK8sObjects(objects...).AddImageObjects(imageObjs...).AddBuildObjects(buildObjs...).OnOpenShift().Build()
 }
 
-// CreateFakeDiscoveryClient creates a fake discovery client that supports prometheus, infinispan, strimzi api
+// CreateFakeDiscoveryClient creates a fake discovery client that supports prometheus, infinispan, strimzi, keycloak, postgresql, mongodb, knative eventing, grafana, jaeger, artemis api
 func (f *fakeClientStruct) createFakeDiscoveryClient() discovery.DiscoveryInterface {
 	disco := &discfake.FakeDiscovery{
 		Fake: &clienttesting.Fake{
@@ -118,6 +118,12 @@ func (f *fakeClientStruct) createFakeDiscoveryClient() discovery.DiscoveryInterf
 				{GroupVersion: "infinispan.org/v1"},
 				{GroupVersion: "kafka.strimzi.io/v1beta1"},
 				{GroupVersion: "keycloak.org/v1alpha1"},
+				{GroupVersion: "acid.zalan.do/v1"},
+				{GroupVersion: "mongodb.com/v1"},
+				{GroupVersion: "eventing.knative.dev/v1"},
+				{GroupVersion: "integreatly.org/v1alpha1"},
+				{GroupVersion: "jaegertracing.io/v1"},
+				{GroupVersion: "broker.amq.io/v1beta1"},
 			},
 		},
 	}
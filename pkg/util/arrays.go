@@ -50,3 +50,14 @@ func ContainsAll(array1 []string, array2 []string) bool {
 
 	return true
 }
+
+// RemoveFromSlice removes all the occurrences of the s string from the array
+func RemoveFromSlice(array []string, s string) []string {
+	var result []string
+	for _, item := range array {
+		if item != s {
+			result = append(result, item)
+		}
+	}
+	return result
+}
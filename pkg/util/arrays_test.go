@@ -97,3 +97,26 @@ func TestContainsAll(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveFromSlice(t *testing.T) {
+	type args struct {
+		array []string
+		s     string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{"Removed", args{[]string{"1", "2", "1", "3"}, "1"}, []string{"2", "3"}},
+		{"NotFound", args{[]string{"1", "2"}, "3"}, []string{"1", "2"}},
+		{"Empty", args{[]string{"1"}, "1"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemoveFromSlice(tt.args.array, tt.args.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RemoveFromSlice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
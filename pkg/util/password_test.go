@@ -0,0 +1,32 @@
+// Copyright 2020 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	password, err := GeneratePassword(16)
+	assert.NoError(t, err)
+	assert.Len(t, password, 16)
+	assert.Regexp(t, "^[a-zA-Z0-9]+$", password)
+
+	other, err := GeneratePassword(16)
+	assert.NoError(t, err)
+	assert.NotEqual(t, password, other)
+}
@@ -85,7 +85,7 @@ func parseKogitoInfraResource(targetResourceType string) (*v1alpha1.Resource, er
 
 // WaitForKogitoInfraResource waits for the given KogitoInfra resource to be ready
 func WaitForKogitoInfraResource(namespace, name string, timeoutInMin int) error {
-	return WaitForOnOpenshift(namespace, fmt.Sprintf("KogitoInfra %s status to be Success", name), timeoutInMin,
+	return WaitForOnOpenshift(namespace, fmt.Sprintf("KogitoInfra %s status to be Ready", name), timeoutInMin,
 		func() (bool, error) {
 			infraResource, err := getKogitoInfraResource(namespace, name)
 			if err != nil {
@@ -95,7 +95,7 @@ func WaitForKogitoInfraResource(namespace, name string, timeoutInMin int) error
 				return false, nil
 			}
 
-			return infraResource.Status.Condition.Type == "Success" && infraResource.Status.Condition.Status == "True", nil
+			return infraResource.Status.IsConditionTrue(v1alpha1.ReadyInfraConditionType), nil
 		})
 }
 